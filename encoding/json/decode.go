@@ -133,6 +133,7 @@ const (
 	labelKey        = "label"
 	parametersKey   = "parameters"
 	returnKey       = "return"
+	purityKey       = "purity"
 )
 
 func (d *Decoder) decodeJSON(v any) cadence.Value {
@@ -225,6 +226,8 @@ func (d *Decoder) decodeJSON(v any) cadence.Value {
 		return d.decodeCapability(valueJSON)
 	case enumTypeStr:
 		return d.decodeEnum(valueJSON)
+	case attachmentTypeStr:
+		return d.decodeAttachment(valueJSON)
 	}
 
 	panic(errors.NewDefaultUserError("invalid type: %s", typeStr))
@@ -823,6 +826,31 @@ func (d *Decoder) decodeEnum(valueJSON any) cadence.Enum {
 	))
 }
 
+func (d *Decoder) decodeAttachment(valueJSON any) cadence.Attachment {
+	comp := d.decodeComposite(valueJSON)
+
+	attachment, err := cadence.NewMeteredAttachment(
+		d.gauge,
+		len(comp.fieldValues),
+		func() ([]cadence.Value, error) {
+			return comp.fieldValues, nil
+		},
+	)
+
+	if err != nil {
+		panic(errors.NewDefaultUserError("invalid attachment: %w", err))
+	}
+
+	return attachment.WithType(cadence.NewMeteredAttachmentType(
+		d.gauge,
+		comp.location,
+		comp.qualifiedIdentifier,
+		nil,
+		comp.fieldTypes,
+		nil,
+	))
+}
+
 func (d *Decoder) decodeLink(valueJSON any) cadence.Link {
 	obj := toObject(valueJSON)
 
@@ -919,16 +947,34 @@ func (d *Decoder) decodeFieldType(valueJSON any, results typeDecodingResults) ca
 	)
 }
 
-func (d *Decoder) decodeFunctionType(returnValue, parametersValue, id any, results typeDecodingResults) cadence.Type {
+func (d *Decoder) decodeFunctionType(
+	returnValue, parametersValue, id, purityValue any,
+	results typeDecodingResults,
+) cadence.Type {
 	parameters := d.decodeParamTypes(toSlice(parametersValue), results)
 	returnType := d.decodeType(returnValue, results)
+	purity := d.decodePurity(purityValue)
 
 	return cadence.NewMeteredFunctionType(
 		d.gauge,
 		"",
 		parameters,
 		returnType,
-	).WithID(toString(id))
+	).WithID(toString(id)).
+		WithPurity(purity)
+}
+
+func (d *Decoder) decodePurity(purityValue any) cadence.FunctionPurity {
+	if purityValue == nil {
+		return cadence.FunctionPurityImpure
+	}
+
+	switch toString(purityValue) {
+	case functionPurityViewStr:
+		return cadence.FunctionPurityView
+	default:
+		panic(errors.NewDefaultUserError("invalid function purity: %s", purityValue))
+	}
 }
 
 func (d *Decoder) decodeNominalType(
@@ -1030,12 +1076,29 @@ func (d *Decoder) decodeNominalType(
 			inits,
 		)
 		result = compositeType
+	case "Attachment":
+		compositeType = cadence.NewMeteredAttachmentType(
+			d.gauge,
+			location,
+			qualifiedIdentifier,
+			nil,
+			nil,
+			inits,
+		)
+		result = compositeType
 	default:
 		panic(errors.NewDefaultUserError("invalid kind: %s", kind))
 	}
 
 	results[typeID] = result
 
+	// NOTE: decode the base type after recording the result,
+	// as the base type may refer to the attachment type
+
+	if attachmentType, ok := compositeType.(*cadence.AttachmentType); ok {
+		attachmentType.BaseType = d.decodeType(obj.Get(typeKey), results)
+	}
+
 	fields := d.decodeFieldTypes(fs, results)
 
 	switch {
@@ -1095,7 +1158,9 @@ func (d *Decoder) decodeType(valueJSON any, results typeDecodingResults) cadence
 		returnValue := obj.Get(returnKey)
 		parametersValue := obj.Get(parametersKey)
 		idValue := obj.Get(typeIDKey)
-		return d.decodeFunctionType(returnValue, parametersValue, idValue, results)
+		// purity is optional, impure functions omit it
+		purityValue := obj[purityKey]
+		return d.decodeFunctionType(returnValue, parametersValue, idValue, purityValue, results)
 	case "Restriction":
 		restrictionsValue := obj.Get(restrictionsKey)
 		typeIDValue := toString(obj.Get(typeIDKey))
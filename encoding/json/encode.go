@@ -192,6 +192,7 @@ type jsonParameterType struct {
 type jsonFunctionType struct {
 	Kind       string              `json:"kind"`
 	TypeID     string              `json:"typeID"`
+	Purity     string              `json:"purity,omitempty"`
 	Parameters []jsonParameterType `json:"parameters"`
 	Return     jsonValue           `json:"return"`
 }
@@ -211,44 +212,46 @@ type jsonFunctionValue struct {
 }
 
 const (
-	voidTypeStr       = "Void"
-	optionalTypeStr   = "Optional"
-	boolTypeStr       = "Bool"
-	characterTypeStr  = "Character"
-	stringTypeStr     = "String"
-	addressTypeStr    = "Address"
-	intTypeStr        = "Int"
-	int8TypeStr       = "Int8"
-	int16TypeStr      = "Int16"
-	int32TypeStr      = "Int32"
-	int64TypeStr      = "Int64"
-	int128TypeStr     = "Int128"
-	int256TypeStr     = "Int256"
-	uintTypeStr       = "UInt"
-	uint8TypeStr      = "UInt8"
-	uint16TypeStr     = "UInt16"
-	uint32TypeStr     = "UInt32"
-	uint64TypeStr     = "UInt64"
-	uint128TypeStr    = "UInt128"
-	uint256TypeStr    = "UInt256"
-	word8TypeStr      = "Word8"
-	word16TypeStr     = "Word16"
-	word32TypeStr     = "Word32"
-	word64TypeStr     = "Word64"
-	fix64TypeStr      = "Fix64"
-	ufix64TypeStr     = "UFix64"
-	arrayTypeStr      = "Array"
-	dictionaryTypeStr = "Dictionary"
-	structTypeStr     = "Struct"
-	resourceTypeStr   = "Resource"
-	eventTypeStr      = "Event"
-	contractTypeStr   = "Contract"
-	linkTypeStr       = "Link"
-	pathTypeStr       = "Path"
-	typeTypeStr       = "Type"
-	capabilityTypeStr = "Capability"
-	enumTypeStr       = "Enum"
-	functionTypeStr   = "Function"
+	voidTypeStr           = "Void"
+	optionalTypeStr       = "Optional"
+	boolTypeStr           = "Bool"
+	characterTypeStr      = "Character"
+	stringTypeStr         = "String"
+	addressTypeStr        = "Address"
+	intTypeStr            = "Int"
+	int8TypeStr           = "Int8"
+	int16TypeStr          = "Int16"
+	int32TypeStr          = "Int32"
+	int64TypeStr          = "Int64"
+	int128TypeStr         = "Int128"
+	int256TypeStr         = "Int256"
+	uintTypeStr           = "UInt"
+	uint8TypeStr          = "UInt8"
+	uint16TypeStr         = "UInt16"
+	uint32TypeStr         = "UInt32"
+	uint64TypeStr         = "UInt64"
+	uint128TypeStr        = "UInt128"
+	uint256TypeStr        = "UInt256"
+	word8TypeStr          = "Word8"
+	word16TypeStr         = "Word16"
+	word32TypeStr         = "Word32"
+	word64TypeStr         = "Word64"
+	fix64TypeStr          = "Fix64"
+	ufix64TypeStr         = "UFix64"
+	arrayTypeStr          = "Array"
+	dictionaryTypeStr     = "Dictionary"
+	structTypeStr         = "Struct"
+	resourceTypeStr       = "Resource"
+	eventTypeStr          = "Event"
+	contractTypeStr       = "Contract"
+	linkTypeStr           = "Link"
+	pathTypeStr           = "Path"
+	typeTypeStr           = "Type"
+	capabilityTypeStr     = "Capability"
+	enumTypeStr           = "Enum"
+	attachmentTypeStr     = "Attachment"
+	functionTypeStr       = "Function"
+	functionPurityViewStr = "view"
 )
 
 // Prepare traverses the object graph of the provided value and constructs
@@ -329,6 +332,8 @@ func Prepare(v cadence.Value) jsonValue {
 		return prepareCapability(x)
 	case cadence.Enum:
 		return prepareEnum(x)
+	case cadence.Attachment:
+		return prepareAttachment(x)
 	case cadence.Function:
 		return prepareFunction(x)
 	default:
@@ -570,6 +575,10 @@ func prepareEnum(v cadence.Enum) jsonValue {
 	return prepareComposite(enumTypeStr, v.EnumType.ID(), v.EnumType.Fields, v.Fields)
 }
 
+func prepareAttachment(v cadence.Attachment) jsonValue {
+	return prepareComposite(attachmentTypeStr, v.AttachmentType.ID(), v.AttachmentType.Fields, v.Fields)
+}
+
 func prepareComposite(kind, id string, fieldTypes []cadence.Field, fields []cadence.Value) jsonValue {
 	if len(fieldTypes) != len(fields) {
 		panic(fmt.Errorf(
@@ -816,6 +825,7 @@ func prepareType(typ cadence.Type, results typePreparationResults) jsonValue {
 		return jsonFunctionType{
 			Kind:       "Function",
 			TypeID:     typ.ID(),
+			Purity:     preparePurity(typ.Purity),
 			Return:     prepareType(typ.ReturnType, results),
 			Parameters: prepareParameters(typ.Parameters, results),
 		}
@@ -849,6 +859,14 @@ func prepareType(typ cadence.Type, results typePreparationResults) jsonValue {
 			Initializers: prepareInitializers(typ.Initializers, results),
 			Type:         prepareType(typ.RawType, results),
 		}
+	case *cadence.AttachmentType:
+		return jsonNominalType{
+			Kind:         "Attachment",
+			TypeID:       typeId(typ.Location, typ.QualifiedIdentifier),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
+			Type:         prepareType(typ.BaseType, results),
+		}
 	case nil:
 		return ""
 	default:
@@ -878,6 +896,15 @@ func prepareCapability(capability cadence.Capability) jsonValue {
 	}
 }
 
+func preparePurity(purity cadence.FunctionPurity) string {
+	switch purity {
+	case cadence.FunctionPurityView:
+		return functionPurityViewStr
+	default:
+		return ""
+	}
+}
+
 func prepareFunction(function cadence.Function) jsonValue {
 	return jsonValueObject{
 		Type: functionTypeStr,
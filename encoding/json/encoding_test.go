@@ -1635,6 +1635,51 @@ func TestEncodeContract(t *testing.T) {
 	testAllEncodeAndDecode(t, simpleContract, resourceContract)
 }
 
+func TestEncodeAttachment(t *testing.T) {
+
+	t.Parallel()
+
+	simpleAttachmentType := &cadence.AttachmentType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooAttachment",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+		},
+	}
+
+	simpleAttachment := encodeTest{
+		"Simple",
+		cadence.NewAttachment(
+			[]cadence.Value{
+				cadence.NewInt(1),
+			},
+		).WithType(simpleAttachmentType),
+		// language=json
+		`
+          {
+            "type": "Attachment",
+            "value": {
+              "id": "S.test.FooAttachment",
+              "fields": [
+                {
+                  "name": "a",
+                  "value": {
+                    "type": "Int",
+                    "value": "1"
+                  }
+                }
+              ]
+            }
+          }
+        `,
+	}
+
+	testAllEncodeAndDecode(t, simpleAttachment)
+}
+
 func TestEncodeLink(t *testing.T) {
 
 	t.Parallel()
@@ -2337,6 +2382,57 @@ func TestEncodeType(t *testing.T) {
 		)
 	})
 
+	t.Run("with static attachment", func(t *testing.T) {
+
+		testEncodeAndDecode(
+			t,
+			cadence.TypeValue{
+				StaticType: &cadence.AttachmentType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "A",
+					BaseType: &cadence.StructType{
+						Location:            utils.TestLocation,
+						QualifiedIdentifier: "S",
+						Fields:              []cadence.Field{},
+						Initializers:        [][]cadence.Parameter{},
+					},
+					Fields: []cadence.Field{
+						{Identifier: "foo", Type: cadence.IntType{}},
+					},
+					Initializers: [][]cadence.Parameter{},
+				},
+			},
+			// language=json
+			`
+              {
+                "type": "Type",
+                "value": {
+                  "staticType": {
+                    "kind": "Attachment",
+                    "type": {
+                      "kind": "Struct",
+                      "type": "",
+                      "typeID": "S.test.S",
+                      "fields": [],
+                      "initializers": []
+                    },
+                    "typeID": "S.test.A",
+                    "fields": [
+                      {
+                        "id": "foo",
+                        "type": {
+                          "kind": "Int"
+                        }
+                      }
+                    ],
+                    "initializers": []
+                  }
+                }
+              }
+            `,
+		)
+	})
+
 	t.Run("with static &int", func(t *testing.T) {
 
 		testEncodeAndDecode(
@@ -2406,6 +2502,48 @@ func TestEncodeType(t *testing.T) {
 
 	})
 
+	t.Run("with static view function", func(t *testing.T) {
+
+		testEncodeAndDecode(
+			t,
+			cadence.TypeValue{
+				StaticType: (&cadence.FunctionType{
+					Purity: cadence.FunctionPurityView,
+					Parameters: []cadence.Parameter{
+						{Label: "qux", Identifier: "baz", Type: cadence.StringType{}},
+					},
+					ReturnType: cadence.IntType{},
+				}).WithID("Foo"),
+			},
+			// language=json
+			`
+              {
+                "type": "Type",
+                "value": {
+                  "staticType": {
+                    "kind": "Function",
+                    "typeID": "Foo",
+                    "purity": "view",
+                    "return": {
+                      "kind": "Int"
+                    },
+                    "parameters": [
+                      {
+                        "label": "qux",
+                        "id": "baz",
+                        "type": {
+                          "kind": "String"
+                        }
+                      }
+                    ]
+                  }
+                }
+              }
+            `,
+		)
+
+	})
+
 	t.Run("with static Capability<Int>", func(t *testing.T) {
 
 		testEncodeAndDecode(
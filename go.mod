@@ -3,7 +3,6 @@ module github.com/onflow/cadence
 go 1.18
 
 require (
-	github.com/bits-and-blooms/bitset v1.2.2
 	github.com/bytecodealliance/wasmtime-go v0.40.0
 	github.com/c-bata/go-prompt v0.2.5
 	github.com/fxamacker/cbor/v2 v2.4.1-0.20220515183430-ad2eae63303f
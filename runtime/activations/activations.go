@@ -97,6 +97,29 @@ func (a *Activation[T]) FunctionValues() map[string]T {
 	return values
 }
 
+// ForEach calls the given function for each name-value pair in the activation and its parents.
+// Names declared in parents which are shadowed by an inner activation are skipped.
+// The iteration order within an activation is undefined.
+func (a *Activation[T]) ForEach(f func(name string, value T)) {
+
+	seen := map[string]struct{}{}
+
+	current := a
+
+	for current != nil {
+
+		for name, value := range current.entries { //nolint:maprangecheck
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			f(name, value)
+		}
+
+		current = current.Parent
+	}
+}
+
 // Set sets the given name-value pair in the activation.
 func (a *Activation[T]) Set(name string, value T) {
 	if a.entries == nil {
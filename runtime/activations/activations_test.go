@@ -71,3 +71,32 @@ func TestActivations(t *testing.T) {
 	assert.Zero(t, activations.Find("b"))
 	assert.Zero(t, activations.Find("c"))
 }
+
+func TestActivationForEach(t *testing.T) {
+
+	t.Parallel()
+
+	parent := NewActivation[int](nil, nil)
+	parent.Set("a", 1)
+	parent.Set("b", 2)
+
+	child := NewActivation(nil, parent)
+	child.Set("a", 3)
+	child.Set("c", 4)
+
+	values := map[string]int{}
+	child.ForEach(func(name string, value int) {
+		_, ok := values[name]
+		assert.False(t, ok)
+		values[name] = value
+	})
+
+	assert.Equal(t,
+		map[string]int{
+			"a": 3,
+			"b": 2,
+			"c": 4,
+		},
+		values,
+	)
+}
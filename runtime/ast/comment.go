@@ -0,0 +1,56 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Comment is a line comment (`// ...`) or a block comment (`/* ... */`) in the source code.
+//
+// Comments are only recorded by the parser if enabled, see Program.Comments
+type Comment struct {
+	// Text is the source code of the comment, including the delimiters
+	Text string
+	Range
+}
+
+func NewComment(memoryGauge common.MemoryGauge, text string, commentRange Range) *Comment {
+	common.UseMemory(memoryGauge, common.CommentMemoryUsage)
+	return &Comment{
+		Text:  text,
+		Range: commentRange,
+	}
+}
+
+// IsBlock returns true if the comment is a block comment
+func (c *Comment) IsBlock() bool {
+	return strings.HasPrefix(c.Text, "/*")
+}
+
+// IsDocString returns true if the comment is a docstring,
+// i.e. a line comment starting with `///`, or a block comment starting with `/**`
+func (c *Comment) IsDocString() bool {
+	return (strings.HasPrefix(c.Text, "///") ||
+		strings.HasPrefix(c.Text, "/**")) &&
+		// `/**/` is an empty block comment
+		c.Text != "/**/"
+}
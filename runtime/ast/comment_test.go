@@ -0,0 +1,47 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComment_Kind(t *testing.T) {
+
+	t.Parallel()
+
+	for _, test := range []struct {
+		text        string
+		isBlock     bool
+		isDocString bool
+	}{
+		{text: "// a"},
+		{text: "/// a", isDocString: true},
+		{text: "/* a */", isBlock: true},
+		{text: "/** a */", isBlock: true, isDocString: true},
+		{text: "/**/", isBlock: true},
+	} {
+		comment := NewComment(nil, test.text, Range{})
+
+		assert.Equal(t, test.isBlock, comment.IsBlock(), test.text)
+		assert.Equal(t, test.isDocString, comment.IsDocString(), test.text)
+	}
+}
@@ -29,15 +29,18 @@ import (
 
 // CompositeDeclaration
 
-// NOTE: For events, only an empty initializer is declared
+// NOTE: For events, only an empty initializer is declared.
+// For attachments, the base type is the type the attachment is declared for
 
 type CompositeDeclaration struct {
-	Access        Access
-	CompositeKind common.CompositeKind
-	Identifier    Identifier
-	Conformances  []*NominalType
-	Members       *Members
-	DocString     string
+	Access            Access
+	CompositeKind     common.CompositeKind
+	Identifier        Identifier
+	TypeParameterList *TypeParameterList `json:",omitempty"`
+	BaseType          *NominalType       `json:",omitempty"`
+	Conformances      []*NominalType
+	Members           *Members
+	DocString         string
 	Range
 }
 
@@ -50,6 +53,8 @@ func NewCompositeDeclaration(
 	access Access,
 	compositeKind common.CompositeKind,
 	identifier Identifier,
+	typeParameterList *TypeParameterList,
+	baseType *NominalType,
 	conformances []*NominalType,
 	members *Members,
 	docString string,
@@ -58,13 +63,15 @@ func NewCompositeDeclaration(
 	common.UseMemory(memoryGauge, common.CompositeDeclarationMemoryUsage)
 
 	return &CompositeDeclaration{
-		Access:        access,
-		CompositeKind: compositeKind,
-		Identifier:    identifier,
-		Conformances:  conformances,
-		Members:       members,
-		DocString:     docString,
-		Range:         declarationRange,
+		Access:            access,
+		CompositeKind:     compositeKind,
+		Identifier:        identifier,
+		TypeParameterList: typeParameterList,
+		BaseType:          baseType,
+		Conformances:      conformances,
+		Members:           members,
+		DocString:         docString,
+		Range:             declarationRange,
 	}
 }
 
@@ -124,6 +131,8 @@ func (d *CompositeDeclaration) Doc() prettier.Doc {
 		d.CompositeKind,
 		false,
 		d.Identifier.Identifier,
+		d.TypeParameterList,
+		d.BaseType,
 		d.Conformances,
 		d.Members,
 	)
@@ -163,6 +172,7 @@ func (d *CompositeDeclaration) String() string {
 }
 
 var interfaceKeywordSpaceDoc = prettier.Text("interface ")
+var attachmentForKeywordDoc = prettier.Text(" for ")
 var compositeConformancesSeparatorDoc = prettier.Text(":")
 var compositeConformanceSeparatorDoc prettier.Doc = prettier.Concat{
 	prettier.Text(","),
@@ -174,6 +184,8 @@ func CompositeDocument(
 	kind common.CompositeKind,
 	isInterface bool,
 	identifier string,
+	typeParameterList *TypeParameterList,
+	baseType *NominalType,
 	conformances []*NominalType,
 	members *Members,
 ) prettier.Doc {
@@ -206,6 +218,21 @@ func CompositeDocument(
 		prettier.Text(identifier),
 	)
 
+	if !typeParameterList.IsEmpty() {
+		doc = append(
+			doc,
+			typeParameterList.Doc(),
+		)
+	}
+
+	if baseType != nil {
+		doc = append(
+			doc,
+			attachmentForKeywordDoc,
+			baseType.Doc(),
+		)
+	}
+
 	if len(conformances) > 0 {
 
 		conformancesDoc := prettier.Concat{
@@ -38,6 +38,7 @@ const (
 	ElementTypeFieldDeclaration
 	ElementTypeEnumCaseDeclaration
 	ElementTypePragmaDeclaration
+	ElementTypeTypeAliasDeclaration
 	ElementTypeImportDeclaration
 	ElementTypeTransactionDeclaration
 
@@ -55,6 +56,7 @@ const (
 	ElementTypeAssignmentStatement
 	ElementTypeSwapStatement
 	ElementTypeExpressionStatement
+	ElementTypeRemoveStatement
 
 	// Expressions
 
@@ -80,4 +82,6 @@ const (
 	ElementTypeReferenceExpression
 	ElementTypeForceExpression
 	ElementTypePathExpression
+	ElementTypeAttachExpression
+	ElementTypeStringTemplateExpression
 )
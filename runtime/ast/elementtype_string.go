@@ -19,47 +19,51 @@ func _() {
 	_ = x[ElementTypeFieldDeclaration-8]
 	_ = x[ElementTypeEnumCaseDeclaration-9]
 	_ = x[ElementTypePragmaDeclaration-10]
-	_ = x[ElementTypeImportDeclaration-11]
-	_ = x[ElementTypeTransactionDeclaration-12]
-	_ = x[ElementTypeReturnStatement-13]
-	_ = x[ElementTypeBreakStatement-14]
-	_ = x[ElementTypeContinueStatement-15]
-	_ = x[ElementTypeIfStatement-16]
-	_ = x[ElementTypeSwitchStatement-17]
-	_ = x[ElementTypeWhileStatement-18]
-	_ = x[ElementTypeForStatement-19]
-	_ = x[ElementTypeEmitStatement-20]
-	_ = x[ElementTypeVariableDeclaration-21]
-	_ = x[ElementTypeAssignmentStatement-22]
-	_ = x[ElementTypeSwapStatement-23]
-	_ = x[ElementTypeExpressionStatement-24]
-	_ = x[ElementTypeVoidExpression-25]
-	_ = x[ElementTypeBoolExpression-26]
-	_ = x[ElementTypeNilExpression-27]
-	_ = x[ElementTypeIntegerExpression-28]
-	_ = x[ElementTypeFixedPointExpression-29]
-	_ = x[ElementTypeArrayExpression-30]
-	_ = x[ElementTypeDictionaryExpression-31]
-	_ = x[ElementTypeIdentifierExpression-32]
-	_ = x[ElementTypeInvocationExpression-33]
-	_ = x[ElementTypeMemberExpression-34]
-	_ = x[ElementTypeIndexExpression-35]
-	_ = x[ElementTypeConditionalExpression-36]
-	_ = x[ElementTypeUnaryExpression-37]
-	_ = x[ElementTypeBinaryExpression-38]
-	_ = x[ElementTypeFunctionExpression-39]
-	_ = x[ElementTypeStringExpression-40]
-	_ = x[ElementTypeCastingExpression-41]
-	_ = x[ElementTypeCreateExpression-42]
-	_ = x[ElementTypeDestroyExpression-43]
-	_ = x[ElementTypeReferenceExpression-44]
-	_ = x[ElementTypeForceExpression-45]
-	_ = x[ElementTypePathExpression-46]
+	_ = x[ElementTypeTypeAliasDeclaration-11]
+	_ = x[ElementTypeImportDeclaration-12]
+	_ = x[ElementTypeTransactionDeclaration-13]
+	_ = x[ElementTypeReturnStatement-14]
+	_ = x[ElementTypeBreakStatement-15]
+	_ = x[ElementTypeContinueStatement-16]
+	_ = x[ElementTypeIfStatement-17]
+	_ = x[ElementTypeSwitchStatement-18]
+	_ = x[ElementTypeWhileStatement-19]
+	_ = x[ElementTypeForStatement-20]
+	_ = x[ElementTypeEmitStatement-21]
+	_ = x[ElementTypeVariableDeclaration-22]
+	_ = x[ElementTypeAssignmentStatement-23]
+	_ = x[ElementTypeSwapStatement-24]
+	_ = x[ElementTypeExpressionStatement-25]
+	_ = x[ElementTypeRemoveStatement-26]
+	_ = x[ElementTypeVoidExpression-27]
+	_ = x[ElementTypeBoolExpression-28]
+	_ = x[ElementTypeNilExpression-29]
+	_ = x[ElementTypeIntegerExpression-30]
+	_ = x[ElementTypeFixedPointExpression-31]
+	_ = x[ElementTypeArrayExpression-32]
+	_ = x[ElementTypeDictionaryExpression-33]
+	_ = x[ElementTypeIdentifierExpression-34]
+	_ = x[ElementTypeInvocationExpression-35]
+	_ = x[ElementTypeMemberExpression-36]
+	_ = x[ElementTypeIndexExpression-37]
+	_ = x[ElementTypeConditionalExpression-38]
+	_ = x[ElementTypeUnaryExpression-39]
+	_ = x[ElementTypeBinaryExpression-40]
+	_ = x[ElementTypeFunctionExpression-41]
+	_ = x[ElementTypeStringExpression-42]
+	_ = x[ElementTypeCastingExpression-43]
+	_ = x[ElementTypeCreateExpression-44]
+	_ = x[ElementTypeDestroyExpression-45]
+	_ = x[ElementTypeReferenceExpression-46]
+	_ = x[ElementTypeForceExpression-47]
+	_ = x[ElementTypePathExpression-48]
+	_ = x[ElementTypeAttachExpression-49]
+	_ = x[ElementTypeStringTemplateExpression-50]
 }
 
-const _ElementType_name = "ElementTypeUnknownElementTypeProgramElementTypeBlockElementTypeFunctionBlockElementTypeFunctionDeclarationElementTypeSpecialFunctionDeclarationElementTypeCompositeDeclarationElementTypeInterfaceDeclarationElementTypeFieldDeclarationElementTypeEnumCaseDeclarationElementTypePragmaDeclarationElementTypeImportDeclarationElementTypeTransactionDeclarationElementTypeReturnStatementElementTypeBreakStatementElementTypeContinueStatementElementTypeIfStatementElementTypeSwitchStatementElementTypeWhileStatementElementTypeForStatementElementTypeEmitStatementElementTypeVariableDeclarationElementTypeAssignmentStatementElementTypeSwapStatementElementTypeExpressionStatementElementTypeVoidExpressionElementTypeBoolExpressionElementTypeNilExpressionElementTypeIntegerExpressionElementTypeFixedPointExpressionElementTypeArrayExpressionElementTypeDictionaryExpressionElementTypeIdentifierExpressionElementTypeInvocationExpressionElementTypeMemberExpressionElementTypeIndexExpressionElementTypeConditionalExpressionElementTypeUnaryExpressionElementTypeBinaryExpressionElementTypeFunctionExpressionElementTypeStringExpressionElementTypeCastingExpressionElementTypeCreateExpressionElementTypeDestroyExpressionElementTypeReferenceExpressionElementTypeForceExpressionElementTypePathExpression"
+const _ElementType_name = "ElementTypeUnknownElementTypeProgramElementTypeBlockElementTypeFunctionBlockElementTypeFunctionDeclarationElementTypeSpecialFunctionDeclarationElementTypeCompositeDeclarationElementTypeInterfaceDeclarationElementTypeFieldDeclarationElementTypeEnumCaseDeclarationElementTypePragmaDeclarationElementTypeTypeAliasDeclarationElementTypeImportDeclarationElementTypeTransactionDeclarationElementTypeReturnStatementElementTypeBreakStatementElementTypeContinueStatementElementTypeIfStatementElementTypeSwitchStatementElementTypeWhileStatementElementTypeForStatementElementTypeEmitStatementElementTypeVariableDeclarationElementTypeAssignmentStatementElementTypeSwapStatementElementTypeExpressionStatementElementTypeRemoveStatementElementTypeVoidExpressionElementTypeBoolExpressionElementTypeNilExpressionElementTypeIntegerExpressionElementTypeFixedPointExpressionElementTypeArrayExpressionElementTypeDictionaryExpressionElementTypeIdentifierExpressionElementTypeInvocationExpressionElementTypeMemberExpressionElementTypeIndexExpressionElementTypeConditionalExpressionElementTypeUnaryExpressionElementTypeBinaryExpressionElementTypeFunctionExpressionElementTypeStringExpressionElementTypeCastingExpressionElementTypeCreateExpressionElementTypeDestroyExpressionElementTypeReferenceExpressionElementTypeForceExpressionElementTypePathExpressionElementTypeAttachExpressionElementTypeStringTemplateExpression"
 
-var _ElementType_index = [...]uint16{0, 18, 36, 52, 76, 106, 143, 174, 205, 232, 262, 290, 318, 351, 377, 402, 430, 452, 478, 503, 526, 550, 580, 610, 634, 664, 689, 714, 738, 766, 797, 823, 854, 885, 916, 943, 969, 1001, 1027, 1054, 1083, 1110, 1138, 1165, 1193, 1223, 1249, 1274}
+var _ElementType_index = [...]uint16{0, 18, 36, 52, 76, 106, 143, 174, 205, 232, 262, 290, 321, 349, 382, 408, 433, 461, 483, 509, 534, 557, 581, 611, 641, 665, 695, 721, 746, 771, 795, 823, 854, 880, 911, 942, 973, 1000, 1026, 1058, 1084, 1111, 1140, 1167, 1195, 1222, 1250, 1280, 1306, 1331, 1358, 1393}
 
 func (i ElementType) String() string {
 	if i >= ElementType(len(_ElementType_index)-1) {
@@ -220,6 +220,100 @@ func (*StringExpression) precedence() precedence {
 	return precedenceLiteral
 }
 
+// StringTemplateExpression
+
+// StringTemplateExpression is a string literal with interpolated expressions,
+// e.g. `"Hello, \(name)!"`.
+//
+// Values contains the literal parts of the template,
+// and always has one more element than Expressions:
+// The template is the concatenation of the values,
+// with the expressions interleaved.
+type StringTemplateExpression struct {
+	Values      []string
+	Expressions []Expression
+	Range
+}
+
+var _ Element = &StringTemplateExpression{}
+var _ Expression = &StringTemplateExpression{}
+
+func NewStringTemplateExpression(
+	gauge common.MemoryGauge,
+	values []string,
+	expressions []Expression,
+	exprRange Range,
+) *StringTemplateExpression {
+	common.UseMemory(gauge, common.NewStringTemplateExpressionMemoryUsage(len(expressions)))
+	return &StringTemplateExpression{
+		Values:      values,
+		Expressions: expressions,
+		Range:       exprRange,
+	}
+}
+
+func (*StringTemplateExpression) ElementType() ElementType {
+	return ElementTypeStringTemplateExpression
+}
+
+func (*StringTemplateExpression) isExpression() {}
+
+func (*StringTemplateExpression) isIfStatementTest() {}
+
+func (e *StringTemplateExpression) Walk(walkChild func(Element)) {
+	walkExpressions(walkChild, e.Expressions)
+}
+
+func (e *StringTemplateExpression) String() string {
+	return Prettier(e)
+}
+
+const stringTemplateInterpolationStart = `\(`
+const stringTemplateInterpolationEnd = ")"
+
+func (e *StringTemplateExpression) Doc() prettier.Doc {
+	var b strings.Builder
+	docs := make(prettier.Concat, 0, len(e.Expressions)*2+1)
+
+	b.WriteByte('"')
+
+	for i, value := range e.Values {
+		writeEscapedString(&b, value)
+
+		if i >= len(e.Expressions) {
+			break
+		}
+
+		b.WriteString(stringTemplateInterpolationStart)
+		docs = append(
+			docs,
+			prettier.Text(b.String()),
+			e.Expressions[i].Doc(),
+		)
+		b.Reset()
+		b.WriteString(stringTemplateInterpolationEnd)
+	}
+
+	b.WriteByte('"')
+
+	return append(docs, prettier.Text(b.String()))
+}
+
+func (e *StringTemplateExpression) MarshalJSON() ([]byte, error) {
+	type Alias StringTemplateExpression
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "StringTemplateExpression",
+		Alias: (*Alias)(e),
+	})
+}
+
+func (*StringTemplateExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 // IntegerExpression
 
 type IntegerExpression struct {
@@ -862,11 +956,22 @@ func (e *MemberExpression) Doc() prettier.Doc {
 		separatorDoc = memberExpressionSeparatorDoc
 	}
 
-	return prettier.Concat{
-		parenthesizedExpressionDoc(
+	var expressionDoc prettier.Doc
+	if _, ok := e.Expression.(*IntegerExpression); ok {
+		// The separator would be parsed as the decimal point of a fixed-point literal
+		expressionDoc = prettier.WrapParentheses(
+			e.Expression.Doc(),
+			prettier.SoftLine{},
+		)
+	} else {
+		expressionDoc = parenthesizedExpressionDoc(
 			e.Expression,
 			e.precedence(),
-		),
+		)
+	}
+
+	return prettier.Concat{
+		expressionDoc,
 		prettier.Group{
 			Doc: prettier.Indent{
 				Doc: prettier.Concat{
@@ -1341,6 +1446,7 @@ func (e *BinaryExpression) IsLeftAssociative() bool {
 // FunctionExpression
 
 type FunctionExpression struct {
+	Purity               FunctionPurity `json:",omitempty"`
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
@@ -1352,6 +1458,7 @@ var _ Expression = &FunctionExpression{}
 
 func NewFunctionExpression(
 	gauge common.MemoryGauge,
+	purity FunctionPurity,
 	parameters *ParameterList,
 	returnType *TypeAnnotation,
 	functionBlock *FunctionBlock,
@@ -1360,6 +1467,7 @@ func NewFunctionExpression(
 	common.UseMemory(gauge, common.FunctionExpressionMemoryUsage)
 
 	return &FunctionExpression{
+		Purity:               purity,
 		ParameterList:        parameters,
 		ReturnTypeAnnotation: returnType,
 		FunctionBlock:        functionBlock,
@@ -1391,14 +1499,24 @@ var functionExpressionEmptyBlockDoc prettier.Doc = prettier.Text(" {}")
 
 func FunctionDocument(
 	access Access,
+	purity FunctionPurity,
 	includeKeyword bool,
 	identifier string,
+	typeParameterList *TypeParameterList,
 	parameterList *ParameterList,
 	returnTypeAnnotation *TypeAnnotation,
 	block *FunctionBlock,
 ) prettier.Doc {
 
 	var signatureDoc prettier.Concat
+
+	if !typeParameterList.IsEmpty() {
+		signatureDoc = append(
+			signatureDoc,
+			typeParameterList.Doc(),
+		)
+	}
+
 	if parameterList != nil {
 		signatureDoc = append(
 			signatureDoc,
@@ -1426,6 +1544,14 @@ func FunctionDocument(
 		)
 	}
 
+	if purity != FunctionPurityUnspecified {
+		doc = append(
+			doc,
+			prettier.Text(purity.Keyword()),
+			prettier.Space,
+		)
+	}
+
 	if includeKeyword {
 		doc = append(
 			doc,
@@ -1465,8 +1591,10 @@ func FunctionDocument(
 func (e *FunctionExpression) Doc() prettier.Doc {
 	return FunctionDocument(
 		AccessNotSpecified,
+		e.Purity,
 		true,
 		"",
+		nil,
 		e.ParameterList,
 		e.ReturnTypeAnnotation,
 		e.FunctionBlock,
@@ -1737,6 +1865,89 @@ func (*DestroyExpression) precedence() precedence {
 	return precedenceUnaryPrefix
 }
 
+// AttachExpression
+
+type AttachExpression struct {
+	Base       Expression
+	Attachment *InvocationExpression
+	StartPos   Position `json:"-"`
+}
+
+var _ Element = &AttachExpression{}
+var _ Expression = &AttachExpression{}
+
+func NewAttachExpression(
+	gauge common.MemoryGauge,
+	base Expression,
+	attachment *InvocationExpression,
+	startPos Position,
+) *AttachExpression {
+	common.UseMemory(gauge, common.AttachExpressionMemoryUsage)
+
+	return &AttachExpression{
+		Base:       base,
+		Attachment: attachment,
+		StartPos:   startPos,
+	}
+}
+
+func (*AttachExpression) ElementType() ElementType {
+	return ElementTypeAttachExpression
+}
+
+func (*AttachExpression) isExpression() {}
+
+func (*AttachExpression) isIfStatementTest() {}
+
+func (e *AttachExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Attachment)
+	walkChild(e.Base)
+}
+
+func (e *AttachExpression) String() string {
+	return Prettier(e)
+}
+
+const attachExpressionKeywordDoc = prettier.Text("attach ")
+const attachExpressionToKeywordDoc = prettier.Text(" to ")
+
+func (e *AttachExpression) Doc() prettier.Doc {
+	return prettier.Concat{
+		attachExpressionKeywordDoc,
+		e.Attachment.Doc(),
+		attachExpressionToKeywordDoc,
+		parenthesizedExpressionDoc(
+			e.Base,
+			e.precedence(),
+		),
+	}
+}
+
+func (e *AttachExpression) StartPosition() Position {
+	return e.StartPos
+}
+
+func (e *AttachExpression) EndPosition(memoryGauge common.MemoryGauge) Position {
+	return e.Base.EndPosition(memoryGauge)
+}
+
+func (e *AttachExpression) MarshalJSON() ([]byte, error) {
+	type Alias AttachExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "AttachExpression",
+		Range: NewUnmeteredRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (*AttachExpression) precedence() precedence {
+	return precedenceUnaryPrefix
+}
+
 // ReferenceExpression
 
 type ReferenceExpression struct {
@@ -48,6 +48,10 @@ type StringExtractor interface {
 	ExtractString(extractor *ExpressionExtractor, expression *StringExpression) ExpressionExtraction
 }
 
+type StringTemplateExtractor interface {
+	ExtractStringTemplate(extractor *ExpressionExtractor, expression *StringTemplateExpression) ExpressionExtraction
+}
+
 type ArrayExtractor interface {
 	ExtractArray(extractor *ExpressionExtractor, expression *ArrayExpression) ExpressionExtraction
 }
@@ -112,31 +116,37 @@ type PathExtractor interface {
 	ExtractPath(extractor *ExpressionExtractor, expression *PathExpression) ExpressionExtraction
 }
 
+type AttachExtractor interface {
+	ExtractAttach(extractor *ExpressionExtractor, expression *AttachExpression) ExpressionExtraction
+}
+
 type ExpressionExtractor struct {
-	nextIdentifier       int
-	VoidExtractor        VoidExtractor
-	BoolExtractor        BoolExtractor
-	NilExtractor         NilExtractor
-	IntExtractor         IntExtractor
-	FixedPointExtractor  FixedPointExtractor
-	StringExtractor      StringExtractor
-	ArrayExtractor       ArrayExtractor
-	DictionaryExtractor  DictionaryExtractor
-	IdentifierExtractor  IdentifierExtractor
-	InvocationExtractor  InvocationExtractor
-	MemberExtractor      MemberExtractor
-	IndexExtractor       IndexExtractor
-	ConditionalExtractor ConditionalExtractor
-	UnaryExtractor       UnaryExtractor
-	BinaryExtractor      BinaryExtractor
-	FunctionExtractor    FunctionExtractor
-	CastingExtractor     CastingExtractor
-	CreateExtractor      CreateExtractor
-	DestroyExtractor     DestroyExtractor
-	ReferenceExtractor   ReferenceExtractor
-	ForceExtractor       ForceExtractor
-	PathExtractor        PathExtractor
-	MemoryGauge          common.MemoryGauge
+	nextIdentifier          int
+	VoidExtractor           VoidExtractor
+	BoolExtractor           BoolExtractor
+	NilExtractor            NilExtractor
+	IntExtractor            IntExtractor
+	FixedPointExtractor     FixedPointExtractor
+	StringExtractor         StringExtractor
+	StringTemplateExtractor StringTemplateExtractor
+	ArrayExtractor          ArrayExtractor
+	DictionaryExtractor     DictionaryExtractor
+	IdentifierExtractor     IdentifierExtractor
+	InvocationExtractor     InvocationExtractor
+	MemberExtractor         MemberExtractor
+	IndexExtractor          IndexExtractor
+	ConditionalExtractor    ConditionalExtractor
+	UnaryExtractor          UnaryExtractor
+	BinaryExtractor         BinaryExtractor
+	FunctionExtractor       FunctionExtractor
+	CastingExtractor        CastingExtractor
+	CreateExtractor         CreateExtractor
+	DestroyExtractor        DestroyExtractor
+	ReferenceExtractor      ReferenceExtractor
+	ForceExtractor          ForceExtractor
+	PathExtractor           PathExtractor
+	AttachExtractor         AttachExtractor
+	MemoryGauge             common.MemoryGauge
 }
 
 var _ ExpressionVisitor[ExpressionExtraction] = &ExpressionExtractor{}
@@ -266,6 +276,35 @@ func (extractor *ExpressionExtractor) ExtractString(expression *StringExpression
 	return rewriteExpressionAsIs(expression)
 }
 
+func (extractor *ExpressionExtractor) VisitStringTemplateExpression(expression *StringTemplateExpression) ExpressionExtraction {
+
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.StringTemplateExtractor != nil {
+		return extractor.StringTemplateExtractor.ExtractStringTemplate(extractor, expression)
+	}
+	return extractor.ExtractStringTemplate(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractStringTemplate(expression *StringTemplateExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite all interpolated expressions
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions(expression.Expressions)
+
+	newExpression.Expressions = rewrittenExpressions
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitArrayExpression(expression *ArrayExpression) ExpressionExtraction {
 
 	// delegate to child extractor, if any,
@@ -781,3 +820,50 @@ func (extractor *ExpressionExtractor) VisitPathExpression(expression *PathExpres
 func (extractor *ExpressionExtractor) ExtractPath(expression *PathExpression) ExpressionExtraction {
 	return rewriteExpressionAsIs(expression)
 }
+
+func (extractor *ExpressionExtractor) VisitAttachExpression(expression *AttachExpression) ExpressionExtraction {
+
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.AttachExtractor != nil {
+		return extractor.AttachExtractor.ExtractAttach(extractor, expression)
+	}
+	return extractor.ExtractAttach(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractAttach(expression *AttachExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite the attachment and base sub-expressions
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions([]Expression{
+			newExpression.Attachment,
+			newExpression.Base,
+		})
+
+	attachment, ok := rewrittenExpressions[0].(*InvocationExpression)
+	if !ok {
+		// Edge-case:
+		// The rewritten expression returned from the extractor may not be an InvocationExpression,
+		// but an expression of another type.
+		//
+		// Wrap the rewritten expression in an InvocationExpression.
+
+		attachment = &InvocationExpression{
+			InvokedExpression: rewrittenExpressions[0],
+			EndPos:            rewrittenExpressions[0].EndPosition(extractor.MemoryGauge),
+		}
+	}
+
+	newExpression.Attachment = attachment
+	newExpression.Base = rewrittenExpressions[1]
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
@@ -180,6 +180,90 @@ func TestStringExpression_String(t *testing.T) {
 	)
 }
 
+func TestStringTemplateExpression_MarshalJSON(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &StringTemplateExpression{
+		Values: []string{"Hello, ", "!"},
+		Expressions: []Expression{
+			&IdentifierExpression{
+				Identifier: Identifier{
+					Identifier: "name",
+					Pos:        Position{Offset: 1, Line: 2, Column: 3},
+				},
+			},
+		},
+		Range: Range{
+			StartPos: Position{Offset: 1, Line: 2, Column: 3},
+			EndPos:   Position{Offset: 4, Line: 5, Column: 6},
+		},
+	}
+
+	actual, err := json.Marshal(expr)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		// language=json
+		`
+        {
+            "Type": "StringTemplateExpression",
+            "Values": ["Hello, ", "!"],
+            "Expressions": [
+                {
+                    "Type": "IdentifierExpression",
+                    "Identifier": {
+                        "Identifier": "name",
+                        "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+                        "EndPos": {"Offset": 4, "Line": 2, "Column": 6}
+                    },
+                    "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+                    "EndPos": {"Offset": 4, "Line": 2, "Column": 6}
+                }
+            ],
+            "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+            "EndPos": {"Offset": 4, "Line": 5, "Column": 6}
+        }
+        `,
+		string(actual),
+	)
+}
+
+func TestStringTemplateExpression_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &StringTemplateExpression{
+		Values: []string{"a\t", "\\(", ""},
+		Expressions: []Expression{
+			&IdentifierExpression{
+				Identifier: Identifier{
+					Identifier: "b",
+				},
+			},
+			&StringExpression{
+				Value: "c",
+			},
+		},
+	}
+
+	assert.Equal(t,
+		prettier.Concat{
+			prettier.Text(`"a\t\(`),
+			prettier.Text("b"),
+			prettier.Text(`)\\(\(`),
+			prettier.Text(`"c"`),
+			prettier.Text(`)"`),
+		},
+		expr.Doc(),
+	)
+
+	assert.Equal(t,
+		`"a\t\(b)\\(\("c")"`,
+		expr.String(),
+	)
+}
+
 func TestIntegerExpression_MarshalJSON(t *testing.T) {
 
 	t.Parallel()
@@ -114,6 +114,8 @@ func (d *InterfaceDeclaration) Doc() prettier.Doc {
 		true,
 		d.Identifier.Identifier,
 		nil,
+		nil,
+		nil,
 		d.Members,
 	)
 }
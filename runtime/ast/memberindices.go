@@ -55,6 +55,8 @@ type memberIndices struct {
 	_composites []*CompositeDeclaration
 	// Use `EnumCases()` instead
 	_enumCases []*EnumCaseDeclaration
+	// Use `TypeAliases()` instead
+	_typeAliases []*TypeAliasDeclaration
 }
 
 func (i *memberIndices) FieldsByIdentifier(declarations []Declaration) map[string]*FieldDeclaration {
@@ -117,6 +119,11 @@ func (i *memberIndices) EnumCases(declarations []Declaration) []*EnumCaseDeclara
 	return i._enumCases
 }
 
+func (i *memberIndices) TypeAliases(declarations []Declaration) []*TypeAliasDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._typeAliases
+}
+
 func (i *memberIndices) initializer(declarations []Declaration) func() {
 	return func() {
 		i.init(declarations)
@@ -144,6 +151,8 @@ func (i *memberIndices) init(declarations []Declaration) {
 
 	i._enumCases = make([]*EnumCaseDeclaration, 0)
 
+	i._typeAliases = make([]*TypeAliasDeclaration, 0)
+
 	for _, declaration := range declarations {
 		switch declaration := declaration.(type) {
 		case *FieldDeclaration:
@@ -174,6 +183,9 @@ func (i *memberIndices) init(declarations []Declaration) {
 
 		case *EnumCaseDeclaration:
 			i._enumCases = append(i._enumCases, declaration)
+
+		case *TypeAliasDeclaration:
+			i._typeAliases = append(i._typeAliases, declaration)
 		}
 	}
 }
@@ -70,6 +70,7 @@ const (
 	// - CreateExpression
 	// - DestroyExpression
 	// - ReferenceExpression
+	// - AttachExpression
 	precedenceUnaryPrefix
 	// precedenceUnaryPostfix is the precedence of
 	// - ForceExpression
@@ -83,6 +84,7 @@ const (
 	// - BoolExpression
 	// - NilExpression
 	// - StringExpression
+	// - StringTemplateExpression
 	// - IntegerExpression
 	// - FixedPointExpression
 	// - ArrayExpression
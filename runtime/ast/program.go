@@ -29,7 +29,9 @@ import (
 type Program struct {
 	// all declarations, in the order they are defined
 	declarations []Declaration
-	indices      programIndices
+	// all comments, in the order they are defined, if recorded
+	comments []*Comment
+	indices  programIndices
 }
 
 var _ Element = &Program{}
@@ -41,6 +43,17 @@ func NewProgram(memoryGauge common.MemoryGauge, declarations []Declaration) *Pro
 	}
 }
 
+// NewProgramWithComments returns a new program with the given declarations and comments
+func NewProgramWithComments(
+	memoryGauge common.MemoryGauge,
+	declarations []Declaration,
+	comments []*Comment,
+) *Program {
+	program := NewProgram(memoryGauge, declarations)
+	program.comments = comments
+	return program
+}
+
 func (*Program) ElementType() ElementType {
 	return ElementTypeProgram
 }
@@ -70,6 +83,13 @@ func (p *Program) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, p.declarations)
 }
 
+// Comments returns all comments of the program, in the order they are defined.
+//
+// Comments are only recorded if enabled when parsing the program, see parser.Config
+func (p *Program) Comments() []*Comment {
+	return p.comments
+}
+
 func (p *Program) PragmaDeclarations() []*PragmaDeclaration {
 	return p.indices.pragmaDeclarations(p.declarations)
 }
@@ -98,6 +118,10 @@ func (p *Program) VariableDeclarations() []*VariableDeclaration {
 	return p.indices.variableDeclarations(p.declarations)
 }
 
+func (p *Program) TypeAliasDeclarations() []*TypeAliasDeclaration {
+	return p.indices.typeAliasDeclarations(p.declarations)
+}
+
 // SoleContractDeclaration returns the sole contract declaration, if any,
 // and if there are no other actionable declarations.
 func (p *Program) SoleContractDeclaration() *CompositeDeclaration {
@@ -166,10 +190,12 @@ func (p *Program) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		Type         string
 		Declarations []Declaration
+		Comments     []*Comment `json:",omitempty"`
 		*Alias
 	}{
 		Type:         "Program",
 		Declarations: p.declarations,
+		Comments:     p.comments,
 		Alias:        (*Alias)(p),
 	})
 }
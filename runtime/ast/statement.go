@@ -541,6 +541,78 @@ func (s *EmitStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// RemoveStatement
+
+type RemoveStatement struct {
+	Attachment *NominalType
+	Value      Expression
+	StartPos   Position `json:"-"`
+}
+
+var _ Element = &RemoveStatement{}
+var _ Statement = &RemoveStatement{}
+
+func NewRemoveStatement(
+	gauge common.MemoryGauge,
+	attachment *NominalType,
+	value Expression,
+	startPos Position,
+) *RemoveStatement {
+	common.UseMemory(gauge, common.RemoveStatementMemoryUsage)
+	return &RemoveStatement{
+		Attachment: attachment,
+		Value:      value,
+		StartPos:   startPos,
+	}
+}
+
+func (*RemoveStatement) ElementType() ElementType {
+	return ElementTypeRemoveStatement
+}
+
+func (*RemoveStatement) isStatement() {}
+
+func (s *RemoveStatement) StartPosition() Position {
+	return s.StartPos
+}
+
+func (s *RemoveStatement) EndPosition(memoryGauge common.MemoryGauge) Position {
+	return s.Value.EndPosition(memoryGauge)
+}
+
+func (s *RemoveStatement) Walk(walkChild func(Element)) {
+	walkChild(s.Value)
+}
+
+const removeStatementKeywordSpaceDoc = prettier.Text("remove ")
+const removeStatementFromKeywordDoc = prettier.Text(" from ")
+
+func (s *RemoveStatement) Doc() prettier.Doc {
+	return prettier.Concat{
+		removeStatementKeywordSpaceDoc,
+		s.Attachment.Doc(),
+		removeStatementFromKeywordDoc,
+		s.Value.Doc(),
+	}
+}
+
+func (s *RemoveStatement) String() string {
+	return Prettier(s)
+}
+
+func (s *RemoveStatement) MarshalJSON() ([]byte, error) {
+	type Alias RemoveStatement
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "RemoveStatement",
+		Range: NewUnmeteredRangeFromPositioned(s),
+		Alias: (*Alias)(s),
+	})
+}
+
 // AssignmentStatement
 
 type AssignmentStatement struct {
@@ -0,0 +1,99 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TextEdit is an edit of source code.
+//
+// If Insertion is not empty, it is inserted at the start position of the range.
+// Otherwise, the code in the range is replaced with Replacement,
+// i.e. the code is removed if Replacement is empty.
+type TextEdit struct {
+	Replacement string
+	Insertion   string
+	Range
+}
+
+// ApplyTo returns the given code with the edit applied
+func (edit TextEdit) ApplyTo(code string) string {
+	start, end := edit.offsets()
+	return code[:start] + edit.text() + code[end:]
+}
+
+// offsets returns the start offset (inclusive) and end offset (exclusive) of the edited code
+func (edit TextEdit) offsets() (start int, end int) {
+	start = edit.StartPos.Offset
+	if edit.Insertion != "" {
+		return start, start
+	}
+	return start, edit.EndPos.Offset + 1
+}
+
+func (edit TextEdit) text() string {
+	if edit.Insertion != "" {
+		return edit.Insertion
+	}
+	return edit.Replacement
+}
+
+// ApplyTextEdits returns the given code with all given edits applied.
+//
+// The edits refer to the positions of the given code, i.e. the offsets of later edits do not need to be adjusted.
+// An error is returned if edits overlap, or are out of the bounds of the code.
+func ApplyTextEdits(code string, edits []TextEdit) (string, error) {
+	sortedEdits := make([]TextEdit, len(edits))
+	copy(sortedEdits, edits)
+
+	sort.SliceStable(sortedEdits, func(i, j int) bool {
+		start1, end1 := sortedEdits[i].offsets()
+		start2, end2 := sortedEdits[j].offsets()
+		if start1 != start2 {
+			return start1 < start2
+		}
+		// Insertions come before replacements at the same offset
+		return end1 < end2
+	})
+
+	var builder strings.Builder
+
+	offset := 0
+	for _, edit := range sortedEdits {
+		start, end := edit.offsets()
+
+		if start < offset {
+			return "", fmt.Errorf("overlapping text edit at offset %d", start)
+		}
+		if end > len(code) {
+			return "", fmt.Errorf("text edit at offset %d is out of bounds", start)
+		}
+
+		builder.WriteString(code[offset:start])
+		builder.WriteString(edit.text())
+		offset = end
+	}
+
+	builder.WriteString(code[offset:])
+
+	return builder.String(), nil
+}
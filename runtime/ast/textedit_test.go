@@ -0,0 +1,164 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextEdit_ApplyTo(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "let x = y"
+
+	t.Run("insertion", func(t *testing.T) {
+
+		t.Parallel()
+
+		edit := TextEdit{
+			Insertion: "!",
+			Range: Range{
+				StartPos: Position{Offset: 9, Line: 1, Column: 9},
+				EndPos:   Position{Offset: 9, Line: 1, Column: 9},
+			},
+		}
+
+		assert.Equal(t, "let x = y!", edit.ApplyTo(code))
+	})
+
+	t.Run("replacement", func(t *testing.T) {
+
+		t.Parallel()
+
+		edit := TextEdit{
+			Replacement: "<-",
+			Range: Range{
+				StartPos: Position{Offset: 6, Line: 1, Column: 6},
+				EndPos:   Position{Offset: 6, Line: 1, Column: 6},
+			},
+		}
+
+		assert.Equal(t, "let x <- y", edit.ApplyTo(code))
+	})
+
+	t.Run("removal", func(t *testing.T) {
+
+		t.Parallel()
+
+		edit := TextEdit{
+			Range: Range{
+				StartPos: Position{Offset: 0, Line: 1, Column: 0},
+				EndPos:   Position{Offset: 3, Line: 1, Column: 3},
+			},
+		}
+
+		assert.Equal(t, "x = y", edit.ApplyTo(code))
+	})
+}
+
+func TestApplyTextEdits(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "let x = y"
+
+	t.Run("multiple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := ApplyTextEdits(
+			code,
+			[]TextEdit{
+				{
+					Insertion: ")!",
+					Range: Range{
+						StartPos: Position{Offset: 9, Line: 1, Column: 9},
+						EndPos:   Position{Offset: 9, Line: 1, Column: 9},
+					},
+				},
+				{
+					Replacement: "var",
+					Range: Range{
+						StartPos: Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   Position{Offset: 2, Line: 1, Column: 2},
+					},
+				},
+				{
+					Insertion: "(",
+					Range: Range{
+						StartPos: Position{Offset: 8, Line: 1, Column: 8},
+						EndPos:   Position{Offset: 8, Line: 1, Column: 8},
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "var x = (y)!", result)
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ApplyTextEdits(
+			code,
+			[]TextEdit{
+				{
+					Replacement: "var",
+					Range: Range{
+						StartPos: Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   Position{Offset: 4, Line: 1, Column: 4},
+					},
+				},
+				{
+					Replacement: "y",
+					Range: Range{
+						StartPos: Position{Offset: 4, Line: 1, Column: 4},
+						EndPos:   Position{Offset: 4, Line: 1, Column: 4},
+					},
+				},
+			},
+		)
+		require.EqualError(t, err, "overlapping text edit at offset 4")
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ApplyTextEdits(
+			code,
+			[]TextEdit{
+				{
+					Replacement: "z",
+					Range: Range{
+						StartPos: Position{Offset: 8, Line: 1, Column: 8},
+						EndPos:   Position{Offset: 9, Line: 1, Column: 9},
+					},
+				},
+			},
+		)
+		require.EqualError(t, err, "text edit at offset 8 is out of bounds")
+	})
+}
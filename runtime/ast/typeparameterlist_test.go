@@ -0,0 +1,121 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
+)
+
+func TestTypeParameterList_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		var typeParameterList *TypeParameterList
+
+		require.Equal(t,
+			prettier.Concat{},
+			typeParameterList.Doc(),
+		)
+	})
+
+	t.Run("type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		typeParameterList := &TypeParameterList{
+			TypeParameters: []*TypeParameter{
+				{
+					Identifier: Identifier{Identifier: "T"},
+					TypeBound: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{Identifier: "Integer"},
+						},
+					},
+				},
+				{
+					Identifier: Identifier{Identifier: "U"},
+				},
+			},
+		}
+
+		require.Equal(t,
+			prettier.Group{
+				Doc: prettier.Concat{
+					prettier.Text("<"),
+					prettier.Indent{
+						Doc: prettier.Concat{
+							prettier.SoftLine{},
+							prettier.Concat{
+								prettier.Concat{
+									prettier.Text("T"),
+									prettier.Text(": "),
+									prettier.Text("Integer"),
+								},
+								prettier.Concat{
+									prettier.Text(","),
+									prettier.Line{},
+								},
+								prettier.Concat{
+									prettier.Text("U"),
+								},
+							},
+						},
+					},
+					prettier.SoftLine{},
+					prettier.Text(">"),
+				},
+			},
+			typeParameterList.Doc(),
+		)
+	})
+}
+
+func TestTypeParameterList_String(t *testing.T) {
+
+	t.Parallel()
+
+	typeParameterList := &TypeParameterList{
+		TypeParameters: []*TypeParameter{
+			{
+				Identifier: Identifier{Identifier: "T"},
+				TypeBound: &TypeAnnotation{
+					IsResource: true,
+					Type: &NominalType{
+						Identifier: Identifier{Identifier: "AnyResource"},
+					},
+				},
+			},
+			{
+				Identifier: Identifier{Identifier: "U"},
+			},
+		},
+	}
+
+	require.Equal(t,
+		"<T: @AnyResource, U>",
+		typeParameterList.String(),
+	)
+}
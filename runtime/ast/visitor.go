@@ -43,6 +43,7 @@ type DeclarationVisitor[T any] interface {
 	VisitEnumCaseDeclaration(*EnumCaseDeclaration) T
 	VisitPragmaDeclaration(*PragmaDeclaration) T
 	VisitImportDeclaration(*ImportDeclaration) T
+	VisitTypeAliasDeclaration(*TypeAliasDeclaration) T
 }
 
 func AcceptDeclaration[T any](declaration Declaration, visitor DeclarationVisitor[T]) (_ T) {
@@ -61,6 +62,9 @@ func AcceptDeclaration[T any](declaration Declaration, visitor DeclarationVisito
 	case ElementTypeImportDeclaration:
 		return visitor.VisitImportDeclaration(declaration.(*ImportDeclaration))
 
+	case ElementTypeTypeAliasDeclaration:
+		return visitor.VisitTypeAliasDeclaration(declaration.(*TypeAliasDeclaration))
+
 	case ElementTypeVariableDeclaration:
 		return visitor.VisitVariableDeclaration(declaration.(*VariableDeclaration))
 
@@ -96,6 +100,7 @@ type StatementVisitor[T any] interface {
 	VisitSwitchStatement(*SwitchStatement) T
 	VisitEmitStatement(*EmitStatement) T
 	VisitExpressionStatement(*ExpressionStatement) T
+	VisitRemoveStatement(*RemoveStatement) T
 }
 
 func AcceptStatement[T any](statement Statement, visitor StatementVisitor[T]) (_ T) {
@@ -134,6 +139,9 @@ func AcceptStatement[T any](statement Statement, visitor StatementVisitor[T]) (_
 	case ElementTypeExpressionStatement:
 		return visitor.VisitExpressionStatement(statement.(*ExpressionStatement))
 
+	case ElementTypeRemoveStatement:
+		return visitor.VisitRemoveStatement(statement.(*RemoveStatement))
+
 	case ElementTypeVariableDeclaration:
 		return visitor.VisitVariableDeclaration(statement.(*VariableDeclaration))
 
@@ -161,6 +169,7 @@ type ExpressionVisitor[T any] interface {
 	VisitNilExpression(*NilExpression) T
 	VisitBoolExpression(*BoolExpression) T
 	VisitStringExpression(*StringExpression) T
+	VisitStringTemplateExpression(*StringTemplateExpression) T
 	VisitIntegerExpression(*IntegerExpression) T
 	VisitFixedPointExpression(*FixedPointExpression) T
 	VisitDictionaryExpression(*DictionaryExpression) T
@@ -179,6 +188,7 @@ type ExpressionVisitor[T any] interface {
 	VisitCastingExpression(*CastingExpression) T
 	VisitBinaryExpression(*BinaryExpression) T
 	VisitConditionalExpression(*ConditionalExpression) T
+	VisitAttachExpression(*AttachExpression) T
 }
 
 func AcceptExpression[T any](expression Expression, visitor ExpressionVisitor[T]) (_ T) {
@@ -196,6 +206,9 @@ func AcceptExpression[T any](expression Expression, visitor ExpressionVisitor[T]
 	case ElementTypeStringExpression:
 		return visitor.VisitStringExpression(expression.(*StringExpression))
 
+	case ElementTypeStringTemplateExpression:
+		return visitor.VisitStringTemplateExpression(expression.(*StringTemplateExpression))
+
 	case ElementTypeIntegerExpression:
 		return visitor.VisitIntegerExpression(expression.(*IntegerExpression))
 
@@ -249,6 +262,9 @@ func AcceptExpression[T any](expression Expression, visitor ExpressionVisitor[T]
 
 	case ElementTypeConditionalExpression:
 		return visitor.VisitConditionalExpression(expression.(*ConditionalExpression))
+
+	case ElementTypeAttachExpression:
+		return visitor.VisitAttachExpression(expression.(*AttachExpression))
 	}
 
 	panic(errors.NewUnreachableError())
@@ -0,0 +1,165 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/activations"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// LaunchFunc runs the program in the given source file, which has the given location.
+// The program must be run with the given debugger,
+// and all program logs must be reported using the given log function.
+//
+// The function is called in a separate goroutine, and should return once the program has finished.
+//
+// Embedders, e.g. an emulator, can provide their own function to debug scripts and transactions
+// executed by a full runtime, by setting the debugger in the runtime's configuration.
+type LaunchFunc func(
+	path string,
+	location common.Location,
+	debugger *interpreter.Debugger,
+	log func(message string),
+) error
+
+type logger func(message string)
+
+func (l logger) ProgramLog(message string) error {
+	l(message)
+	return nil
+}
+
+var _ stdlib.Logger = logger(nil)
+
+// RunFile is a LaunchFunc which runs the program in the given file with a standalone interpreter,
+// like the `execute` command.
+//
+// If the program declares a global function `main`, it is invoked.
+// Otherwise, if the program declares a transaction, the transaction is executed.
+// Transactions with parameters or signers are not supported.
+func RunFile(
+	path string,
+	location common.Location,
+	debugger *interpreter.Debugger,
+	log func(message string),
+) error {
+
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	codes := map[common.Location][]byte{
+		location: code,
+	}
+
+	prettyError := func(err error) error {
+		var builder strings.Builder
+		printErr := pretty.NewErrorPrettyPrinter(&builder, false).
+			PrettyPrintError(err, location, codes)
+		if printErr != nil {
+			return err
+		}
+		return errors.New(builder.String())
+	}
+
+	program, err := parser.ParseProgram(code, nil)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		location,
+		nil,
+		cmd.DefaultCheckerConfig(map[common.Location]*sema.Checker{}, codes),
+	)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return prettyError(err)
+	}
+
+	baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+	interpreter.Declare(baseActivation, stdlib.NewLogFunction(logger(log)))
+
+	var uuid uint64
+
+	config := &interpreter.Config{
+		BaseActivation: baseActivation,
+		Storage:        interpreter.NewInMemoryStorage(nil),
+		UUIDHandler: func() (uint64, error) {
+			defer func() { uuid++ }()
+			return uuid, nil
+		},
+		Debugger: debugger,
+		ImportLocationHandler: func(_ *interpreter.Interpreter, location common.Location) interpreter.Import {
+			panic(fmt.Errorf("cannot import `%s`: importing programs is not supported", location))
+		},
+	}
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		location,
+		config,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = inter.Interpret()
+	if err != nil {
+		return prettyError(err)
+	}
+
+	switch {
+	case inter.Globals.Contains("main"):
+		_, err = inter.Invoke("main")
+
+	case len(inter.Transactions) > 0:
+		transactionType := checker.Elaboration.TransactionTypes[0]
+		if len(transactionType.Parameters) > 0 || len(transactionType.PrepareParameters) > 0 {
+			return errors.New("transactions with parameters or signers are not supported")
+		}
+		err = inter.InvokeTransaction(0)
+
+	default:
+		return errors.New("program has no `main` function and no transaction")
+	}
+
+	if err != nil {
+		return prettyError(err)
+	}
+
+	return nil
+}
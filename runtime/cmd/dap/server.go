@@ -0,0 +1,705 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// The interpreter executes a program on a single goroutine,
+// so the server reports a single thread.
+const mainThreadID = 1
+
+const (
+	stopReasonEntry      = "entry"
+	stopReasonStep       = "step"
+	stopReasonPause      = "pause"
+	stopReasonBreakpoint = "breakpoint"
+)
+
+const (
+	scopeNameLocals  = "Locals"
+	scopeNameGlobals = "Globals"
+)
+
+// Server is a Debug Adapter Protocol server,
+// which allows editors to debug programs using an interpreter.Debugger.
+type Server struct {
+	reader   *bufio.Reader
+	writer   io.Writer
+	debugger *interpreter.Debugger
+	launch   LaunchFunc
+	sources  SourceMapper
+
+	// writeLock guards writes to the writer and the sequence number
+	writeLock sync.Mutex
+	seq       int
+
+	// lock guards the fields below,
+	// which are accessed by the request loop,
+	// the program goroutine, and the stop goroutine
+	lock             sync.Mutex
+	launchArguments  *LaunchRequestArguments
+	configured       bool
+	started          bool
+	done             chan struct{}
+	stop             *interpreter.Stop
+	stopReason       string
+	variableHandles  variableHandles
+	requestHandlers  map[string]requestHandler
+	disconnectCalled bool
+}
+
+type requestHandler func(arguments json.RawMessage) (body any, err error)
+
+// NewServer returns a new server which reads requests from the given reader,
+// and writes responses and events to the given writer.
+//
+// Programs are run using the given launch function,
+// and locations are mapped to source files using the given source mapper.
+func NewServer(
+	reader io.Reader,
+	writer io.Writer,
+	debugger *interpreter.Debugger,
+	launch LaunchFunc,
+	sources SourceMapper,
+) *Server {
+	server := &Server{
+		reader:     bufio.NewReader(reader),
+		writer:     writer,
+		debugger:   debugger,
+		launch:     launch,
+		sources:    sources,
+		done:       make(chan struct{}),
+		stopReason: stopReasonBreakpoint,
+	}
+
+	debugger.SetLogHandler(func(message string) {
+		server.output("console", message+"\n")
+	})
+	debugger.SetExpressionParser(parser.ParseExpression)
+
+	server.requestHandlers = map[string]requestHandler{
+		"initialize":              server.initialize,
+		"launch":                  server.launchProgram,
+		"setBreakpoints":          server.setBreakpoints,
+		"setExceptionBreakpoints": server.setExceptionBreakpoints,
+		"configurationDone":       server.configurationDone,
+		"threads":                 server.threads,
+		"stackTrace":              server.stackTrace,
+		"scopes":                  server.scopes,
+		"variables":               server.variables,
+		"evaluate":                server.evaluate,
+		"continue":                server.continueProgram,
+		"next":                    server.stepper((*interpreter.Debugger).RequestStepOver),
+		"stepIn":                  server.stepper((*interpreter.Debugger).RequestStepIn),
+		"stepOut":                 server.stepper((*interpreter.Debugger).RequestStepOut),
+		"pause":                   server.pause,
+		"disconnect":              server.disconnect,
+		"terminate":               server.disconnect,
+	}
+
+	return server
+}
+
+// Run handles requests until the client disconnects or the reader is closed.
+func (s *Server) Run() error {
+	for {
+		content, err := ReadMessage(s.reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var request Request
+		err = json.Unmarshal(content, &request)
+		if err != nil {
+			return err
+		}
+
+		if request.Type != messageTypeRequest {
+			continue
+		}
+
+		err = s.handleRequest(request)
+		if err != nil {
+			return err
+		}
+
+		s.lock.Lock()
+		disconnected := s.disconnectCalled
+		started := s.started
+		s.lock.Unlock()
+
+		if disconnected {
+			// Wait for the program to run to completion,
+			// so it does not outlive the server
+
+			if started {
+				<-s.done
+			}
+
+			return nil
+		}
+	}
+}
+
+func (s *Server) handleRequest(request Request) error {
+	response := Response{
+		ProtocolMessage: ProtocolMessage{
+			Type: messageTypeResponse,
+		},
+		RequestSeq: request.Seq,
+		Command:    request.Command,
+	}
+
+	handler, ok := s.requestHandlers[request.Command]
+	if !ok {
+		response.Message = fmt.Sprintf("unsupported request: %s", request.Command)
+		return s.send(&response.ProtocolMessage, response)
+	}
+
+	body, err := handler(request.Arguments)
+	if err != nil {
+		response.Message = err.Error()
+	} else {
+		response.Success = true
+		response.Body = body
+	}
+
+	err = s.send(&response.ProtocolMessage, response)
+	if err != nil {
+		return err
+	}
+
+	// The initialized event must be sent after the response to the initialize request
+
+	if request.Command == "initialize" && response.Success {
+		return s.sendEvent("initialized", nil)
+	}
+
+	return nil
+}
+
+func (s *Server) send(protocolMessage *ProtocolMessage, message any) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.seq++
+	protocolMessage.Seq = s.seq
+
+	return WriteMessage(s.writer, message)
+}
+
+func (s *Server) sendEvent(event string, body any) error {
+	message := Event{
+		ProtocolMessage: ProtocolMessage{
+			Type: messageTypeEvent,
+		},
+		Event: event,
+		Body:  body,
+	}
+	return s.send(&message.ProtocolMessage, message)
+}
+
+func (s *Server) output(category string, output string) {
+	_ = s.sendEvent(
+		"output",
+		OutputEventBody{
+			Category: category,
+			Output:   output,
+		},
+	)
+}
+
+func decodeArguments[T any](arguments json.RawMessage) (result T, err error) {
+	if len(arguments) == 0 {
+		return
+	}
+	err = json.Unmarshal(arguments, &result)
+	return
+}
+
+func (s *Server) initialize(_ json.RawMessage) (any, error) {
+	return Capabilities{
+		SupportsConfigurationDoneRequest:  true,
+		SupportsEvaluateForHovers:         true,
+		SupportsTerminateRequest:          true,
+		SupportsConditionalBreakpoints:    true,
+		SupportsHitConditionalBreakpoints: true,
+		SupportsLogPoints:                 true,
+	}, nil
+}
+
+func (s *Server) launchProgram(rawArguments json.RawMessage) (any, error) {
+	arguments, err := decodeArguments[LaunchRequestArguments](rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if arguments.Program == "" {
+		return nil, errors.New("missing program")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.launchArguments != nil {
+		return nil, errors.New("program already launched")
+	}
+
+	s.launchArguments = &arguments
+
+	s.startIfReady()
+
+	return nil, nil
+}
+
+func (s *Server) configurationDone(_ json.RawMessage) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.configured = true
+
+	s.startIfReady()
+
+	return nil, nil
+}
+
+// startIfReady starts the program once it got launched and the client finished the configuration,
+// so breakpoints are set before the program runs.
+//
+// The lock must be held.
+func (s *Server) startIfReady() {
+	if s.started || !s.configured || s.launchArguments == nil {
+		return
+	}
+
+	s.started = true
+
+	arguments := *s.launchArguments
+
+	debugger := s.debugger
+	if arguments.NoDebug {
+		debugger = nil
+	} else if arguments.StopOnEntry {
+		s.stopReason = stopReasonEntry
+		s.debugger.RequestPause()
+	}
+
+	go s.handleStops()
+
+	go func() {
+		defer close(s.done)
+
+		location := s.sources.Location(arguments.Program)
+
+		exitCode := 0
+
+		err := s.launch(
+			arguments.Program,
+			location,
+			debugger,
+			func(message string) {
+				s.output("stdout", message+"\n")
+			},
+		)
+		if err != nil {
+			s.output("stderr", err.Error()+"\n")
+			exitCode = 1
+		}
+
+		_ = s.sendEvent("exited", ExitedEventBody{ExitCode: exitCode})
+		_ = s.sendEvent("terminated", nil)
+	}()
+}
+
+// handleStops reports stops of the program to the client, until the program finished.
+func (s *Server) handleStops() {
+	for {
+		select {
+		case stop := <-s.debugger.Stops():
+			s.lock.Lock()
+
+			// The program may still stop after the client disconnected,
+			// e.g. because a pause or step was requested before.
+			// Let the program run to completion
+
+			if s.disconnectCalled {
+				s.lock.Unlock()
+				s.debugger.Continue()
+				continue
+			}
+
+			s.stop = &stop
+			reason := s.stopReason
+			s.stopReason = stopReasonBreakpoint
+			s.lock.Unlock()
+
+			body := StoppedEventBody{
+				Reason:            reason,
+				ThreadID:          mainThreadID,
+				AllThreadsStopped: true,
+			}
+
+			if stop.Breakpoint != nil {
+				body.Reason = stopReasonBreakpoint
+				body.HitBreakpointIDs = []int{stop.Breakpoint.ID}
+			}
+
+			_ = s.sendEvent("stopped", body)
+
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Server) setBreakpoints(rawArguments json.RawMessage) (any, error) {
+	arguments, err := decodeArguments[SetBreakpointsArguments](rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	location := s.sources.Location(arguments.Source.Path)
+
+	s.debugger.ClearBreakpointsForLocation(location)
+
+	breakpoints := make([]Breakpoint, 0, len(arguments.Breakpoints))
+
+	for _, sourceBreakpoint := range arguments.Breakpoints {
+		breakpoint := Breakpoint{
+			Source: &arguments.Source,
+			Line:   sourceBreakpoint.Line,
+		}
+
+		added, err := s.addBreakpoint(location, sourceBreakpoint)
+		if err != nil {
+			breakpoint.Message = err.Error()
+		} else {
+			breakpoint.ID = added.ID
+			breakpoint.Verified = true
+		}
+
+		breakpoints = append(breakpoints, breakpoint)
+	}
+
+	return SetBreakpointsResponseBody{
+		Breakpoints: breakpoints,
+	}, nil
+}
+
+func (s *Server) addBreakpoint(
+	location common.Location,
+	sourceBreakpoint SourceBreakpoint,
+) (*interpreter.Breakpoint, error) {
+
+	options := interpreter.BreakpointOptions{
+		Condition:  sourceBreakpoint.Condition,
+		LogMessage: sourceBreakpoint.LogMessage,
+	}
+
+	if sourceBreakpoint.HitCondition != "" {
+		var err error
+		options.HitCondition, err = interpreter.ParseHitCondition(sourceBreakpoint.HitCondition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.debugger.AddBreakpointWithOptions(
+		location,
+		uint(sourceBreakpoint.Line),
+		options,
+	)
+}
+
+func (s *Server) setExceptionBreakpoints(_ json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+func (s *Server) threads(_ json.RawMessage) (any, error) {
+	return ThreadsResponseBody{
+		Threads: []Thread{
+			{
+				ID:   mainThreadID,
+				Name: "main",
+			},
+		},
+	}, nil
+}
+
+// programFinished returns true if the program was started and finished.
+func (s *Server) programFinished() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// currentStop returns the stop the program is currently paused at.
+//
+// The lock must be held.
+func (s *Server) currentStop() (*interpreter.Stop, error) {
+	if s.stop == nil {
+		return nil, errors.New("program is not paused")
+	}
+	return s.stop, nil
+}
+
+func (s *Server) stackTrace(rawArguments json.RawMessage) (any, error) {
+	arguments, err := decodeArguments[StackTraceArguments](rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stop, err := s.currentStop()
+	if err != nil {
+		return nil, err
+	}
+
+	callStack := stop.CallStack
+
+	start := arguments.StartFrame
+	if start > len(callStack) {
+		start = len(callStack)
+	}
+	end := len(callStack)
+	if arguments.Levels > 0 && start+arguments.Levels < end {
+		end = start + arguments.Levels
+	}
+
+	stackFrames := make([]StackFrame, 0, end-start)
+
+	for id := start; id < end; id++ {
+		frame := callStack[id]
+
+		name := frame.FunctionName
+		if name == "" {
+			name = frame.Location.String()
+		}
+
+		stackFrames = append(stackFrames, StackFrame{
+			ID:     id,
+			Name:   name,
+			Source: s.source(frame.Location),
+			Line:   frame.Range.StartPos.Line,
+			// Cadence columns start at 0, DAP columns start at 1
+			Column:    frame.Range.StartPos.Column + 1,
+			EndLine:   frame.Range.EndPos.Line,
+			EndColumn: frame.Range.EndPos.Column + 1,
+		})
+	}
+
+	return StackTraceResponseBody{
+		StackFrames: stackFrames,
+		TotalFrames: len(callStack),
+	}, nil
+}
+
+func (s *Server) scopes(_ json.RawMessage) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stop, err := s.currentStop()
+	if err != nil {
+		return nil, err
+	}
+
+	inter := stop.Interpreter
+	activation := s.debugger.CurrentActivation(inter)
+
+	return ScopesResponseBody{
+		Scopes: []Scope{
+			{
+				Name:             scopeNameLocals,
+				PresentationHint: "locals",
+				VariablesReference: s.variableHandles.add(func() []Variable {
+					return s.activationVariables(inter, activation)
+				}),
+			},
+			{
+				Name: scopeNameGlobals,
+				VariablesReference: s.variableHandles.add(func() []Variable {
+					return s.globalVariables(inter)
+				}),
+			},
+		},
+	}, nil
+}
+
+func (s *Server) variables(rawArguments json.RawMessage) (any, error) {
+	arguments, err := decodeArguments[VariablesArguments](rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err = s.currentStop()
+	if err != nil {
+		return nil, err
+	}
+
+	container := s.variableHandles.get(arguments.VariablesReference)
+	if container == nil {
+		return nil, fmt.Errorf("unknown variables reference: %d", arguments.VariablesReference)
+	}
+
+	variables := container()
+	if variables == nil {
+		variables = []Variable{}
+	}
+
+	return VariablesResponseBody{
+		Variables: variables,
+	}, nil
+}
+
+func (s *Server) evaluate(rawArguments json.RawMessage) (any, error) {
+	arguments, err := decodeArguments[EvaluateArguments](rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stop, err := s.currentStop()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the scope of the innermost frame is available
+	if arguments.FrameID != nil && *arguments.FrameID != 0 {
+		return nil, errors.New("expressions can only be evaluated in the innermost frame")
+	}
+
+	value, err := s.debugger.Evaluate(*stop, arguments.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	variable := s.variable(stop.Interpreter, arguments.Expression, value)
+
+	return EvaluateResponseBody{
+		Result:             variable.Value,
+		Type:               variable.Type,
+		VariablesReference: variable.VariablesReference,
+	}, nil
+}
+
+// resume resumes the paused program.
+// If requestStep is not nil, it is called to request the program to pause again after a step.
+//
+// The lock must be held.
+func (s *Server) resume(requestStep func(*interpreter.Debugger)) error {
+	if s.programFinished() {
+		return errors.New("program terminated")
+	}
+
+	if _, err := s.currentStop(); err != nil {
+		return err
+	}
+
+	s.stop = nil
+	s.variableHandles.reset()
+
+	if requestStep != nil {
+		s.stopReason = stopReasonStep
+		requestStep(s.debugger)
+	}
+
+	s.debugger.Continue()
+
+	return nil
+}
+
+func (s *Server) continueProgram(_ json.RawMessage) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.resume(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ContinueResponseBody{
+		AllThreadsContinued: true,
+	}, nil
+}
+
+func (s *Server) stepper(requestStep func(*interpreter.Debugger)) requestHandler {
+	return func(_ json.RawMessage) (any, error) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		return nil, s.resume(requestStep)
+	}
+}
+
+func (s *Server) pause(_ json.RawMessage) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.stop != nil {
+		return nil, nil
+	}
+
+	s.stopReason = stopReasonPause
+	s.debugger.RequestPause()
+
+	return nil, nil
+}
+
+func (s *Server) disconnect(_ json.RawMessage) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.disconnectCalled = true
+
+	// Let the program run to completion.
+	// Stops which are still requested, e.g. by a pause or a step,
+	// are continued by handleStops
+
+	s.debugger.ClearBreakpoints()
+
+	if s.stop != nil {
+		_ = s.resume(nil)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,687 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type testMessage struct {
+	Type       string          `json:"type"`
+	Command    string          `json:"command"`
+	Event      string          `json:"event"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	RequestSeq int             `json:"request_seq"`
+	Body       json.RawMessage `json:"body"`
+}
+
+type testClient struct {
+	t        *testing.T
+	writer   io.Writer
+	seq      int
+	messages chan testMessage
+	pending  []testMessage
+}
+
+func newTestClient(t *testing.T) *testClient {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	server := NewServer(
+		serverReader,
+		serverWriter,
+		interpreter.NewDebugger(),
+		RunFile,
+		FileSourceMapper{},
+	)
+
+	go func() {
+		_ = server.Run()
+		_ = serverWriter.Close()
+	}()
+
+	client := &testClient{
+		t:        t,
+		writer:   clientWriter,
+		messages: make(chan testMessage),
+	}
+
+	go func() {
+		reader := bufio.NewReader(clientReader)
+		for {
+			content, err := ReadMessage(reader)
+			if err != nil {
+				close(client.messages)
+				return
+			}
+
+			var message testMessage
+			err = json.Unmarshal(content, &message)
+			if err != nil {
+				panic(err)
+			}
+			client.messages <- message
+		}
+	}()
+
+	return client
+}
+
+func (c *testClient) send(command string, arguments any) int {
+	c.seq++
+
+	encodedArguments, err := json.Marshal(arguments)
+	require.NoError(c.t, err)
+
+	err = WriteMessage(c.writer, Request{
+		ProtocolMessage: ProtocolMessage{
+			Seq:  c.seq,
+			Type: messageTypeRequest,
+		},
+		Command:   command,
+		Arguments: encodedArguments,
+	})
+	require.NoError(c.t, err)
+
+	return c.seq
+}
+
+// waitFor returns the first received message which satisfies the given predicate.
+// Other messages are kept for later calls.
+func (c *testClient) waitFor(predicate func(message testMessage) bool) testMessage {
+	for i, message := range c.pending {
+		if predicate(message) {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return message
+		}
+	}
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case message, ok := <-c.messages:
+			require.True(c.t, ok, "connection closed")
+
+			if predicate(message) {
+				return message
+			}
+			c.pending = append(c.pending, message)
+
+		case <-timeout:
+			require.FailNow(c.t, "timed out waiting for message")
+		}
+	}
+}
+
+func (c *testClient) request(command string, arguments any, body any) {
+	seq := c.send(command, arguments)
+
+	response := c.waitFor(func(message testMessage) bool {
+		return message.Type == messageTypeResponse && message.RequestSeq == seq
+	})
+
+	require.True(c.t, response.Success, response.Message)
+	require.Equal(c.t, command, response.Command)
+
+	if body != nil {
+		require.NoError(c.t, json.Unmarshal(response.Body, body))
+	}
+}
+
+func (c *testClient) event(event string, body any) {
+	message := c.waitFor(func(message testMessage) bool {
+		return message.Type == messageTypeEvent && message.Event == event
+	})
+
+	if body != nil {
+		require.NoError(c.t, json.Unmarshal(message.Body, body))
+	}
+}
+
+// failedRequest sends the given request and returns the message of the failed response.
+func (c *testClient) failedRequest(command string, arguments any) string {
+	seq := c.send(command, arguments)
+
+	response := c.waitFor(func(message testMessage) bool {
+		return message.Type == messageTypeResponse && message.RequestSeq == seq
+	})
+
+	require.False(c.t, response.Success)
+	require.Equal(c.t, command, response.Command)
+
+	return response.Message
+}
+
+// closed waits until the server closed the connection.
+func (c *testClient) closed() {
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case _, ok := <-c.messages:
+			if !ok {
+				return
+			}
+
+		case <-timeout:
+			require.FailNow(c.t, "timed out waiting for the connection to close")
+		}
+	}
+}
+
+func writeTestProgram(t *testing.T, code string) string {
+	path := filepath.Join(t.TempDir(), "test.cdc")
+	err := os.WriteFile(path, []byte(code), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestServer(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun main() {
+          let x = 1
+          let y = [x, 2]
+          log(x + y[1])
+      }
+
+      pub fun c() {}
+
+      pub fun b() {}
+    `)
+
+	client := newTestClient(t)
+
+	var capabilities Capabilities
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, &capabilities)
+	require.True(t, capabilities.SupportsConfigurationDoneRequest)
+
+	client.event("initialized", nil)
+
+	client.request("launch", LaunchRequestArguments{Program: path}, nil)
+
+	var breakpoints SetBreakpointsResponseBody
+	client.request(
+		"setBreakpoints",
+		SetBreakpointsArguments{
+			Source: Source{Path: path},
+			Breakpoints: []SourceBreakpoint{
+				{Line: 4},
+			},
+		},
+		&breakpoints,
+	)
+	require.Len(t, breakpoints.Breakpoints, 1)
+	require.True(t, breakpoints.Breakpoints[0].Verified)
+
+	client.request("configurationDone", nil, nil)
+
+	var stopped StoppedEventBody
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonBreakpoint, stopped.Reason)
+
+	var stackTrace StackTraceResponseBody
+	client.request("stackTrace", StackTraceArguments{ThreadID: mainThreadID}, &stackTrace)
+	require.NotEmpty(t, stackTrace.StackFrames)
+	require.Equal(t, 4, stackTrace.StackFrames[0].Line)
+	require.Equal(t, path, stackTrace.StackFrames[0].Source.Path)
+
+	var scopes ScopesResponseBody
+	client.request("scopes", ScopesArguments{FrameID: stackTrace.StackFrames[0].ID}, &scopes)
+	require.Equal(t, scopeNameLocals, scopes.Scopes[0].Name)
+
+	var locals VariablesResponseBody
+	client.request(
+		"variables",
+		VariablesArguments{VariablesReference: scopes.Scopes[0].VariablesReference},
+		&locals,
+	)
+	require.Equal(t,
+		[]Variable{
+			{
+				Name:  "x",
+				Value: "1",
+				Type:  "Int",
+			},
+		},
+		locals.Variables,
+	)
+
+	// Globals are sorted by name
+
+	require.Equal(t, scopeNameGlobals, scopes.Scopes[1].Name)
+
+	var globals VariablesResponseBody
+	client.request(
+		"variables",
+		VariablesArguments{VariablesReference: scopes.Scopes[1].VariablesReference},
+		&globals,
+	)
+
+	globalNames := make([]string, 0, len(globals.Variables))
+	for _, variable := range globals.Variables {
+		globalNames = append(globalNames, variable.Name)
+	}
+	require.Equal(t, []string{"b", "c", "main"}, globalNames)
+
+	client.request("next", nil, nil)
+
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonStep, stopped.Reason)
+
+	client.request("stackTrace", StackTraceArguments{ThreadID: mainThreadID}, &stackTrace)
+	require.Equal(t, 5, stackTrace.StackFrames[0].Line)
+
+	client.request("scopes", ScopesArguments{FrameID: stackTrace.StackFrames[0].ID}, &scopes)
+	client.request(
+		"variables",
+		VariablesArguments{VariablesReference: scopes.Scopes[0].VariablesReference},
+		&locals,
+	)
+	require.Len(t, locals.Variables, 2)
+	require.Equal(t, "y", locals.Variables[1].Name)
+	require.Equal(t, "[1, 2]", locals.Variables[1].Value)
+	require.NotZero(t, locals.Variables[1].VariablesReference)
+
+	var elements VariablesResponseBody
+	client.request(
+		"variables",
+		VariablesArguments{VariablesReference: locals.Variables[1].VariablesReference},
+		&elements,
+	)
+	require.Equal(t,
+		[]Variable{
+			{
+				Name:  "[0]",
+				Value: "1",
+				Type:  "Int",
+			},
+			{
+				Name:  "[1]",
+				Value: "2",
+				Type:  "Int",
+			},
+		},
+		elements.Variables,
+	)
+
+	client.request("continue", nil, nil)
+
+	var output OutputEventBody
+	client.event("output", &output)
+	require.Equal(t,
+		OutputEventBody{
+			Category: "stdout",
+			Output:   "3\n",
+		},
+		output,
+	)
+
+	var exited ExitedEventBody
+	client.event("exited", &exited)
+	require.Equal(t, 0, exited.ExitCode)
+
+	client.event("terminated", nil)
+
+	client.request("disconnect", nil, nil)
+}
+
+func TestServerStopOnEntry(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      transaction {
+          execute {
+              log("Hello, World!")
+          }
+      }
+    `)
+
+	client := newTestClient(t)
+
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+	client.request("launch", LaunchRequestArguments{Program: path, StopOnEntry: true}, nil)
+	client.request("configurationDone", nil, nil)
+
+	var stopped StoppedEventBody
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonEntry, stopped.Reason)
+
+	var stackTrace StackTraceResponseBody
+	client.request("stackTrace", StackTraceArguments{ThreadID: mainThreadID}, &stackTrace)
+	require.Equal(t, 4, stackTrace.StackFrames[0].Line)
+
+	client.request("continue", nil, nil)
+
+	var output OutputEventBody
+	client.event("output", &output)
+	require.Equal(t, "\"Hello, World!\"\n", output.Output)
+
+	client.event("terminated", nil)
+}
+
+func TestServerProgramError(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun main() {
+          let x: Int = "not an integer"
+      }
+    `)
+
+	client := newTestClient(t)
+
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+	client.request("launch", LaunchRequestArguments{Program: path}, nil)
+	client.request("configurationDone", nil, nil)
+
+	var output OutputEventBody
+	client.event("output", &output)
+	require.Equal(t, "stderr", output.Category)
+	require.Contains(t, output.Output, "mismatched types")
+
+	var exited ExitedEventBody
+	client.event("exited", &exited)
+	require.Equal(t, 1, exited.ExitCode)
+}
+
+func TestFileSourceMapper(t *testing.T) {
+
+	t.Parallel()
+
+	mapper := FileSourceMapper{
+		AddressDirectory: "/contracts",
+	}
+
+	addressLocation := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Foo",
+	}
+
+	require.Equal(t,
+		"/contracts/0000000000000001/Foo.cdc",
+		mapper.Path(addressLocation),
+	)
+	require.Equal(t,
+		addressLocation,
+		mapper.Location("/contracts/0000000000000001/Foo.cdc"),
+	)
+
+	require.Equal(t,
+		common.StringLocation("/scripts/test.cdc"),
+		mapper.Location("/scripts/../scripts/test.cdc"),
+	)
+	require.Equal(t,
+		"/scripts/test.cdc",
+		mapper.Path(common.StringLocation("/scripts/test.cdc")),
+	)
+
+	require.Equal(t, "", mapper.Path(common.IdentifierLocation("Test")))
+}
+
+func TestServerStepping(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun double(_ x: Int): Int {
+          return x * 2
+      }
+
+      pub fun main() {
+          let x = double(1)
+          log(x)
+      }
+    `)
+
+	client := newTestClient(t)
+
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+	client.request("launch", LaunchRequestArguments{Program: path, StopOnEntry: true}, nil)
+	client.request("configurationDone", nil, nil)
+
+	var stopped StoppedEventBody
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonEntry, stopped.Reason)
+
+	client.request("stepIn", nil, nil)
+
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonStep, stopped.Reason)
+
+	var stackTrace StackTraceResponseBody
+	client.request("stackTrace", StackTraceArguments{ThreadID: mainThreadID}, &stackTrace)
+	require.Equal(t, 2, stackTrace.TotalFrames)
+	require.Equal(t, "double", stackTrace.StackFrames[0].Name)
+	require.Equal(t, 3, stackTrace.StackFrames[0].Line)
+	require.Equal(t, path, stackTrace.StackFrames[1].Name)
+	require.Equal(t, 7, stackTrace.StackFrames[1].Line)
+
+	client.request("stepOut", nil, nil)
+
+	client.event("stopped", &stopped)
+	client.request("stackTrace", StackTraceArguments{ThreadID: mainThreadID}, &stackTrace)
+	require.Equal(t, 1, stackTrace.TotalFrames)
+	require.Equal(t, 8, stackTrace.StackFrames[0].Line)
+
+	client.request("continue", nil, nil)
+
+	client.event("terminated", nil)
+}
+
+func TestServerStepAtEndOfProgram(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun main() {
+          log(1)
+      }
+    `)
+
+	client := newTestClient(t)
+
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+	client.request("launch", LaunchRequestArguments{Program: path, StopOnEntry: true}, nil)
+	client.request("configurationDone", nil, nil)
+
+	var stopped StoppedEventBody
+	client.event("stopped", &stopped)
+	require.Equal(t, stopReasonEntry, stopped.Reason)
+
+	client.request("next", nil, nil)
+
+	client.event("terminated", nil)
+
+	for _, command := range []string{"next", "stepIn", "stepOut", "continue"} {
+		message := client.failedRequest(command, nil)
+		require.Equal(t, "program terminated", message)
+	}
+
+	client.request("disconnect", nil, nil)
+
+	client.closed()
+}
+
+func TestServerDisconnectWhileRunning(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun main() {
+          var i = 0
+          while i < 1000 {
+              i = i + 1
+          }
+          log(i)
+      }
+    `)
+
+	t.Run("pending step", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := newTestClient(t)
+
+		client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+		client.request("launch", LaunchRequestArguments{Program: path, StopOnEntry: true}, nil)
+		client.request("configurationDone", nil, nil)
+
+		client.event("stopped", nil)
+
+		// Disconnect without waiting for the step to complete
+
+		client.request("next", nil, nil)
+		client.request("disconnect", nil, nil)
+
+		var output OutputEventBody
+		client.event("output", &output)
+		require.Equal(t, "1000\n", output.Output)
+
+		client.event("terminated", nil)
+
+		client.closed()
+	})
+
+	t.Run("pending pause", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := newTestClient(t)
+
+		client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+		client.request("launch", LaunchRequestArguments{Program: path}, nil)
+		client.request("configurationDone", nil, nil)
+
+		// Disconnect without waiting for the program to pause
+
+		client.request("pause", nil, nil)
+		client.request("disconnect", nil, nil)
+
+		var output OutputEventBody
+		client.event("output", &output)
+		require.Equal(t, "1000\n", output.Output)
+
+		client.event("terminated", nil)
+
+		client.closed()
+	})
+}
+
+func TestServerEvaluate(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub struct Point {
+          pub let x: Int
+          pub let y: Int
+
+          init(x: Int, y: Int) {
+              self.x = x
+              self.y = y
+          }
+      }
+
+      pub fun main() {
+          let point = Point(x: 1, y: 2)
+          log(point.x)
+      }
+    `)
+
+	client := newTestClient(t)
+
+	client.request("initialize", InitializeRequestArguments{AdapterID: "cadence"}, nil)
+	client.request("launch", LaunchRequestArguments{Program: path}, nil)
+	client.request(
+		"setBreakpoints",
+		SetBreakpointsArguments{
+			Source: Source{Path: path},
+			Breakpoints: []SourceBreakpoint{
+				{Line: 14},
+			},
+		},
+		nil,
+	)
+	client.request("configurationDone", nil, nil)
+
+	client.event("stopped", nil)
+
+	var result EvaluateResponseBody
+	client.request(
+		"evaluate",
+		EvaluateArguments{Expression: "point.x + point.y"},
+		&result,
+	)
+	require.Equal(t, "3", result.Result)
+	require.Equal(t, "Int", result.Type)
+
+	client.request(
+		"evaluate",
+		EvaluateArguments{Expression: "point.getType() == Type<Point>()"},
+		&result,
+	)
+	require.Equal(t, "true", result.Result)
+
+	// Structured results can be expanded
+
+	client.request(
+		"evaluate",
+		EvaluateArguments{Expression: "point"},
+		&result,
+	)
+	require.NotZero(t, result.VariablesReference)
+
+	var variables VariablesResponseBody
+	client.request(
+		"variables",
+		VariablesArguments{VariablesReference: result.VariablesReference},
+		&variables,
+	)
+	require.Len(t, variables.Variables, 2)
+
+	// Invalid expressions are reported as failed responses
+
+	seq := client.send("evaluate", EvaluateArguments{Expression: "create Point(x: 3, y: 4)"})
+	response := client.waitFor(func(message testMessage) bool {
+		return message.Type == messageTypeResponse && message.RequestSeq == seq
+	})
+	require.False(t, response.Success)
+
+	client.request("continue", nil, nil)
+
+	client.event("terminated", nil)
+}
@@ -0,0 +1,114 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dap
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const sourceFileExtension = ".cdc"
+
+// SourceMapper maps between the locations of programs and the source files they are loaded from.
+type SourceMapper interface {
+	// Location returns the location of the program in the source file with the given path.
+	Location(path string) common.Location
+	// Path returns the path of the source file of the program with the given location,
+	// or an empty string if the program has no source file.
+	Path(location common.Location) string
+}
+
+// FileSourceMapper maps string locations to the file paths they contain.
+//
+// If AddressDirectory is set, address locations are mapped to files in a directory per address,
+// i.e. the contract `Foo` at address 0x1 is in the file `<AddressDirectory>/0000000000000001/Foo.cdc`.
+type FileSourceMapper struct {
+	AddressDirectory string
+}
+
+var _ SourceMapper = FileSourceMapper{}
+
+func (m FileSourceMapper) Location(path string) common.Location {
+	path = filepath.Clean(path)
+
+	if m.AddressDirectory != "" {
+		relativePath, err := filepath.Rel(filepath.Clean(m.AddressDirectory), path)
+		if err == nil {
+			location, ok := addressLocationFromRelativePath(relativePath)
+			if ok {
+				return location
+			}
+		}
+	}
+
+	return common.StringLocation(path)
+}
+
+func addressLocationFromRelativePath(relativePath string) (common.AddressLocation, bool) {
+	parts := strings.Split(filepath.ToSlash(relativePath), "/")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], sourceFileExtension) {
+		return common.AddressLocation{}, false
+	}
+
+	address, err := common.HexToAddress(parts[0])
+	if err != nil {
+		return common.AddressLocation{}, false
+	}
+
+	return common.AddressLocation{
+		Address: address,
+		Name:    strings.TrimSuffix(parts[1], sourceFileExtension),
+	}, true
+}
+
+func (m FileSourceMapper) Path(location common.Location) string {
+	switch location := location.(type) {
+	case common.StringLocation:
+		return string(location)
+
+	case common.AddressLocation:
+		if m.AddressDirectory == "" {
+			return ""
+		}
+		return filepath.Join(
+			m.AddressDirectory,
+			location.Address.Hex(),
+			location.Name+sourceFileExtension,
+		)
+
+	default:
+		return ""
+	}
+}
+
+func (s *Server) source(location common.Location) *Source {
+	path := s.sources.Path(location)
+	if path == "" {
+		return &Source{
+			Name: location.String(),
+		}
+	}
+
+	return &Source{
+		Name: filepath.Base(path),
+		Path: path,
+	}
+}
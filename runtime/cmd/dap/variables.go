@@ -0,0 +1,180 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dap
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// variableContainer lazily produces the variables of a scope or of a structured value.
+type variableContainer func() []Variable
+
+// variableHandles keeps track of the variable containers handed out to the client
+// while the program is stopped. The handles are invalidated when the program continues.
+type variableHandles struct {
+	containers []variableContainer
+}
+
+// add registers the given container and returns its reference.
+// References start at 1, as 0 indicates that a variable has no children.
+func (h *variableHandles) add(container variableContainer) int {
+	h.containers = append(h.containers, container)
+	return len(h.containers)
+}
+
+func (h *variableHandles) get(reference int) variableContainer {
+	index := reference - 1
+	if index < 0 || index >= len(h.containers) {
+		return nil
+	}
+	return h.containers[index]
+}
+
+func (h *variableHandles) reset() {
+	h.containers = nil
+}
+
+func (s *Server) activationVariables(
+	inter *interpreter.Interpreter,
+	activation *interpreter.VariableActivation,
+) []Variable {
+	if activation == nil {
+		return nil
+	}
+
+	values := activation.FunctionValues()
+
+	names := make([]string, 0, len(values))
+	for name := range values { //nolint:maprangecheck
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variables := make([]Variable, 0, len(names))
+	for _, name := range names {
+		variables = append(
+			variables,
+			s.variable(inter, name, values[name].GetValue()),
+		)
+	}
+	return variables
+}
+
+func (s *Server) globalVariables(inter *interpreter.Interpreter) []Variable {
+	// The iteration order of the globals is undefined,
+	// so sort the variables by name to produce a deterministic response
+
+	var variables []Variable
+	inter.Globals.ForEach(func(name string, variable *interpreter.Variable) {
+		variables = append(
+			variables,
+			s.variable(inter, name, variable.GetValue()),
+		)
+	})
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+
+	return variables
+}
+
+// variable returns the DAP variable for the given value.
+// If the value has children (e.g. fields or elements),
+// a container for them is registered.
+func (s *Server) variable(inter *interpreter.Interpreter, name string, value interpreter.Value) Variable {
+	if value == nil {
+		return Variable{
+			Name:  name,
+			Value: "nil",
+		}
+	}
+
+	var container variableContainer
+
+	switch value := value.(type) {
+	case *interpreter.CompositeValue:
+		container = func() []Variable {
+			var variables []Variable
+			value.ForEachField(inter, func(fieldName string, fieldValue interpreter.Value) {
+				variables = append(variables, s.variable(inter, fieldName, fieldValue))
+			})
+			return variables
+		}
+
+	case *interpreter.ArrayValue:
+		if value.Count() > 0 {
+			container = func() []Variable {
+				var variables []Variable
+				index := 0
+				value.Iterate(inter, func(element interpreter.Value) (resume bool) {
+					variables = append(variables, s.variable(inter, fmt.Sprintf("[%d]", index), element))
+					index++
+					return true
+				})
+				return variables
+			}
+		}
+
+	case *interpreter.DictionaryValue:
+		if value.Count() > 0 {
+			container = func() []Variable {
+				var variables []Variable
+				value.Iterate(inter, func(key, element interpreter.Value) (resume bool) {
+					variables = append(variables, s.variable(inter, key.String(), element))
+					return true
+				})
+				return variables
+			}
+		}
+
+	case *interpreter.SomeValue:
+		inner := s.variable(
+			inter,
+			name,
+			value.InnerValue(inter, interpreter.EmptyLocationRange),
+		)
+		inner.Value = value.String()
+		inner.Type = valueType(inter, value)
+		return inner
+	}
+
+	variable := Variable{
+		Name:  name,
+		Value: value.String(),
+		Type:  valueType(inter, value),
+	}
+
+	if container != nil {
+		variable.VariablesReference = s.variableHandles.add(container)
+	}
+
+	return variable
+}
+
+func valueType(inter *interpreter.Interpreter, value interpreter.Value) string {
+	staticType := value.StaticType(inter)
+	if staticType == nil {
+		return ""
+	}
+	return staticType.String()
+}
@@ -0,0 +1,55 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/cmd/dap"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+var addressDirectoryFlag = flag.String(
+	"addressDirectory",
+	"",
+	"directory containing the source files of contracts, in a directory per address (<address>/<name>.cdc)",
+)
+
+// main runs a Debug Adapter Protocol server over standard input and output,
+// which allows editors to debug Cadence programs.
+func main() {
+	flag.Parse()
+
+	server := dap.NewServer(
+		os.Stdin,
+		os.Stdout,
+		interpreter.NewDebugger(),
+		dap.RunFile,
+		dap.FileSourceMapper{
+			AddressDirectory: *addressDirectoryFlag,
+		},
+	)
+
+	err := server.Run()
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+}
@@ -21,12 +21,16 @@ package execute
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/c-bata/go-prompt"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
 )
 
 const commandShortHelp = "h"
@@ -35,24 +39,82 @@ const commandShortContinue = "c"
 const commandLongContinue = "continue"
 const commandShortNext = "n"
 const commandLongNext = "next"
+const commandShortOver = "o"
+const commandLongOver = "over"
+const commandShortStep = "si"
+const commandLongStep = "step"
+const commandShortFinish = "f"
+const commandLongFinish = "finish"
 const commandLongExit = "exit"
 const commandShortShow = "s"
 const commandLongShow = "show"
+const commandShortEval = "e"
+const commandLongEval = "eval"
 const commandShortWhere = "w"
 const commandLongWhere = "where"
+const commandShortBacktrace = "bt"
+const commandLongBacktrace = "backtrace"
+const commandShortBreak = "b"
+const commandLongBreak = "break"
+const commandShortLogpoint = "lp"
+const commandLongLogpoint = "logpoint"
+const commandShortBreakpoints = "bl"
+const commandLongBreakpoints = "breakpoints"
+const commandShortDelete = "d"
+const commandLongDelete = "delete"
+
+const breakpointHitsKeyword = "hits"
+const breakpointIfKeyword = "if"
 
 var debuggerCommandSuggestions = []prompt.Suggest{
 	{Text: commandLongContinue, Description: "Continue"},
-	{Text: commandLongNext, Description: "Next / step"},
+	{Text: commandLongNext, Description: "Next statement"},
+	{Text: commandLongOver, Description: "Step over"},
+	{Text: commandLongStep, Description: "Step in"},
+	{Text: commandLongFinish, Description: "Step out"},
 	{Text: commandLongWhere, Description: "Location info"},
+	{Text: commandLongBacktrace, Description: "Call stack"},
 	{Text: commandLongShow, Description: "Show variable(s)"},
+	{Text: commandLongEval, Description: "Evaluate expression: eval <expression>"},
+	{Text: commandLongBreak, Description: "Add breakpoint: break <line> [hits <condition>] [if <condition>]"},
+	{Text: commandLongLogpoint, Description: "Add logpoint: logpoint <line> <message with {expressions}>"},
+	{Text: commandLongBreakpoints, Description: "List breakpoints"},
+	{Text: commandLongDelete, Description: "Delete breakpoint: delete <id>"},
 	{Text: commandLongExit, Description: "Exit"},
 	{Text: commandLongHelp, Description: "Help"},
 }
 
+// StartDebugger starts handling the stops of the given debugger interactively:
+// When the program is paused, e.g. at a breakpoint,
+// an interactive debugger is run until the program is continued.
+//
+// An interrupt signal (e.g. ^C) pauses the running program.
+func StartDebugger(debugger *interpreter.Debugger) {
+	debugger.SetLogHandler(func(message string) {
+		fmt.Println(message)
+	})
+	debugger.SetExpressionParser(parser.ParseExpression)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	go func() {
+		for range signals {
+			debugger.RequestPause()
+		}
+	}()
+
+	go func() {
+		for stop := range debugger.Stops() {
+			NewInteractiveDebugger(debugger, stop).Run()
+		}
+	}()
+}
+
 type InteractiveDebugger struct {
-	debugger *interpreter.Debugger
-	stop     interpreter.Stop
+	debugger  *interpreter.Debugger
+	stop      interpreter.Stop
+	continued bool
 }
 
 func NewInteractiveDebugger(debugger *interpreter.Debugger, stop interpreter.Stop) *InteractiveDebugger {
@@ -63,13 +125,30 @@ func NewInteractiveDebugger(debugger *interpreter.Debugger, stop interpreter.Sto
 }
 
 func (d *InteractiveDebugger) Continue() {
+	d.continued = true
 	d.debugger.Continue()
 }
 
+// Next continues the program and pauses it at the next statement,
+// which might be in a function invoked by the current statement
 func (d *InteractiveDebugger) Next() {
 	d.stop = d.debugger.Next()
 }
 
+// Over continues the program and pauses it at the next statement
+// of the current function, or of its caller
+func (d *InteractiveDebugger) Over() {
+	d.stop = d.debugger.StepOver()
+}
+
+func (d *InteractiveDebugger) Step() {
+	d.stop = d.debugger.StepIn()
+}
+
+func (d *InteractiveDebugger) Finish() {
+	d.stop = d.debugger.StepOut()
+}
+
 // Show shows the values for the variables with the given names.
 // If no names are given, lists all non-base variables
 func (d *InteractiveDebugger) Show(names []string) {
@@ -106,6 +185,22 @@ func (d *InteractiveDebugger) Show(names []string) {
 	}
 }
 
+// Eval evaluates the given expression in the current scope and prints the result
+func (d *InteractiveDebugger) Eval(code string) {
+	if strings.TrimSpace(code) == "" {
+		printDebuggerError("missing expression")
+		return
+	}
+
+	value, err := d.debugger.Evaluate(d.stop, code)
+	if err != nil {
+		printDebuggerError("%s", err)
+		return
+	}
+
+	fmt.Println(formatValue(value))
+}
+
 func (d *InteractiveDebugger) Run() {
 
 	executor := func(in string) {
@@ -122,10 +217,28 @@ func (d *InteractiveDebugger) Run() {
 			d.Continue()
 		case commandShortNext, commandLongNext:
 			d.Next()
+		case commandShortOver, commandLongOver:
+			d.Over()
+		case commandShortStep, commandLongStep:
+			d.Step()
+		case commandShortFinish, commandLongFinish:
+			d.Finish()
 		case commandShortShow, commandLongShow:
 			d.Show(arguments)
+		case commandShortEval, commandLongEval:
+			d.Eval(strings.Join(arguments, " "))
 		case commandShortWhere, commandLongWhere:
 			d.Where()
+		case commandShortBacktrace, commandLongBacktrace:
+			d.Backtrace()
+		case commandShortBreak, commandLongBreak:
+			d.Break(arguments)
+		case commandShortLogpoint, commandLongLogpoint:
+			d.Logpoint(arguments)
+		case commandShortBreakpoints, commandLongBreakpoints:
+			d.Breakpoints()
+		case commandShortDelete, commandLongDelete:
+			d.Delete(arguments)
 		case commandShortHelp, commandLongHelp:
 			d.Help()
 		case commandLongExit:
@@ -154,6 +267,7 @@ func (d *InteractiveDebugger) Run() {
 	}
 
 	fmt.Println()
+	d.Where()
 
 	prompt.New(
 		executor,
@@ -161,6 +275,11 @@ func (d *InteractiveDebugger) Run() {
 		prompt.OptionPrefix("(cdb) "),
 		prompt.OptionSetExitCheckerOnInput(exitChecker),
 	).Run()
+
+	// The prompt might have been exited without continuing, e.g. using ^D
+	if !d.continued {
+		d.Continue()
+	}
 }
 
 func (d *InteractiveDebugger) Help() {
@@ -182,3 +301,173 @@ func (d *InteractiveDebugger) Where() {
 		d.stop.Statement.StartPosition().Line,
 	)
 }
+
+// Backtrace prints the call stack, starting with the innermost frame
+func (d *InteractiveDebugger) Backtrace() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	for i, frame := range d.stop.CallStack {
+		functionName := frame.FunctionName
+		if functionName == "" {
+			functionName = "<entry point>"
+		}
+
+		_, _ = fmt.Fprintf(w,
+			"#%d\t%s\t%s @ %d:%d\n",
+			i,
+			functionName,
+			frame.Location,
+			frame.Range.StartPos.Line,
+			frame.Range.StartPos.Column,
+		)
+	}
+	_ = w.Flush()
+}
+
+func printDebuggerError(format string, arguments ...any) {
+	message := fmt.Sprintf("error: "+format, arguments...)
+	fmt.Println(colorizeError(message))
+}
+
+func parseLine(arguments []string) (uint, bool) {
+	if len(arguments) < 1 {
+		printDebuggerError("missing line")
+		return 0, false
+	}
+
+	line, err := strconv.ParseUint(arguments[0], 10, 0)
+	if err != nil || line == 0 {
+		printDebuggerError("invalid line '%s'", arguments[0])
+		return 0, false
+	}
+
+	return uint(line), true
+}
+
+// Break adds a breakpoint at a line of the current program.
+// The breakpoint may have a hit condition and a condition,
+// e.g. `break 12 hits >= 3 if amount > 10.0`
+func (d *InteractiveDebugger) Break(arguments []string) {
+	addBreakpoint(d.debugger, d.stop.Interpreter.Location, arguments)
+}
+
+// Logpoint adds a logpoint at a line of the current program,
+// e.g. `logpoint 12 balance is {vault.balance}`
+func (d *InteractiveDebugger) Logpoint(arguments []string) {
+	addLogpoint(d.debugger, d.stop.Interpreter.Location, arguments)
+}
+
+// Breakpoints lists all breakpoints
+func (d *InteractiveDebugger) Breakpoints() {
+	printBreakpoints(d.debugger)
+}
+
+// Delete deletes the breakpoint with the given ID
+func (d *InteractiveDebugger) Delete(arguments []string) {
+	deleteBreakpoint(d.debugger, arguments)
+}
+
+// addBreakpoint adds a breakpoint at a line of the program with the given location.
+// The arguments are the line, optionally followed by a hit condition and a condition,
+// e.g. `12 hits >= 3 if amount > 10.0`
+func addBreakpoint(debugger *interpreter.Debugger, location common.Location, arguments []string) {
+	line, ok := parseLine(arguments)
+	if !ok {
+		return
+	}
+
+	var options interpreter.BreakpointOptions
+
+	rest := strings.TrimSpace(strings.Join(arguments[1:], " "))
+
+	if strings.HasPrefix(rest, breakpointHitsKeyword+" ") {
+		rest = strings.TrimPrefix(rest, breakpointHitsKeyword+" ")
+
+		hitCondition := rest
+		rest = ""
+		if index := strings.Index(hitCondition, " "+breakpointIfKeyword+" "); index >= 0 {
+			rest = strings.TrimSpace(hitCondition[index:])
+			hitCondition = hitCondition[:index]
+		}
+
+		var err error
+		options.HitCondition, err = interpreter.ParseHitCondition(hitCondition)
+		if err != nil {
+			printDebuggerError("%s", err)
+			return
+		}
+	}
+
+	if strings.HasPrefix(rest, breakpointIfKeyword+" ") {
+		options.Condition = strings.TrimSpace(strings.TrimPrefix(rest, breakpointIfKeyword+" "))
+		rest = ""
+	}
+
+	if rest != "" {
+		printDebuggerError("invalid breakpoint options '%s'", rest)
+		return
+	}
+
+	addBreakpointWithOptions(debugger, location, line, options)
+}
+
+// addLogpoint adds a logpoint at a line of the program with the given location.
+// The arguments are the line, followed by the message, e.g. `12 balance is {vault.balance}`
+func addLogpoint(debugger *interpreter.Debugger, location common.Location, arguments []string) {
+	line, ok := parseLine(arguments)
+	if !ok {
+		return
+	}
+
+	message := strings.Join(arguments[1:], " ")
+	if message == "" {
+		printDebuggerError("missing message")
+		return
+	}
+
+	addBreakpointWithOptions(
+		debugger,
+		location,
+		line,
+		interpreter.BreakpointOptions{
+			LogMessage: message,
+		},
+	)
+}
+
+func addBreakpointWithOptions(
+	debugger *interpreter.Debugger,
+	location common.Location,
+	line uint,
+	options interpreter.BreakpointOptions,
+) {
+	breakpoint, err := debugger.AddBreakpointWithOptions(location, line, options)
+	if err != nil {
+		printDebuggerError("%s", err)
+		return
+	}
+
+	fmt.Printf("added %s\n", breakpoint)
+}
+
+func printBreakpoints(debugger *interpreter.Debugger) {
+	for _, breakpoint := range debugger.Breakpoints() {
+		fmt.Printf("%s (%d hits)\n", &breakpoint, breakpoint.Hits)
+	}
+}
+
+func deleteBreakpoint(debugger *interpreter.Debugger, arguments []string) {
+	if len(arguments) < 1 {
+		printDebuggerError("missing breakpoint ID")
+		return
+	}
+
+	id, err := strconv.Atoi(arguments[0])
+	if err != nil {
+		printDebuggerError("invalid breakpoint ID '%s'", arguments[0])
+		return
+	}
+
+	if !debugger.RemoveBreakpointByID(id) {
+		printDebuggerError("no breakpoint with ID %d", id)
+	}
+}
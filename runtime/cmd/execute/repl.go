@@ -34,7 +34,11 @@ import (
 	"github.com/onflow/cadence/runtime/pretty"
 )
 
-func RunREPL() {
+// RunREPL runs the interactive REPL.
+//
+// If a debugger is given, breakpoints can be added to the functions declared in the REPL,
+// and the stops of the debugger should be handled, e.g. using StartDebugger.
+func RunREPL(debugger *interpreter.Debugger) {
 	printReplWelcome()
 
 	lineNumber := 1
@@ -53,6 +57,7 @@ func RunREPL() {
 		func(value interpreter.Value) {
 			fmt.Println(formatValue(value))
 		},
+		debugger,
 	)
 
 	if err != nil {
@@ -61,7 +66,7 @@ func RunREPL() {
 
 	executor := func(line string) {
 		if code == "" && strings.HasPrefix(line, ".") {
-			handleCommand(repl, line)
+			handleCommand(repl, debugger, line)
 			code = ""
 			return
 		}
@@ -122,12 +127,39 @@ Commands are prefixed with a dot. Valid commands are:
 .help                 Print this help message
 .export variable      Export variable
 
+When debugging (-debug), breakpoints can be added to the lines of functions,
+and are hit when the functions are invoked:
+
+.break line [hits condition] [if condition]
+                      Add breakpoint
+.logpoint line message
+                      Add logpoint, the message may contain {expressions}
+.breakpoints          List breakpoints
+.delete id            Delete breakpoint
+
 Press ^C to abort current expression, ^D to exit`
 
 const replAssistanceMessage = `Type '.help' for assistance.`
 
-func handleCommand(repl *runtime.REPL, command string) {
+func handleCommand(repl *runtime.REPL, debugger *interpreter.Debugger, command string) {
 	parts := strings.SplitN(command, " ", 2)
+
+	switch parts[0] {
+	case ".break", ".logpoint", ".breakpoints", ".delete":
+		if debugger == nil {
+			fmt.Println(colorizeError("Debugging is not enabled"))
+			return
+		}
+
+		var arguments []string
+		if len(parts) > 1 {
+			arguments = strings.Fields(parts[1])
+		}
+
+		handleDebuggerCommand(debugger, parts[0], arguments)
+		return
+	}
+
 	switch parts[0] {
 	case ".exit":
 		os.Exit(0)
@@ -159,6 +191,21 @@ func handleCommand(repl *runtime.REPL, command string) {
 	}
 }
 
+func handleDebuggerCommand(debugger *interpreter.Debugger, command string, arguments []string) {
+	location := common.REPLLocation{}
+
+	switch command {
+	case ".break":
+		addBreakpoint(debugger, location, arguments)
+	case ".logpoint":
+		addLogpoint(debugger, location, arguments)
+	case ".breakpoints":
+		printBreakpoints(debugger)
+	case ".delete":
+		deleteBreakpoint(debugger, arguments)
+	}
+}
+
 func printReplWelcome() {
 	fmt.Printf("Welcome to Cadence %s!\n%s\n\n", cadence.Version, replAssistanceMessage)
 }
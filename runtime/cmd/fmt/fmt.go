@@ -0,0 +1,144 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/tools/format"
+)
+
+const (
+	exitCodeSuccess     = 0
+	exitCodeUnformatted = 1
+	exitCodeFailure     = 2
+)
+
+type options struct {
+	paths  []string
+	check  bool
+	write  bool
+	config format.Config
+}
+
+// run formats the programs in the files of the given options, or the given input if no files are given,
+// writes the formatted code or the unformatted files to the given output, and errors to the given error output,
+// and returns the exit code
+func run(input io.Reader, output io.Writer, errorOutput io.Writer, options options) int {
+
+	reportFailure := func(err error) int {
+		_, _ = fmt.Fprintln(errorOutput, pretty.FormatErrorMessage(pretty.ErrorPrefix, err.Error(), false))
+		return exitCodeFailure
+	}
+
+	if options.config.MaxLineWidth <= 0 {
+		return reportFailure(fmt.Errorf("invalid line width: %d", options.config.MaxLineWidth))
+	}
+
+	if len(options.paths) == 0 {
+		if options.write {
+			return reportFailure(fmt.Errorf("cannot write the standard input"))
+		}
+
+		code, err := io.ReadAll(input)
+		if err != nil {
+			return reportFailure(err)
+		}
+
+		formatted, err := options.config.Source(code)
+		if err != nil {
+			return reportFailure(err)
+		}
+
+		if options.check {
+			if !bytes.Equal(code, formatted) {
+				_, _ = fmt.Fprintln(output, "<standard input>")
+				return exitCodeUnformatted
+			}
+			return exitCodeSuccess
+		}
+
+		_, _ = output.Write(formatted)
+		return exitCodeSuccess
+	}
+
+	exitCode := exitCodeSuccess
+
+	for _, path := range options.paths {
+		unformatted, err := formatFile(path, output, options)
+		if err != nil {
+			_, _ = fmt.Fprintf(errorOutput, "%s: ", path)
+			reportFailure(err)
+			exitCode = exitCodeFailure
+			continue
+		}
+
+		if unformatted && exitCode == exitCodeSuccess {
+			exitCode = exitCodeUnformatted
+		}
+	}
+
+	return exitCode
+}
+
+// formatFile formats the program in the file with the given path.
+//
+// In check mode, the path is written to the given output if the file is not formatted.
+// Otherwise, the formatted code is written to the file or the given output.
+func formatFile(path string, output io.Writer, options options) (unformatted bool, err error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	formatted, err := options.config.Source(code)
+	if err != nil {
+		return false, err
+	}
+
+	unformatted = !bytes.Equal(code, formatted)
+
+	switch {
+	case options.check:
+		if unformatted {
+			_, _ = fmt.Fprintln(output, path)
+		}
+		return unformatted, nil
+
+	case options.write:
+		if !unformatted {
+			return false, nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+
+		return false, os.WriteFile(path, formatted, info.Mode())
+
+	default:
+		_, err = output.Write(formatted)
+		return false, err
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/tools/format"
+)
+
+const testUnformattedCode = `
+pub fun main(): Int { return 1 }
+`
+
+const testFormattedCode = `pub fun main(): Int {
+    return 1
+}
+`
+
+func writeTestFile(t *testing.T, name string, code string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(code), 0o644)
+	require.NoError(t, err)
+	return path
+}
+
+func runTest(t *testing.T, input string, options options) (exitCode int, output string, errorOutput string) {
+	if options.config == (format.Config{}) {
+		options.config = format.DefaultConfig
+	}
+
+	var outputBuilder, errorOutputBuilder strings.Builder
+	exitCode = run(strings.NewReader(input), &outputBuilder, &errorOutputBuilder, options)
+	return exitCode, outputBuilder.String(), errorOutputBuilder.String()
+}
+
+func TestFormatStandardInput(t *testing.T) {
+
+	t.Parallel()
+
+	exitCode, output, errorOutput := runTest(t, testUnformattedCode, options{})
+
+	assert.Empty(t, errorOutput)
+	assert.Equal(t, exitCodeSuccess, exitCode)
+	assert.Equal(t, testFormattedCode, output)
+}
+
+func TestFormatFiles(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("output", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := writeTestFile(t, "test.cdc", testUnformattedCode)
+
+		exitCode, output, errorOutput := runTest(t, "", options{
+			paths: []string{path},
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Equal(t, exitCodeSuccess, exitCode)
+		assert.Equal(t, testFormattedCode, output)
+	})
+
+	t.Run("write", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := writeTestFile(t, "test.cdc", testUnformattedCode)
+
+		exitCode, output, errorOutput := runTest(t, "", options{
+			paths: []string{path},
+			write: true,
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Empty(t, output)
+		assert.Equal(t, exitCodeSuccess, exitCode)
+
+		code, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, testFormattedCode, string(code))
+	})
+}
+
+func TestFormatCheck(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("formatted", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := writeTestFile(t, "test.cdc", testFormattedCode)
+
+		exitCode, output, errorOutput := runTest(t, "", options{
+			paths: []string{path},
+			check: true,
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Empty(t, output)
+		assert.Equal(t, exitCodeSuccess, exitCode)
+	})
+
+	t.Run("unformatted", func(t *testing.T) {
+
+		t.Parallel()
+
+		formattedPath := writeTestFile(t, "formatted.cdc", testFormattedCode)
+		unformattedPath := writeTestFile(t, "unformatted.cdc", testUnformattedCode)
+
+		exitCode, output, errorOutput := runTest(t, "", options{
+			paths: []string{formattedPath, unformattedPath},
+			check: true,
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Equal(t, unformattedPath+"\n", output)
+		assert.Equal(t, exitCodeUnformatted, exitCode)
+
+		// The file is not changed
+
+		code, err := os.ReadFile(unformattedPath)
+		require.NoError(t, err)
+		assert.Equal(t, testUnformattedCode, string(code))
+	})
+
+	t.Run("standard input", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, output, _ := runTest(t, testUnformattedCode, options{
+			check: true,
+		})
+
+		assert.Equal(t, "<standard input>\n", output)
+		assert.Equal(t, exitCodeUnformatted, exitCode)
+	})
+}
+
+func TestFormatFailures(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("parsing error", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := writeTestFile(t, "invalid.cdc", `pub fun main( {`)
+
+		exitCode, _, errorOutput := runTest(t, "", options{
+			paths: []string{path},
+			check: true,
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, path)
+		assert.Contains(t, errorOutput, "Parsing failed")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, _, errorOutput := runTest(t, "", options{
+			paths: []string{filepath.Join(t.TempDir(), "missing.cdc")},
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, "missing.cdc")
+	})
+
+	t.Run("write standard input", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, _, errorOutput := runTest(t, testUnformattedCode, options{
+			write: true,
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, "cannot write the standard input")
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/onflow/cadence/tools/format"
+)
+
+var checkFlag = flag.Bool("check", false, "do not write the formatted code, but fail if any file is not formatted")
+
+var writeFlag = flag.Bool("w", false, "write the formatted code to the files instead of the standard output")
+
+var widthFlag = flag.Int("width", format.DefaultConfig.MaxLineWidth, "maximum line width")
+
+// main formats the Cadence programs in the given files,
+// or the program read from the standard input, if no files are given.
+//
+// In check mode, the files which are not formatted are listed,
+// and the exit code is 1 if there are any, so the command can be used to gate CI.
+func main() {
+	flag.Parse()
+
+	os.Exit(
+		run(
+			os.Stdin,
+			os.Stdout,
+			os.Stderr,
+			options{
+				paths: flag.Args(),
+				check: *checkFlag,
+				write: *writeFlag,
+				config: format.Config{
+					MaxLineWidth: *widthFlag,
+					Indent:       format.DefaultConfig.Indent,
+				},
+			},
+		),
+	)
+}
@@ -0,0 +1,137 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// fix applies the first suggested fix of each of the given diagnostics and load errors
+// to the source files of the given paths, and returns the number of applied fixes.
+//
+// Fixes which overlap with previously applied fixes are skipped.
+func (l *linter) fix(
+	paths []string,
+	diagnostics []analysis.Diagnostic,
+	loadErrors []loadError,
+) (
+	int,
+	error,
+) {
+	fixes := map[common.Location][]analysis.SuggestedFix{}
+
+	for _, loadError := range loadErrors {
+		location := loadError.location
+		code, ok := l.codes[location]
+		if !ok {
+			continue
+		}
+		fixes[location] = append(
+			fixes[location],
+			errorFixes(loadError.err, location, string(code))...,
+		)
+	}
+
+	for _, diagnostic := range diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+		location := diagnostic.Location
+		fixes[location] = append(fixes[location], diagnostic.SuggestedFixes[0])
+	}
+
+	fixCount := 0
+
+	for _, path := range paths {
+		location := l.sources.Location(path)
+
+		locationFixes := fixes[location]
+		if len(locationFixes) == 0 {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return fixCount, err
+		}
+
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return fixCount, err
+		}
+
+		fixed, applied, err := analysis.ApplySuggestedFixes(code, locationFixes)
+		if err != nil {
+			return fixCount, err
+		}
+
+		if len(applied) == 0 {
+			continue
+		}
+
+		err = os.WriteFile(path, fixed, info.Mode())
+		if err != nil {
+			return fixCount, err
+		}
+
+		fixCount += len(applied)
+	}
+
+	return fixCount, nil
+}
+
+// errorFixes returns the first suggested fix of each error in the given error tree
+// which occurred in the program with the given location.
+//
+// Errors of imported programs are skipped.
+func errorFixes(err error, location common.Location, code string) []analysis.SuggestedFix {
+	var fixes []analysis.SuggestedFix
+
+	var walk func(err error)
+	walk = func(err error) {
+		if locatedErr, ok := err.(common.HasLocation); ok {
+			errLocation := locatedErr.ImportLocation()
+			if errLocation != nil && errLocation != location {
+				return
+			}
+		}
+
+		if hasFixes, ok := err.(errors.HasSuggestedFixes[ast.TextEdit]); ok {
+			errFixes := hasFixes.SuggestedFixes(code)
+			if len(errFixes) > 0 {
+				fixes = append(fixes, errFixes[0])
+			}
+		}
+
+		if parentErr, ok := err.(errors.ParentError); ok {
+			for _, childErr := range parentErr.ChildErrors() {
+				walk(childErr)
+			}
+		}
+	}
+
+	walk(err)
+
+	return fixes
+}
@@ -0,0 +1,104 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/parser/lexer"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const ignoreCommentPrefix = "lint:ignore"
+
+// ignoreComments are the analyzers ignored by `// lint:ignore <analyzer>[,<analyzer>...] [reason]` comments,
+// by line.
+//
+// A comment on its own line suppresses the diagnostics of the given analyzers
+// which start on the following line, and a comment after code suppresses the ones on its line.
+type ignoreComments map[int]map[string]struct{}
+
+func parseIgnoreComments(code []byte) ignoreComments {
+	ignores := ignoreComments{}
+
+	tokens := lexer.Lex(code, nil)
+	defer tokens.Reclaim()
+
+	for {
+		token := tokens.Next()
+		if token.Is(lexer.TokenEOF) {
+			break
+		}
+		if !token.Is(lexer.TokenLineComment) {
+			continue
+		}
+
+		comment := string(code[token.StartPos.Offset : token.EndPos.Offset+1])
+		comment = strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+
+		if !strings.HasPrefix(comment, ignoreCommentPrefix) {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(comment, ignoreCommentPrefix))
+		if len(fields) == 0 {
+			continue
+		}
+
+		line := token.StartPos.Line
+		if isOnOwnLine(code, token.StartPos.Offset) {
+			line++
+		}
+
+		names, ok := ignores[line]
+		if !ok {
+			names = map[string]struct{}{}
+			ignores[line] = names
+		}
+
+		for _, name := range strings.Split(fields[0], ",") {
+			if name == "" {
+				continue
+			}
+			names[name] = struct{}{}
+		}
+	}
+
+	return ignores
+}
+
+func (ignores ignoreComments) isIgnored(diagnostic analysis.Diagnostic) bool {
+	_, ok := ignores[diagnostic.StartPos.Line][diagnostic.Category]
+	return ok
+}
+
+// isOnOwnLine returns true if only whitespace precedes the given offset on its line
+func isOnOwnLine(code []byte, offset int) bool {
+	for i := offset - 1; i >= 0; i-- {
+		switch code[i] {
+		case '\n':
+			return true
+		case ' ', '\t', '\r':
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/cmd/dap"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/tools/analysis"
+	"github.com/onflow/cadence/tools/analysis/analyzers"
+)
+
+const (
+	exitCodeSuccess  = 0
+	exitCodeFindings = 1
+	exitCodeFailure  = 2
+)
+
+type options struct {
+	paths            []string
+	analyzers        string
+	format           format
+	addressDirectory string
+	useColor         bool
+	fix              bool
+}
+
+// run lints the programs in the files of the given options,
+// writes the diagnostics to the given output, and errors to the given error output,
+// and returns the exit code.
+//
+// If fixing is enabled, the suggested fixes are applied to the files,
+// and only the remaining diagnostics and errors are written
+func run(output io.Writer, errorOutput io.Writer, options options) int {
+
+	reportFailure := func(err error) int {
+		_, _ = fmt.Fprintln(errorOutput, pretty.FormatErrorMessage(pretty.ErrorPrefix, err.Error(), false))
+		return exitCodeFailure
+	}
+
+	selectedAnalyzers, err := selectAnalyzers(options.analyzers)
+	if err != nil {
+		return reportFailure(err)
+	}
+
+	writeDiagnostics, err := options.format.writer()
+	if err != nil {
+		return reportFailure(err)
+	}
+
+	if len(options.paths) == 0 {
+		return reportFailure(fmt.Errorf("no files given"))
+	}
+
+	linter := newLinter(options.addressDirectory)
+
+	diagnostics, loadErrors := linter.lint(options.paths, selectedAnalyzers)
+
+	if options.fix {
+		fixCount, err := linter.fix(options.paths, diagnostics, loadErrors)
+		if err != nil {
+			return reportFailure(err)
+		}
+
+		// Lint the fixed files again, so only the remaining problems are reported
+
+		if fixCount > 0 {
+			linter = newLinter(options.addressDirectory)
+			diagnostics, loadErrors = linter.lint(options.paths, selectedAnalyzers)
+		}
+	}
+
+	for _, loadError := range loadErrors {
+		var builder strings.Builder
+		printErr := pretty.NewErrorPrettyPrinter(&builder, options.useColor).
+			PrettyPrintError(loadError.err, loadError.location, linter.codes)
+		if printErr != nil {
+			return reportFailure(printErr)
+		}
+		_, _ = io.WriteString(errorOutput, builder.String())
+	}
+
+	err = writeDiagnostics(output, diagnostics, linter, options.useColor)
+	if err != nil {
+		return reportFailure(err)
+	}
+
+	switch {
+	case len(loadErrors) > 0:
+		return exitCodeFailure
+	case len(diagnostics) > 0:
+		return exitCodeFindings
+	default:
+		return exitCodeSuccess
+	}
+}
+
+// selectAnalyzers returns the analyzers with the given comma-separated names,
+// or all analyzers if no names are given
+func selectAnalyzers(names string) ([]*analysis.Analyzer, error) {
+	if strings.TrimSpace(names) == "" {
+		names = strings.Join(analyzers.Names(), ",")
+	}
+
+	var selected []*analysis.Analyzer
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		analyzer, ok := analyzers.Analyzers[name]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unknown analyzer %q, available analyzers: %s",
+				name,
+				strings.Join(analyzers.Names(), ", "),
+			)
+		}
+		selected = append(selected, analyzer)
+	}
+
+	return selected, nil
+}
+
+type loadError struct {
+	location common.Location
+	err      error
+}
+
+type linter struct {
+	sources dap.FileSourceMapper
+	config  *analysis.Config
+	// codes are the codes of all loaded programs, including imported programs
+	codes map[common.Location][]byte
+}
+
+func newLinter(addressDirectory string) *linter {
+	l := &linter{
+		sources: dap.FileSourceMapper{
+			AddressDirectory: addressDirectory,
+		},
+		codes: map[common.Location][]byte{},
+	}
+
+	l.config = &analysis.Config{
+		Mode:                        analysis.NeedTypes | analysis.NeedExtendedElaboration | analysis.NeedPositionInfo,
+		ResolveAddressContractNames: l.resolveAddressContractNames,
+		ResolveCode:                 l.resolveCode,
+	}
+
+	return l
+}
+
+// resolveAddressContractNames returns the names of the contracts in the directory of the address
+func (l *linter) resolveAddressContractNames(address common.Address) ([]string, error) {
+	if l.sources.AddressDirectory == "" {
+		return nil, fmt.Errorf("cannot import from address %s: no address directory given", address)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(l.sources.AddressDirectory, address.Hex(), "*.cdc"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".cdc"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (l *linter) resolveCode(
+	location common.Location,
+	_ common.Location,
+	_ ast.Range,
+) (
+	[]byte,
+	error,
+) {
+	if _, ok := location.(common.AddressLocation); ok && l.sources.AddressDirectory == "" {
+		return nil, fmt.Errorf("cannot import %s: no address directory given", location)
+	}
+
+	path := l.sources.Path(location)
+	if path == "" {
+		return nil, fmt.Errorf("cannot import %s: no source file", location)
+	}
+
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l.codes[location] = code
+
+	return code, nil
+}
+
+// lint loads the programs in the files with the given paths,
+// and runs the given analyzers on them.
+//
+// Imported programs are loaded, but not analyzed.
+// The diagnostics are sorted by location and position,
+// and diagnostics suppressed by ignore comments are removed.
+func (l *linter) lint(paths []string, analyzersToRun []*analysis.Analyzer) (
+	diagnostics []analysis.Diagnostic,
+	loadErrors []loadError,
+) {
+	programs := analysis.Programs{}
+
+	var locations []common.Location
+
+	for _, path := range paths {
+		location := l.sources.Location(path)
+
+		err := programs.Load(l.config, location)
+		if err != nil {
+			loadErrors = append(loadErrors, loadError{
+				location: location,
+				err:      err,
+			})
+			continue
+		}
+
+		locations = append(locations, location)
+	}
+
+	var lock sync.Mutex
+
+	for _, location := range locations {
+		program := programs[location]
+		ignores := parseIgnoreComments(program.Code)
+
+		program.Run(
+			analyzersToRun,
+			func(diagnostic analysis.Diagnostic) {
+				if ignores.isIgnored(diagnostic) {
+					return
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				diagnostics = append(diagnostics, diagnostic)
+			},
+		)
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a := diagnostics[i]
+		b := diagnostics[j]
+		aLocation := a.Location.String()
+		bLocation := b.Location.String()
+		if aLocation != bLocation {
+			return aLocation < bLocation
+		}
+		if a.StartPos.Offset != b.StartPos.Offset {
+			return a.StartPos.Offset < b.StartPos.Offset
+		}
+		return a.Category < b.Category
+	})
+
+	return diagnostics, loadErrors
+}
+
+// path returns the path of the source file of the given location,
+// or the location itself, if it has no source file
+func (l *linter) path(location common.Location) string {
+	path := l.sources.Path(location)
+	if path == "" {
+		return location.String()
+	}
+	return path
+}
@@ -0,0 +1,375 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/tools/analysis/analyzers"
+)
+
+const testContractCode = `
+pub contract Foo {
+    pub fun answer(): Int {
+        return 42
+    }
+}
+`
+
+const testScriptCode = `
+import Foo from 0x1
+
+pub fun main(): Int {
+    let x = 1
+    // lint:ignore unused-variable
+    let y = 2
+    let z = 3 // lint:ignore redundant-cast,unused-variable reason
+    let a = 4 // lint:ignore redundant-cast
+    return Foo.answer()
+}
+`
+
+func writeTestFiles(t *testing.T) (scriptPath string, addressDirectory string) {
+	directory := t.TempDir()
+
+	addressDirectory = filepath.Join(directory, "addresses")
+	contractDirectory := filepath.Join(addressDirectory, "0000000000000001")
+	require.NoError(t, os.MkdirAll(contractDirectory, 0o755))
+
+	err := os.WriteFile(filepath.Join(contractDirectory, "Foo.cdc"), []byte(testContractCode), 0o644)
+	require.NoError(t, err)
+
+	scriptPath = filepath.Join(directory, "script.cdc")
+	err = os.WriteFile(scriptPath, []byte(testScriptCode), 0o644)
+	require.NoError(t, err)
+
+	return scriptPath, addressDirectory
+}
+
+func runTest(t *testing.T, options options) (exitCode int, output string, errorOutput string) {
+	var outputBuilder, errorOutputBuilder strings.Builder
+	exitCode = run(&outputBuilder, &errorOutputBuilder, options)
+	return exitCode, outputBuilder.String(), errorOutputBuilder.String()
+}
+
+func TestLintText(t *testing.T) {
+
+	t.Parallel()
+
+	scriptPath, addressDirectory := writeTestFiles(t)
+
+	exitCode, output, errorOutput := runTest(t, options{
+		paths:            []string{scriptPath},
+		analyzers:        analyzers.UnusedVariableCategory,
+		format:           formatText,
+		addressDirectory: addressDirectory,
+	})
+
+	assert.Empty(t, errorOutput)
+	assert.Equal(t, exitCodeFindings, exitCode)
+
+	// The diagnostics for `y` and `z` are suppressed,
+	// the comment for `a` does not suppress diagnostics of the analyzer
+
+	assert.Equal(t, 2, strings.Count(output, "unused-variable: "))
+	assert.Contains(t, output, "unused-variable: unused constant `x`")
+	assert.Contains(t, output, "unused-variable: unused constant `a`")
+	assert.Contains(t, output, scriptPath+":5:8")
+	assert.Contains(t, output, "let x = 1")
+}
+
+func TestLintNoFindings(t *testing.T) {
+
+	t.Parallel()
+
+	scriptPath, addressDirectory := writeTestFiles(t)
+
+	exitCode, output, errorOutput := runTest(t, options{
+		paths:            []string{scriptPath},
+		analyzers:        analyzers.UnusedImportCategory,
+		format:           formatText,
+		addressDirectory: addressDirectory,
+	})
+
+	assert.Empty(t, errorOutput)
+	assert.Empty(t, output)
+	assert.Equal(t, exitCodeSuccess, exitCode)
+}
+
+func TestLintJSON(t *testing.T) {
+
+	t.Parallel()
+
+	scriptPath, addressDirectory := writeTestFiles(t)
+
+	exitCode, output, errorOutput := runTest(t, options{
+		paths:            []string{scriptPath},
+		analyzers:        analyzers.UnusedVariableCategory,
+		format:           formatJSON,
+		addressDirectory: addressDirectory,
+	})
+
+	assert.Empty(t, errorOutput)
+	assert.Equal(t, exitCodeFindings, exitCode)
+
+	var diagnostics []jsonDiagnostic
+	require.NoError(t, json.Unmarshal([]byte(output), &diagnostics))
+
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t,
+		jsonDiagnostic{
+			Location: scriptPath,
+			Path:     scriptPath,
+			Category: analyzers.UnusedVariableCategory,
+			Message:  "unused constant `x`",
+			StartPos: jsonPosition{Offset: 52, Line: 5, Column: 8},
+			EndPos:   jsonPosition{Offset: 52, Line: 5, Column: 8},
+		},
+		diagnostics[0],
+	)
+}
+
+func TestLintSARIF(t *testing.T) {
+
+	t.Parallel()
+
+	scriptPath, addressDirectory := writeTestFiles(t)
+
+	exitCode, output, errorOutput := runTest(t, options{
+		paths:            []string{scriptPath},
+		analyzers:        analyzers.UnusedVariableCategory,
+		format:           formatSARIF,
+		addressDirectory: addressDirectory,
+	})
+
+	assert.Empty(t, errorOutput)
+	assert.Equal(t, exitCodeFindings, exitCode)
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(output), &log))
+
+	assert.Equal(t, sarifVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	assert.Equal(t, sarifToolName, run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, len(analyzers.Analyzers))
+
+	require.Len(t, run.Results, 2)
+	assert.Equal(t,
+		sarifResult{
+			RuleID: analyzers.UnusedVariableCategory,
+			Level:  "warning",
+			Message: sarifMessage{
+				Text: "unused constant `x`",
+			},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI: filepath.ToSlash(scriptPath),
+						},
+						Region: sarifRegion{
+							StartLine:   5,
+							StartColumn: 9,
+							EndLine:     5,
+							EndColumn:   10,
+						},
+					},
+				},
+			},
+		},
+		run.Results[0],
+	)
+}
+
+func TestLintFailures(t *testing.T) {
+
+	t.Parallel()
+
+	scriptPath, addressDirectory := writeTestFiles(t)
+
+	t.Run("unknown analyzer", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, _, errorOutput := runTest(t, options{
+			paths:     []string{scriptPath},
+			analyzers: "unknown",
+			format:    formatText,
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, `unknown analyzer "unknown"`)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, _, errorOutput := runTest(t, options{
+			paths:  []string{scriptPath},
+			format: "xml",
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, `unknown format "xml"`)
+	})
+
+	t.Run("missing address directory", func(t *testing.T) {
+
+		t.Parallel()
+
+		exitCode, _, errorOutput := runTest(t, options{
+			paths:  []string{scriptPath},
+			format: formatText,
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, "no address directory given")
+	})
+
+	t.Run("check error", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := filepath.Join(filepath.Dir(addressDirectory), "invalid.cdc")
+		err := os.WriteFile(path, []byte(`pub fun main(): Int { return true }`), 0o644)
+		require.NoError(t, err)
+
+		exitCode, _, errorOutput := runTest(t, options{
+			paths:            []string{path, scriptPath},
+			format:           formatText,
+			addressDirectory: addressDirectory,
+		})
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, errorOutput, "mismatched types")
+	})
+}
+
+func TestLintFix(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("diagnostics", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, addressDirectory := writeTestFiles(t)
+
+		path := filepath.Join(filepath.Dir(addressDirectory), "fix.cdc")
+		err := os.WriteFile(
+			path,
+			[]byte(`
+import Foo from 0x1
+
+pub fun main(): Int {
+    let x = 1
+    return x!
+}
+`),
+			0o644,
+		)
+		require.NoError(t, err)
+
+		exitCode, output, errorOutput := runTest(t, options{
+			paths: []string{path},
+			analyzers: strings.Join(
+				[]string{
+					analyzers.UnusedImportCategory,
+					analyzers.UnnecessaryForceCategory,
+				},
+				",",
+			),
+			format:           formatText,
+			addressDirectory: addressDirectory,
+			fix:              true,
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Empty(t, output)
+		assert.Equal(t, exitCodeSuccess, exitCode)
+
+		fixed, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`
+
+pub fun main(): Int {
+    let x = 1
+    return x
+}
+`,
+			string(fixed),
+		)
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, addressDirectory := writeTestFiles(t)
+
+		path := filepath.Join(filepath.Dir(addressDirectory), "fix.cdc")
+		err := os.WriteFile(
+			path,
+			[]byte(`
+pub fun main(): Int {
+    let x: Int? = 1
+    return x
+}
+`),
+			0o644,
+		)
+		require.NoError(t, err)
+
+		exitCode, _, errorOutput := runTest(t, options{
+			paths:            []string{path},
+			analyzers:        analyzers.UnusedImportCategory,
+			format:           formatText,
+			addressDirectory: addressDirectory,
+			fix:              true,
+		})
+
+		assert.Empty(t, errorOutput)
+		assert.Equal(t, exitCodeSuccess, exitCode)
+
+		fixed, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`
+pub fun main(): Int {
+    let x: Int? = 1
+    return x!
+}
+`,
+			string(fixed),
+		)
+	})
+}
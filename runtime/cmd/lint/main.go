@@ -0,0 +1,82 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/tools/analysis/analyzers"
+)
+
+var analyzersFlag = flag.String(
+	"analyzers",
+	"",
+	fmt.Sprintf(
+		"comma-separated list of analyzers to run, all if empty (%s)",
+		strings.Join(analyzers.Names(), ", "),
+	),
+)
+
+var formatFlag = flag.String(
+	"format",
+	string(formatText),
+	"output format: text, json, or sarif",
+)
+
+var addressDirectoryFlag = flag.String(
+	"addressDirectory",
+	"",
+	"directory containing the source files of imported contracts, in a directory per address (<address>/<name>.cdc)",
+)
+
+var colorFlag = flag.Bool("color", true, "colorize the text output")
+
+var fixFlag = flag.Bool("fix", false, "apply suggested fixes to the files")
+
+// main lints the Cadence programs in the given files.
+//
+// The exit code is 1 if any diagnostics were reported,
+// and 2 if the programs could not be loaded or the options are invalid,
+// so the command can be used to gate CI.
+//
+// Diagnostics can be suppressed with a comment on the line of the diagnostic,
+// or on the line before it, e.g. `// lint:ignore unused-variable`.
+//
+// With -fix, the suggested fixes of diagnostics and checker errors are applied to the files.
+func main() {
+	flag.Parse()
+
+	os.Exit(
+		run(
+			os.Stdout,
+			os.Stderr,
+			options{
+				paths:            flag.Args(),
+				analyzers:        *analyzersFlag,
+				format:           format(*formatFlag),
+				addressDirectory: *addressDirectoryFlag,
+				useColor:         *colorFlag,
+				fix:              *fixFlag,
+			},
+		),
+	)
+}
@@ -0,0 +1,305 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/tools/analysis"
+	"github.com/onflow/cadence/tools/analysis/analyzers"
+)
+
+type format string
+
+const (
+	formatText  format = "text"
+	formatJSON  format = "json"
+	formatSARIF format = "sarif"
+)
+
+type diagnosticsWriter func(
+	output io.Writer,
+	diagnostics []analysis.Diagnostic,
+	linter *linter,
+	useColor bool,
+) error
+
+func (f format) writer() (diagnosticsWriter, error) {
+	switch f {
+	case formatText:
+		return writeText, nil
+	case formatJSON:
+		return writeJSON, nil
+	case formatSARIF:
+		return writeSARIF, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected text, json, or sarif", f)
+	}
+}
+
+// diagnosticError is a diagnostic as an error, so it can be printed by the pretty printer
+type diagnosticError struct {
+	analysis.Diagnostic
+}
+
+var _ error = diagnosticError{}
+var _ errors.HasPrefix = diagnosticError{}
+var _ errors.SecondaryError = diagnosticError{}
+var _ common.HasLocation = diagnosticError{}
+var _ ast.HasPosition = diagnosticError{}
+
+func (e diagnosticError) Error() string {
+	return e.Message
+}
+
+func (e diagnosticError) Prefix() string {
+	return e.Category
+}
+
+func (e diagnosticError) SecondaryError() string {
+	return e.SecondaryMessage
+}
+
+func (e diagnosticError) ImportLocation() common.Location {
+	return e.Location
+}
+
+// writeText writes the diagnostics with code excerpts
+func writeText(
+	output io.Writer,
+	diagnostics []analysis.Diagnostic,
+	linter *linter,
+	useColor bool,
+) error {
+	for _, diagnostic := range diagnostics {
+		var builder strings.Builder
+		err := pretty.NewErrorPrettyPrinter(&builder, useColor).
+			PrettyPrintError(
+				diagnosticError{diagnostic},
+				diagnostic.Location,
+				linter.codes,
+			)
+		if err != nil {
+			return err
+		}
+		builder.WriteString("\n")
+
+		_, err = io.WriteString(output, builder.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jsonPosition struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type jsonDiagnostic struct {
+	Location         string       `json:"location"`
+	Path             string       `json:"path"`
+	Category         string       `json:"category"`
+	Message          string       `json:"message"`
+	SecondaryMessage string       `json:"secondaryMessage,omitempty"`
+	StartPos         jsonPosition `json:"startPos"`
+	EndPos           jsonPosition `json:"endPos"`
+}
+
+func newJSONPosition(position ast.Position) jsonPosition {
+	return jsonPosition{
+		Offset: position.Offset,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+// writeJSON writes the diagnostics as a JSON array
+func writeJSON(
+	output io.Writer,
+	diagnostics []analysis.Diagnostic,
+	linter *linter,
+	_ bool,
+) error {
+	jsonDiagnostics := make([]jsonDiagnostic, 0, len(diagnostics))
+
+	for _, diagnostic := range diagnostics {
+		jsonDiagnostics = append(
+			jsonDiagnostics,
+			jsonDiagnostic{
+				Location:         diagnostic.Location.String(),
+				Path:             linter.path(diagnostic.Location),
+				Category:         diagnostic.Category,
+				Message:          diagnostic.Message,
+				SecondaryMessage: diagnostic.SecondaryMessage,
+				StartPos:         newJSONPosition(diagnostic.StartPos),
+				EndPos:           newJSONPosition(diagnostic.EndPos),
+			},
+		)
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonDiagnostics)
+}
+
+// SARIF 2.1.0, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+const sarifVersion = "2.1.0"
+const sarifToolName = "cadence-lint"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is a region in a source file.
+// Lines and columns start at 1, and the end column is exclusive
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// writeSARIF writes the diagnostics as a SARIF log
+func writeSARIF(
+	output io.Writer,
+	diagnostics []analysis.Diagnostic,
+	linter *linter,
+	_ bool,
+) error {
+	names := analyzers.Names()
+	rules := make([]sarifRule, 0, len(names))
+	for _, name := range names {
+		rules = append(rules, sarifRule{
+			ID: name,
+			ShortDescription: sarifMessage{
+				Text: analyzers.Analyzers[name].Description,
+			},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		message := diagnostic.Message
+		if diagnostic.SecondaryMessage != "" {
+			message = fmt.Sprintf("%s: %s", message, diagnostic.SecondaryMessage)
+		}
+
+		results = append(results, sarifResult{
+			RuleID: diagnostic.Category,
+			Level:  "warning",
+			Message: sarifMessage{
+				Text: message,
+			},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI: filepath.ToSlash(linter.path(diagnostic.Location)),
+						},
+						Region: sarifRegion{
+							StartLine:   diagnostic.StartPos.Line,
+							StartColumn: diagnostic.StartPos.Column + 1,
+							EndLine:     diagnostic.EndPos.Line,
+							EndColumn:   diagnostic.EndPos.Column + 2,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  sarifToolName,
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
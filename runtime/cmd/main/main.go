@@ -19,33 +19,31 @@
 package main
 
 import (
-	"os"
-	"os/signal"
+	"flag"
 
 	"github.com/onflow/cadence/runtime/cmd/execute"
 	"github.com/onflow/cadence/runtime/interpreter"
 )
 
-func main() {
-	if len(os.Args) > 1 {
-		// TODO: also make the REPL support the interactive debugger
-
-		signals := make(chan os.Signal, 1)
-
-		signal.Notify(signals, os.Interrupt)
+var debugFlag = flag.Bool("debug", false, "enable the interactive debugger")
 
-		debugger := interpreter.NewDebugger()
+// main executes the given program, or runs the REPL if no program is given.
+//
+// With -debug, the interactive debugger is run when the program is paused,
+// e.g. at a breakpoint, or when an interrupt signal (e.g. ^C) is received.
+func main() {
+	flag.Parse()
 
-		go func() {
-			for range signals {
-				stop := debugger.Pause()
-				execute.NewInteractiveDebugger(debugger, stop).Run()
-				debugger.Continue()
-			}
-		}()
+	var debugger *interpreter.Debugger
+	if *debugFlag {
+		debugger = interpreter.NewDebugger()
+		execute.StartDebugger(debugger)
+	}
 
-		execute.Execute(os.Args[1:], debugger)
+	args := flag.Args()
+	if len(args) > 0 {
+		execute.Execute(args, debugger)
 	} else {
-		execute.RunREPL()
+		execute.RunREPL(debugger)
 	}
 }
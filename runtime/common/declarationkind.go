@@ -56,6 +56,9 @@ const (
 	DeclarationKindPragma
 	DeclarationKindEnum
 	DeclarationKindEnumCase
+	DeclarationKindTypeAlias
+	DeclarationKindAttachment
+	DeclarationKindBase
 )
 
 func DeclarationKindCount() int {
@@ -72,7 +75,9 @@ func (k DeclarationKind) IsTypeDeclaration() bool {
 		DeclarationKindResourceInterface,
 		DeclarationKindContractInterface,
 		DeclarationKindTypeParameter,
-		DeclarationKindEnum:
+		DeclarationKindEnum,
+		DeclarationKindTypeAlias,
+		DeclarationKindAttachment:
 
 		return true
 
@@ -135,6 +140,12 @@ func (k DeclarationKind) Name() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "enum case"
+	case DeclarationKindTypeAlias:
+		return "type alias"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindBase:
+		return "base"
 	case DeclarationKindUnknown:
 		return "unknown"
 	}
@@ -182,6 +193,12 @@ func (k DeclarationKind) Keywords() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "case"
+	case DeclarationKindTypeAlias:
+		return "typealias"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindBase:
+		return "base"
 	default:
 		return ""
 	}
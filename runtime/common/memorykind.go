@@ -234,6 +234,32 @@ const (
 	MemoryKindOrderedMapEntryList
 	MemoryKindOrderedMapEntry
 
+	// AST declarations (continued)
+	MemoryKindTypeAliasDeclaration
+
+	// AST (continued)
+	MemoryKindTypeParameter
+	MemoryKindTypeParameterList
+
+	// AST statements (continued)
+	MemoryKindRemoveStatement
+
+	// AST expressions (continued)
+	MemoryKindAttachExpression
+
+	// Cadence values (continued)
+	MemoryKindCadenceAttachmentValueBase
+	MemoryKindCadenceAttachmentValueSize
+
+	// Cadence types (continued)
+	MemoryKindCadenceAttachmentType
+
+	// AST expressions (continued)
+	MemoryKindStringTemplateExpression
+
+	// AST (continued)
+	MemoryKindComment
+
 	// Placeholder kind to allow consistent indexing
 	// this should always be the last kind
 	MemoryKindLast
@@ -187,12 +187,22 @@ func _() {
 	_ = x[MemoryKindOrderedMap-176]
 	_ = x[MemoryKindOrderedMapEntryList-177]
 	_ = x[MemoryKindOrderedMapEntry-178]
-	_ = x[MemoryKindLast-179]
+	_ = x[MemoryKindTypeAliasDeclaration-179]
+	_ = x[MemoryKindTypeParameter-180]
+	_ = x[MemoryKindTypeParameterList-181]
+	_ = x[MemoryKindRemoveStatement-182]
+	_ = x[MemoryKindAttachExpression-183]
+	_ = x[MemoryKindCadenceAttachmentValueBase-184]
+	_ = x[MemoryKindCadenceAttachmentValueSize-185]
+	_ = x[MemoryKindCadenceAttachmentType-186]
+	_ = x[MemoryKindStringTemplateExpression-187]
+	_ = x[MemoryKindComment-188]
+	_ = x[MemoryKindLast-189]
 }
 
-const _MemoryKind_name = "UnknownBoolValueAddressValueStringValueCharacterValueNumberValueArrayValueBaseDictionaryValueBaseCompositeValueBaseSimpleCompositeValueBaseOptionalValueNilValueVoidValueTypeValuePathValueCapabilityValueLinkValueStorageReferenceValueEphemeralReferenceValueInterpretedFunctionValueHostFunctionValueBoundFunctionValueBigIntSimpleCompositeValuePublishedValueAtreeArrayDataSlabAtreeArrayMetaDataSlabAtreeArrayElementOverheadAtreeMapDataSlabAtreeMapMetaDataSlabAtreeMapElementOverheadAtreeMapPreAllocatedElementAtreeEncodedSlabPrimitiveStaticTypeCompositeStaticTypeInterfaceStaticTypeVariableSizedStaticTypeConstantSizedStaticTypeDictionaryStaticTypeOptionalStaticTypeRestrictedStaticTypeReferenceStaticTypeCapabilityStaticTypeFunctionStaticTypeCadenceVoidValueCadenceOptionalValueCadenceBoolValueCadenceStringValueCadenceCharacterValueCadenceAddressValueCadenceIntValueCadenceNumberValueCadenceArrayValueBaseCadenceArrayValueLengthCadenceDictionaryValueCadenceKeyValuePairCadenceStructValueBaseCadenceStructValueSizeCadenceResourceValueBaseCadenceResourceValueSizeCadenceEventValueBaseCadenceEventValueSizeCadenceContractValueBaseCadenceContractValueSizeCadenceEnumValueBaseCadenceEnumValueSizeCadenceLinkValueCadencePathValueCadenceTypeValueCadenceCapabilityValueCadenceFunctionValueCadenceSimpleTypeCadenceOptionalTypeCadenceVariableSizedArrayTypeCadenceConstantSizedArrayTypeCadenceDictionaryTypeCadenceFieldCadenceParameterCadenceStructTypeCadenceResourceTypeCadenceEventTypeCadenceContractTypeCadenceStructInterfaceTypeCadenceResourceInterfaceTypeCadenceContractInterfaceTypeCadenceFunctionTypeCadenceReferenceTypeCadenceRestrictedTypeCadenceCapabilityTypeCadenceEnumTypeRawStringAddressLocationBytesVariableCompositeTypeInfoCompositeFieldInvocationStorageMapStorageKeyTypeTokenErrorTokenSpaceTokenProgramIdentifierArgumentBlockFunctionBlockParameterParameterListTransferMembersTypeAnnotationDictionaryEntryFunctionDeclarationCompositeDeclarationInterfaceDeclarationEnumCaseDeclarationFieldDeclarationTransactionDeclarationImportDeclarationVariableDeclarationSpecialFunctionDeclarationPragmaDeclarationAssignmentStatementBreakStatementContinueStatementEmitStatementExpressionStatementForStatementIfStatementReturnStatementSwapStatementSwitchStatementWhileStatementBooleanExpressionNilExpressionStringExpressionIntegerExpressionFixedPointExpressionArrayExpressionDictionaryExpressionIdentifierExpressionInvocationExpressionMemberExpressionIndexExpressionConditionalExpressionUnaryExpressionBinaryExpressionFunctionExpressionCastingExpressionCreateExpressionDestroyExpressionReferenceExpressionForceExpressionPathExpressionConstantSizedTypeDictionaryTypeFunctionTypeInstantiationTypeNominalTypeOptionalTypeReferenceTypeRestrictedTypeVariableSizedTypePositionRangeElaborationActivationActivationEntriesVariableSizedSemaTypeConstantSizedSemaTypeDictionarySemaTypeOptionalSemaTypeRestrictedSemaTypeReferenceSemaTypeCapabilitySemaTypeOrderedMapOrderedMapEntryListOrderedMapEntryLast"
+const _MemoryKind_name = "UnknownBoolValueAddressValueStringValueCharacterValueNumberValueArrayValueBaseDictionaryValueBaseCompositeValueBaseSimpleCompositeValueBaseOptionalValueNilValueVoidValueTypeValuePathValueCapabilityValueLinkValueStorageReferenceValueEphemeralReferenceValueInterpretedFunctionValueHostFunctionValueBoundFunctionValueBigIntSimpleCompositeValuePublishedValueAtreeArrayDataSlabAtreeArrayMetaDataSlabAtreeArrayElementOverheadAtreeMapDataSlabAtreeMapMetaDataSlabAtreeMapElementOverheadAtreeMapPreAllocatedElementAtreeEncodedSlabPrimitiveStaticTypeCompositeStaticTypeInterfaceStaticTypeVariableSizedStaticTypeConstantSizedStaticTypeDictionaryStaticTypeOptionalStaticTypeRestrictedStaticTypeReferenceStaticTypeCapabilityStaticTypeFunctionStaticTypeCadenceVoidValueCadenceOptionalValueCadenceBoolValueCadenceStringValueCadenceCharacterValueCadenceAddressValueCadenceIntValueCadenceNumberValueCadenceArrayValueBaseCadenceArrayValueLengthCadenceDictionaryValueCadenceKeyValuePairCadenceStructValueBaseCadenceStructValueSizeCadenceResourceValueBaseCadenceResourceValueSizeCadenceEventValueBaseCadenceEventValueSizeCadenceContractValueBaseCadenceContractValueSizeCadenceEnumValueBaseCadenceEnumValueSizeCadenceLinkValueCadencePathValueCadenceTypeValueCadenceCapabilityValueCadenceFunctionValueCadenceSimpleTypeCadenceOptionalTypeCadenceVariableSizedArrayTypeCadenceConstantSizedArrayTypeCadenceDictionaryTypeCadenceFieldCadenceParameterCadenceStructTypeCadenceResourceTypeCadenceEventTypeCadenceContractTypeCadenceStructInterfaceTypeCadenceResourceInterfaceTypeCadenceContractInterfaceTypeCadenceFunctionTypeCadenceReferenceTypeCadenceRestrictedTypeCadenceCapabilityTypeCadenceEnumTypeRawStringAddressLocationBytesVariableCompositeTypeInfoCompositeFieldInvocationStorageMapStorageKeyTypeTokenErrorTokenSpaceTokenProgramIdentifierArgumentBlockFunctionBlockParameterParameterListTransferMembersTypeAnnotationDictionaryEntryFunctionDeclarationCompositeDeclarationInterfaceDeclarationEnumCaseDeclarationFieldDeclarationTransactionDeclarationImportDeclarationVariableDeclarationSpecialFunctionDeclarationPragmaDeclarationAssignmentStatementBreakStatementContinueStatementEmitStatementExpressionStatementForStatementIfStatementReturnStatementSwapStatementSwitchStatementWhileStatementBooleanExpressionNilExpressionStringExpressionIntegerExpressionFixedPointExpressionArrayExpressionDictionaryExpressionIdentifierExpressionInvocationExpressionMemberExpressionIndexExpressionConditionalExpressionUnaryExpressionBinaryExpressionFunctionExpressionCastingExpressionCreateExpressionDestroyExpressionReferenceExpressionForceExpressionPathExpressionConstantSizedTypeDictionaryTypeFunctionTypeInstantiationTypeNominalTypeOptionalTypeReferenceTypeRestrictedTypeVariableSizedTypePositionRangeElaborationActivationActivationEntriesVariableSizedSemaTypeConstantSizedSemaTypeDictionarySemaTypeOptionalSemaTypeRestrictedSemaTypeReferenceSemaTypeCapabilitySemaTypeOrderedMapOrderedMapEntryListOrderedMapEntryTypeAliasDeclarationTypeParameterTypeParameterListRemoveStatementAttachExpressionCadenceAttachmentValueBaseCadenceAttachmentValueSizeCadenceAttachmentTypeStringTemplateExpressionCommentLast"
 
-var _MemoryKind_index = [...]uint16{0, 7, 16, 28, 39, 53, 64, 78, 97, 115, 139, 152, 160, 169, 178, 187, 202, 211, 232, 255, 279, 296, 314, 320, 340, 354, 372, 394, 419, 435, 455, 478, 505, 521, 540, 559, 578, 601, 624, 644, 662, 682, 701, 721, 739, 755, 775, 791, 809, 830, 849, 864, 882, 903, 926, 948, 967, 989, 1011, 1035, 1059, 1080, 1101, 1125, 1149, 1169, 1189, 1205, 1221, 1237, 1259, 1279, 1296, 1315, 1344, 1373, 1394, 1406, 1422, 1439, 1458, 1474, 1493, 1519, 1547, 1575, 1594, 1614, 1635, 1656, 1671, 1680, 1695, 1700, 1708, 1725, 1739, 1749, 1759, 1769, 1778, 1788, 1798, 1805, 1815, 1823, 1828, 1841, 1850, 1863, 1871, 1878, 1892, 1907, 1926, 1946, 1966, 1985, 2001, 2023, 2040, 2059, 2085, 2102, 2121, 2135, 2152, 2165, 2184, 2196, 2207, 2222, 2235, 2250, 2264, 2281, 2294, 2310, 2327, 2347, 2362, 2382, 2402, 2422, 2438, 2453, 2474, 2489, 2505, 2523, 2540, 2556, 2573, 2592, 2607, 2621, 2638, 2652, 2664, 2681, 2692, 2704, 2717, 2731, 2748, 2756, 2761, 2772, 2782, 2799, 2820, 2841, 2859, 2875, 2893, 2910, 2928, 2938, 2957, 2972, 2976}
+var _MemoryKind_index = [...]uint16{0, 7, 16, 28, 39, 53, 64, 78, 97, 115, 139, 152, 160, 169, 178, 187, 202, 211, 232, 255, 279, 296, 314, 320, 340, 354, 372, 394, 419, 435, 455, 478, 505, 521, 540, 559, 578, 601, 624, 644, 662, 682, 701, 721, 739, 755, 775, 791, 809, 830, 849, 864, 882, 903, 926, 948, 967, 989, 1011, 1035, 1059, 1080, 1101, 1125, 1149, 1169, 1189, 1205, 1221, 1237, 1259, 1279, 1296, 1315, 1344, 1373, 1394, 1406, 1422, 1439, 1458, 1474, 1493, 1519, 1547, 1575, 1594, 1614, 1635, 1656, 1671, 1680, 1695, 1700, 1708, 1725, 1739, 1749, 1759, 1769, 1778, 1788, 1798, 1805, 1815, 1823, 1828, 1841, 1850, 1863, 1871, 1878, 1892, 1907, 1926, 1946, 1966, 1985, 2001, 2023, 2040, 2059, 2085, 2102, 2121, 2135, 2152, 2165, 2184, 2196, 2207, 2222, 2235, 2250, 2264, 2281, 2294, 2310, 2327, 2347, 2362, 2382, 2402, 2422, 2438, 2453, 2474, 2489, 2505, 2523, 2540, 2556, 2573, 2592, 2607, 2621, 2638, 2652, 2664, 2681, 2692, 2704, 2717, 2731, 2748, 2756, 2761, 2772, 2782, 2799, 2820, 2841, 2859, 2875, 2893, 2910, 2928, 2938, 2957, 2972, 2992, 3005, 3022, 3037, 3053, 3079, 3105, 3126, 3150, 3157, 3161}
 
 func (i MemoryKind) String() string {
 	if i >= MemoryKind(len(_MemoryKind_index)-1) {
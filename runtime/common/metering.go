@@ -44,16 +44,19 @@ var (
 
 	// AST
 
-	ProgramMemoryUsage         = NewConstantMemoryUsage(MemoryKindProgram)
-	IdentifierMemoryUsage      = NewConstantMemoryUsage(MemoryKindIdentifier)
-	ArgumentMemoryUsage        = NewConstantMemoryUsage(MemoryKindArgument)
-	BlockMemoryUsage           = NewConstantMemoryUsage(MemoryKindBlock)
-	FunctionBlockMemoryUsage   = NewConstantMemoryUsage(MemoryKindFunctionBlock)
-	ParameterMemoryUsage       = NewConstantMemoryUsage(MemoryKindParameter)
-	ParameterListMemoryUsage   = NewConstantMemoryUsage(MemoryKindParameterList)
-	TransferMemoryUsage        = NewConstantMemoryUsage(MemoryKindTransfer)
-	TypeAnnotationMemoryUsage  = NewConstantMemoryUsage(MemoryKindTypeAnnotation)
-	DictionaryEntryMemoryUsage = NewConstantMemoryUsage(MemoryKindDictionaryEntry)
+	ProgramMemoryUsage           = NewConstantMemoryUsage(MemoryKindProgram)
+	IdentifierMemoryUsage        = NewConstantMemoryUsage(MemoryKindIdentifier)
+	ArgumentMemoryUsage          = NewConstantMemoryUsage(MemoryKindArgument)
+	BlockMemoryUsage             = NewConstantMemoryUsage(MemoryKindBlock)
+	FunctionBlockMemoryUsage     = NewConstantMemoryUsage(MemoryKindFunctionBlock)
+	ParameterMemoryUsage         = NewConstantMemoryUsage(MemoryKindParameter)
+	ParameterListMemoryUsage     = NewConstantMemoryUsage(MemoryKindParameterList)
+	TypeParameterMemoryUsage     = NewConstantMemoryUsage(MemoryKindTypeParameter)
+	TypeParameterListMemoryUsage = NewConstantMemoryUsage(MemoryKindTypeParameterList)
+	TransferMemoryUsage          = NewConstantMemoryUsage(MemoryKindTransfer)
+	TypeAnnotationMemoryUsage    = NewConstantMemoryUsage(MemoryKindTypeAnnotation)
+	DictionaryEntryMemoryUsage   = NewConstantMemoryUsage(MemoryKindDictionaryEntry)
+	CommentMemoryUsage           = NewConstantMemoryUsage(MemoryKindComment)
 
 	// AST Declarations
 
@@ -67,6 +70,7 @@ var (
 	VariableDeclarationMemoryUsage        = NewConstantMemoryUsage(MemoryKindVariableDeclaration)
 	SpecialFunctionDeclarationMemoryUsage = NewConstantMemoryUsage(MemoryKindSpecialFunctionDeclaration)
 	PragmaDeclarationMemoryUsage          = NewConstantMemoryUsage(MemoryKindPragmaDeclaration)
+	TypeAliasDeclarationMemoryUsage       = NewConstantMemoryUsage(MemoryKindTypeAliasDeclaration)
 
 	// AST Statements
 
@@ -81,6 +85,7 @@ var (
 	SwapStatementMemoryUsage       = NewConstantMemoryUsage(MemoryKindSwapStatement)
 	SwitchStatementMemoryUsage     = NewConstantMemoryUsage(MemoryKindSwitchStatement)
 	WhileStatementMemoryUsage      = NewConstantMemoryUsage(MemoryKindWhileStatement)
+	RemoveStatementMemoryUsage     = NewConstantMemoryUsage(MemoryKindRemoveStatement)
 
 	// AST Expressions
 
@@ -103,6 +108,7 @@ var (
 	ReferenceExpressionMemoryUsage   = NewConstantMemoryUsage(MemoryKindReferenceExpression)
 	ForceExpressionMemoryUsage       = NewConstantMemoryUsage(MemoryKindForceExpression)
 	PathExpressionMemoryUsage        = NewConstantMemoryUsage(MemoryKindPathExpression)
+	AttachExpressionMemoryUsage      = NewConstantMemoryUsage(MemoryKindAttachExpression)
 
 	// AST Types
 
@@ -182,23 +188,24 @@ var (
 
 	// Cadence external values
 
-	CadenceDictionaryValueMemoryUsage   = NewConstantMemoryUsage(MemoryKindCadenceDictionaryValue)
-	CadenceArrayValueBaseMemoryUsage    = NewConstantMemoryUsage(MemoryKindCadenceArrayValueBase)
-	CadenceStructValueBaseMemoryUsage   = NewConstantMemoryUsage(MemoryKindCadenceStructValueBase)
-	CadenceResourceValueBaseMemoryUsage = NewConstantMemoryUsage(MemoryKindCadenceResourceValueBase)
-	CadenceEventValueBaseMemoryUsage    = NewConstantMemoryUsage(MemoryKindCadenceEventValueBase)
-	CadenceContractValueBaseMemoryUsage = NewConstantMemoryUsage(MemoryKindCadenceContractValueBase)
-	CadenceEnumValueBaseMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceEnumValueBase)
-	CadenceAddressValueMemoryUsage      = NewConstantMemoryUsage(MemoryKindCadenceAddressValue)
-	CadenceBoolValueMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceBoolValue)
-	CadenceCapabilityValueMemoryUsage   = NewConstantMemoryUsage(MemoryKindCadenceCapabilityValue)
-	CadenceFunctionValueMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceFunctionValue)
-	CadenceKeyValuePairMemoryUsage      = NewConstantMemoryUsage(MemoryKindCadenceKeyValuePair)
-	CadenceLinkValueMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceLinkValue)
-	CadenceOptionalValueMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceOptionalValue)
-	CadencePathValueMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadencePathValue)
-	CadenceVoidValueMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceVoidValue)
-	CadenceTypeValueMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceTypeValue)
+	CadenceDictionaryValueMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceDictionaryValue)
+	CadenceArrayValueBaseMemoryUsage      = NewConstantMemoryUsage(MemoryKindCadenceArrayValueBase)
+	CadenceStructValueBaseMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceStructValueBase)
+	CadenceResourceValueBaseMemoryUsage   = NewConstantMemoryUsage(MemoryKindCadenceResourceValueBase)
+	CadenceEventValueBaseMemoryUsage      = NewConstantMemoryUsage(MemoryKindCadenceEventValueBase)
+	CadenceContractValueBaseMemoryUsage   = NewConstantMemoryUsage(MemoryKindCadenceContractValueBase)
+	CadenceEnumValueBaseMemoryUsage       = NewConstantMemoryUsage(MemoryKindCadenceEnumValueBase)
+	CadenceAttachmentValueBaseMemoryUsage = NewConstantMemoryUsage(MemoryKindCadenceAttachmentValueBase)
+	CadenceAddressValueMemoryUsage        = NewConstantMemoryUsage(MemoryKindCadenceAddressValue)
+	CadenceBoolValueMemoryUsage           = NewConstantMemoryUsage(MemoryKindCadenceBoolValue)
+	CadenceCapabilityValueMemoryUsage     = NewConstantMemoryUsage(MemoryKindCadenceCapabilityValue)
+	CadenceFunctionValueMemoryUsage       = NewConstantMemoryUsage(MemoryKindCadenceFunctionValue)
+	CadenceKeyValuePairMemoryUsage        = NewConstantMemoryUsage(MemoryKindCadenceKeyValuePair)
+	CadenceLinkValueMemoryUsage           = NewConstantMemoryUsage(MemoryKindCadenceLinkValue)
+	CadenceOptionalValueMemoryUsage       = NewConstantMemoryUsage(MemoryKindCadenceOptionalValue)
+	CadencePathValueMemoryUsage           = NewConstantMemoryUsage(MemoryKindCadencePathValue)
+	CadenceVoidValueMemoryUsage           = NewConstantMemoryUsage(MemoryKindCadenceVoidValue)
+	CadenceTypeValueMemoryUsage           = NewConstantMemoryUsage(MemoryKindCadenceTypeValue)
 
 	// Cadence external types
 
@@ -219,6 +226,7 @@ var (
 	CadenceRestrictedTypeMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceRestrictedType)
 	CadenceStructInterfaceTypeMemoryUsage    = NewConstantMemoryUsage(MemoryKindCadenceStructInterfaceType)
 	CadenceStructTypeMemoryUsage             = NewConstantMemoryUsage(MemoryKindCadenceStructType)
+	CadenceAttachmentTypeMemoryUsage         = NewConstantMemoryUsage(MemoryKindCadenceAttachmentType)
 
 	// Following are the known memory usage amounts for string representation of interpreter values.
 	// Same as `len(format.X)`. However, values are hard-coded to avoid the circular dependency.
@@ -489,6 +497,13 @@ func NewCadenceEnumMemoryUsages(fields int) (MemoryUsage, MemoryUsage) {
 	}
 }
 
+func NewCadenceAttachmentMemoryUsages(fields int) (MemoryUsage, MemoryUsage) {
+	return CadenceAttachmentValueBaseMemoryUsage, MemoryUsage{
+		Kind:   MemoryKindCadenceAttachmentValueSize,
+		Amount: uint64(fields),
+	}
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -767,6 +782,14 @@ func NewArrayExpressionMemoryUsage(length int) MemoryUsage {
 	}
 }
 
+func NewStringTemplateExpressionMemoryUsage(expressions int) MemoryUsage {
+	return MemoryUsage{
+		Kind: MemoryKindStringTemplateExpression,
+		// +1 to account for templates without expressions
+		Amount: uint64(expressions) + 1,
+	}
+}
+
 func NewDictionaryExpressionMemoryUsage(length int) MemoryUsage {
 	return MemoryUsage{
 		Kind: MemoryKindDictionaryExpression,
@@ -98,6 +98,11 @@ func (compiler *Compiler) VisitEmitStatement(_ *ast.EmitStatement) ir.Stmt {
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitRemoveStatement(_ *ast.RemoveStatement) ir.Stmt {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitSwitchStatement(_ *ast.SwitchStatement) ir.Stmt {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -259,6 +264,16 @@ func (compiler *Compiler) VisitDestroyExpression(_ *ast.DestroyExpression) ir.Ex
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitStringTemplateExpression(_ *ast.StringTemplateExpression) ir.Expr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
+func (compiler *Compiler) VisitAttachExpression(_ *ast.AttachExpression) ir.Expr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitReferenceExpression(_ *ast.ReferenceExpression) ir.Expr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -370,6 +385,11 @@ func (compiler *Compiler) VisitImportDeclaration(_ *ast.ImportDeclaration) ir.St
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ir.Stmt {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitTransactionDeclaration(_ *ast.TransactionDeclaration) ir.Stmt {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -1830,6 +1830,277 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 			assertMissingDeclarationError(t, childErrors[1], "B")
 		}
 	})
+
+	t.Run("add type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {}
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub typealias Count = Int
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("keep type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub typealias Counts = [Int]
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub typealias Counts = [Int]
+
+		        pub fun sum(_ counts: Counts): Int {
+		            var sum = 0
+		            for count in counts {
+		                sum = sum + count
+		            }
+		            return sum
+		        }
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("change type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub typealias Count = Int
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub typealias Count = String
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeAliasMismatchError(t, cause, "Test", "Count", "Int", "String")
+	})
+
+	t.Run("remove type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub typealias Count = Int
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {}
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertMissingDeclarationError(t, cause, "Count")
+	})
+
+	t.Run("keep type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T, U: AnyStruct> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T, U: AnyStruct> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("add type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T, U> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("remove type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T, U> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("rename type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<U> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("reorder type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T, U> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<U, T> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("add type parameter to non-generic composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("change type parameter bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T: AnyStruct> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T: Integer> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
+
+	t.Run("add type parameter bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+		    pub contract Test {
+		        pub struct Box<T> {}
+		    }
+		`
+
+		const newCode = `
+		    pub contract Test {
+		        pub struct Box<T: AnyStruct> {}
+		    }
+		`
+
+		err := testDeployAndUpdate(t, "Test", oldCode, newCode)
+		RequireError(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertTypeParameterMismatchError(t, cause, "Box")
+	})
 }
 
 func assertContractRemovalError(t *testing.T, err error, name string) {
@@ -1911,6 +2182,34 @@ func assertMissingEnumCasesError(t *testing.T, err error, declName string, expec
 	assert.Equal(t, foundCases, missingEnumCasesError.Found)
 }
 
+func assertTypeAliasMismatchError(
+	t *testing.T,
+	err error,
+	erroneousDeclName string,
+	typeAliasName string,
+	expectedType string,
+	foundType string,
+) {
+	var typeAliasMismatchError *stdlib.TypeAliasMismatchError
+	require.ErrorAs(t, err, &typeAliasMismatchError)
+
+	assert.Equal(t, typeAliasName, typeAliasMismatchError.TypeAliasName)
+	assert.Equal(t, erroneousDeclName, typeAliasMismatchError.DeclName)
+
+	var typeMismatchError *stdlib.TypeMismatchError
+	assert.ErrorAs(t, typeAliasMismatchError.Err, &typeMismatchError)
+
+	assert.Equal(t, expectedType, typeMismatchError.ExpectedType.String())
+	assert.Equal(t, foundType, typeMismatchError.FoundType.String())
+}
+
+func assertTypeParameterMismatchError(t *testing.T, err error, erroneousDeclName string) {
+	var typeParameterMismatchError *stdlib.TypeParameterMismatchError
+	require.ErrorAs(t, err, &typeParameterMismatchError)
+
+	assert.Equal(t, erroneousDeclName, typeParameterMismatchError.DeclName)
+}
+
 func assertMissingDeclarationError(t *testing.T, err error, declName string) bool {
 	var missingDeclError *stdlib.MissingDeclarationError
 	require.ErrorAs(t, err, &missingDeclError)
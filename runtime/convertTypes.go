@@ -37,6 +37,12 @@ func ExportType(
 		return nil
 	}
 
+	// Exported values do not carry the type arguments of generic composite types,
+	// so generic types are exported as their type bound,
+	// and instantiations as their generic composite type
+
+	t = sema.EraseTypeArguments(t)
+
 	typeID := t.ID()
 	if result, ok := results[typeID]; ok {
 		return result
@@ -191,6 +197,10 @@ func ExportMeteredType(
 		return nil
 	}
 
+	// See ExportType
+
+	t = sema.EraseTypeArguments(t)
+
 	typeID := t.ID()
 	if result, ok := results[typeID]; ok {
 		return result
@@ -429,6 +439,16 @@ func exportCompositeType(
 			nil,
 		)
 
+	case common.CompositeKindAttachment:
+		result = cadence.NewMeteredAttachmentType(
+			gauge,
+			t.Location,
+			t.QualifiedIdentifier(),
+			nil,
+			fields,
+			nil,
+		)
+
 	default:
 		panic(fmt.Sprintf("cannot export composite type %v of unknown kind %v", t, t.Kind))
 	}
@@ -437,6 +457,10 @@ func exportCompositeType(
 
 	results[t.ID()] = result
 
+	if attachmentType, ok := result.(*cadence.AttachmentType); ok {
+		attachmentType.BaseType = ExportMeteredType(gauge, t.AttachmentBaseType, results)
+	}
+
 	for i, member := range fieldMembers {
 		convertedFieldType := ExportMeteredType(gauge, member.TypeAnnotation.Type, results)
 
@@ -560,12 +584,18 @@ func exportFunctionType(
 
 	convertedReturnType := ExportMeteredType(gauge, t.ReturnTypeAnnotation.Type, results)
 
+	var purity cadence.FunctionPurity
+	if t.Purity == sema.FunctionPurityView {
+		purity = cadence.FunctionPurityView
+	}
+
 	return cadence.NewMeteredFunctionType(
 		gauge,
 		"",
 		convertedParameters,
 		convertedReturnType,
-	).WithID(string(t.ID()))
+	).WithID(string(t.ID())).
+		WithPurity(purity)
 }
 
 func exportReferenceType(
@@ -732,7 +762,8 @@ func ImportType(memoryGauge common.MemoryGauge, t cadence.Type) interpreter.Stat
 		*cadence.ResourceType,
 		*cadence.EventType,
 		*cadence.ContractType,
-		*cadence.EnumType:
+		*cadence.EnumType,
+		*cadence.AttachmentType:
 		return importCompositeType(memoryGauge, t.(cadence.CompositeType))
 	case *cadence.StructInterfaceType,
 		*cadence.ResourceInterfaceType,
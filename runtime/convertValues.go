@@ -447,6 +447,18 @@ func exportCompositeValue(
 			return nil, err
 		}
 		return enum.WithType(t.(*cadence.EnumType)), nil
+	case common.CompositeKindAttachment:
+		attachment, err := cadence.NewMeteredAttachment(
+			inter,
+			len(fieldNames),
+			func() ([]cadence.Value, error) {
+				return makeFields()
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return attachment.WithType(t.(*cadence.AttachmentType)), nil
 	}
 
 	return nil, errors.NewDefaultUserError(
@@ -459,6 +471,7 @@ func exportCompositeValue(
 				common.CompositeKindEvent.Name(),
 				common.CompositeKindContract.Name(),
 				common.CompositeKindEnum.Name(),
+				common.CompositeKindAttachment.Name(),
 			},
 			"or",
 		),
@@ -577,6 +590,16 @@ func exportSimpleCompositeValue(
 			return nil, err
 		}
 		return enum.WithType(t.(*cadence.EnumType)), nil
+	case common.CompositeKindAttachment:
+		attachment, err := cadence.NewMeteredAttachment(
+			inter,
+			len(fieldNames),
+			makeFields,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return attachment.WithType(t.(*cadence.AttachmentType)), nil
 	}
 
 	return nil, errors.NewUnexpectedError(
@@ -589,6 +612,7 @@ func exportSimpleCompositeValue(
 				common.CompositeKindEvent.Name(),
 				common.CompositeKindContract.Name(),
 				common.CompositeKindEnum.Name(),
+				common.CompositeKindAttachment.Name(),
 			},
 			"or",
 		),
@@ -875,6 +899,12 @@ func (i valueImporter) importValue(value cadence.Value, expectedType sema.Type)
 			v.EnumType.Fields,
 			v.Fields,
 		)
+	case cadence.Attachment:
+		// Attachments only exist attached to a base value
+		return nil, errors.NewDefaultUserError(
+			"cannot import value of type %s: attachments cannot be imported",
+			v.AttachmentType.ID(),
+		)
 	case cadence.TypeValue:
 		return i.importTypeValue(v.StaticType)
 	case cadence.Capability:
@@ -813,6 +813,17 @@ func TestImportValue(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			label: "Attachment (invalid)",
+			value: cadence.NewAttachment(
+				[]cadence.Value{},
+			).WithType(&cadence.AttachmentType{
+				Location:            TestLocation,
+				QualifiedIdentifier: "A",
+				Fields:              []cadence.Field{},
+			}),
+			expected: nil,
+		},
 		{
 			label:    "Type<Int>()",
 			value:    cadence.NewTypeValue(cadence.IntType{}),
@@ -1196,6 +1207,17 @@ func TestImportRuntimeType(t *testing.T) {
 				QualifiedIdentifier: "S",
 			},
 		},
+		{
+			label: "Attachment",
+			actual: &cadence.AttachmentType{
+				Location:            TestLocation,
+				QualifiedIdentifier: "A",
+			},
+			expected: interpreter.CompositeStaticType{
+				Location:            TestLocation,
+				QualifiedIdentifier: "A",
+			},
+		},
 		{
 			label: "Event",
 			actual: &cadence.EventType{
@@ -1665,6 +1687,83 @@ func exportValueFromScript(t *testing.T, script string) cadence.Value {
 	return value
 }
 
+func TestExportAttachmentValue(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub struct Foo {
+                pub let bar: Int
+
+                init(bar: Int) {
+                    self.bar = bar
+                }
+            }
+
+            pub attachment A for Foo {
+                pub let baz: Int
+
+                init(baz: Int) {
+                    self.baz = baz
+                }
+            }
+
+            pub fun main(): &A? {
+                let foo = attach A(baz: 1) to Foo(bar: 42)
+                return foo[A]
+            }
+        `
+
+		actual := exportValueFromScript(t, script)
+		expected := cadence.NewOptional(
+			cadence.NewAttachment([]cadence.Value{cadence.NewInt(1)}).
+				WithType(&cadence.AttachmentType{
+					Location:            TestLocation,
+					QualifiedIdentifier: "A",
+					BaseType:            fooStructType,
+					Fields: []cadence.Field{
+						{
+							Identifier: "baz",
+							Type:       cadence.IntType{},
+						},
+					},
+				}),
+		)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("base", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub struct Foo {
+                pub let bar: Int
+
+                init(bar: Int) {
+                    self.bar = bar
+                }
+            }
+
+            pub attachment A for Foo {}
+
+            pub fun main(): Foo {
+                return attach A() to Foo(bar: 42)
+            }
+        `
+
+		actual := exportValueFromScript(t, script)
+		expected := cadence.NewStruct([]cadence.Value{cadence.NewInt(42)}).WithType(fooStructType)
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
 func TestExportReferenceValue(t *testing.T) {
 
 	t.Parallel()
@@ -20,37 +20,703 @@ package runtime
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 )
 
+// BranchKind is the kind of a conditional element
+type BranchKind string
+
+const (
+	BranchKindIf            BranchKind = "if"
+	BranchKindSwitch        BranchKind = "switch"
+	BranchKindConditional   BranchKind = "conditional"
+	BranchKindNilCoalescing BranchKind = "nil-coalescing"
+)
+
+// branchKind returns the kind of the given conditional element,
+// or false if the element is not a conditional element
+func branchKind(element ast.Element) (BranchKind, bool) {
+	switch element := element.(type) {
+	case *ast.IfStatement:
+		return BranchKindIf, true
+	case *ast.SwitchStatement:
+		return BranchKindSwitch, true
+	case *ast.ConditionalExpression:
+		return BranchKindConditional, true
+	case *ast.BinaryExpression:
+		if element.Operation == ast.OperationNilCoalesce {
+			return BranchKindNilCoalescing, true
+		}
+	}
+	return "", false
+}
+
+// BranchCoverage records how often each branch of a conditional element was taken,
+// e.g. the then-branch and the else-branch of an if-statement.
+// See interpreter.OnBranchFunc for the indices of the branches
+type BranchCoverage struct {
+	// Kind is the kind of the conditional element
+	Kind BranchKind `json:"kind"`
+	// Line and Column are the start position of the conditional element
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	// EndLine and EndColumn are the end position of the conditional element.
+	// Nested conditional elements may start at the same position,
+	// e.g. the nil-coalescing expression in `(a ?? b) ? c : d`
+	EndLine   int `json:"end_line"`
+	EndColumn int `json:"end_column"`
+	// Hits are the number of times each branch was taken
+	Hits []int `json:"hits"`
+}
+
+// CoveredBranches returns the number of branches which were taken at least once.
+func (c *BranchCoverage) CoveredBranches() int {
+	coveredBranches := 0
+	for _, hits := range c.Hits {
+		if hits > 0 {
+			coveredBranches++
+		}
+	}
+	return coveredBranches
+}
+
+// FunctionCoverage records how often a function was invoked
+type FunctionCoverage struct {
+	// Name is the qualified name of the function, e.g. `Foo.bar`
+	Name string `json:"name"`
+	// Line is the start line of the function declaration
+	Line int `json:"line"`
+	// Hits is the number of invocations
+	Hits int `json:"hits"`
+}
+
+type coveragePosition struct {
+	line   int
+	column int
+}
+
+func newCoveragePosition(position ast.Position) coveragePosition {
+	return coveragePosition{
+		line:   position.Line,
+		column: position.Column,
+	}
+}
+
+// less returns true if the position is before the other position
+func (p coveragePosition) less(other coveragePosition) bool {
+	return p.line < other.line ||
+		(p.line == other.line && p.column < other.column)
+}
+
+// branchKey identifies a conditional element
+type branchKey struct {
+	kind  BranchKind
+	start coveragePosition
+	end   coveragePosition
+}
+
+func newBranchKey(element ast.Element, kind BranchKind) branchKey {
+	return branchKey{
+		kind:  kind,
+		start: newCoveragePosition(element.StartPosition()),
+		end:   newCoveragePosition(element.EndPosition(nil)),
+	}
+}
+
+func (c *BranchCoverage) key() branchKey {
+	return branchKey{
+		kind: c.Kind,
+		start: coveragePosition{
+			line:   c.Line,
+			column: c.Column,
+		},
+		end: coveragePosition{
+			line:   c.EndLine,
+			column: c.EndColumn,
+		},
+	}
+}
+
+// less returns true if the conditional element is ordered before the other conditional element:
+// Elements are ordered by their start position, and enclosing elements before nested elements
+func (k branchKey) less(other branchKey) bool {
+	if k.start != other.start {
+		return k.start.less(other.start)
+	}
+	if k.end != other.end {
+		return other.end.less(k.end)
+	}
+	return k.kind < other.kind
+}
+
 // LocationCoverage records coverage information for a location
 type LocationCoverage struct {
+	// LineHits are the number of times each line was hit.
+	// Lines which contain statements that were never executed have zero hits,
+	// if the program of the location was inspected
 	LineHits map[int]int `json:"line_hits"`
+	// Statements is the number of lines which contain statements,
+	// if the program of the location was inspected
+	Statements int `json:"statements"`
+	// Branches are the conditional elements, ordered by position
+	Branches []*BranchCoverage `json:"branches,omitempty"`
+	// Functions are the declared functions, ordered by position.
+	// Only the functions of inspected programs are recorded
+	Functions []*FunctionCoverage `json:"functions,omitempty"`
+
+	branchesByKey map[branchKey]*BranchCoverage
+	// functionsByParameters are the functions, by the positions of their parameter lists,
+	// which identify the interpreted functions when they are invoked
+	functionsByParameters map[coveragePosition]*FunctionCoverage
 }
 
 func (c *LocationCoverage) AddLineHit(line int) {
 	c.LineHits[line]++
 }
 
+// AddBranchHit records that the branch with the given index
+// of the given conditional element was taken.
+// Elements which are not conditional elements are ignored.
+func (c *LocationCoverage) AddBranchHit(element ast.Element, branch int) {
+	kind, ok := branchKind(element)
+	if !ok {
+		return
+	}
+	branchCoverage := c.branch(newBranchKey(element, kind), branch+1)
+	branchCoverage.Hits[branch]++
+}
+
+// branch returns the coverage of the conditional element with the given key,
+// which has at least the given number of branches
+func (c *LocationCoverage) branch(key branchKey, branches int) *BranchCoverage {
+	c.ensureIndices()
+
+	branchCoverage := c.branchesByKey[key]
+	if branchCoverage == nil {
+		branchCoverage = &BranchCoverage{
+			Kind:      key.kind,
+			Line:      key.start.line,
+			Column:    key.start.column,
+			EndLine:   key.end.line,
+			EndColumn: key.end.column,
+		}
+		c.branchesByKey[key] = branchCoverage
+
+		index := sort.Search(len(c.Branches), func(i int) bool {
+			return key.less(c.Branches[i].key())
+		})
+		c.Branches = append(c.Branches, nil)
+		copy(c.Branches[index+1:], c.Branches[index:])
+		c.Branches[index] = branchCoverage
+	}
+
+	for len(branchCoverage.Hits) < branches {
+		branchCoverage.Hits = append(branchCoverage.Hits, 0)
+	}
+
+	return branchCoverage
+}
+
+// AddFunctionHit records an invocation of the function with the parameter list at the given position.
+// Invocations of functions which were not recorded when inspecting the program are ignored.
+func (c *LocationCoverage) AddFunctionHit(parameterListPosition ast.Position) {
+	c.ensureIndices()
+
+	functionCoverage := c.functionsByParameters[newCoveragePosition(parameterListPosition)]
+	if functionCoverage == nil {
+		return
+	}
+	functionCoverage.Hits++
+}
+
+// ensureIndices initializes the indices of the branches and functions,
+// e.g. if the coverage was decoded.
+// Functions can only be indexed by inspecting their program again
+func (c *LocationCoverage) ensureIndices() {
+	if c.branchesByKey == nil {
+		c.branchesByKey = make(map[branchKey]*BranchCoverage, len(c.Branches))
+		for _, branchCoverage := range c.Branches {
+			c.branchesByKey[branchCoverage.key()] = branchCoverage
+		}
+	}
+
+	if c.functionsByParameters == nil {
+		c.functionsByParameters = map[coveragePosition]*FunctionCoverage{}
+	}
+}
+
+// Lines returns the lines with coverage information, in ascending order.
+func (c *LocationCoverage) Lines() []int {
+	lines := make([]int, 0, len(c.LineHits))
+	for line := range c.LineHits { // nolint:maprangecheck
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// CoveredLines returns the number of lines which were hit at least once.
+func (c *LocationCoverage) CoveredLines() int {
+	coveredLines := 0
+	for _, hits := range c.LineHits { // nolint:maprangecheck
+		if hits > 0 {
+			coveredLines++
+		}
+	}
+	return coveredLines
+}
+
+// TotalBranches returns the number of branches of all conditional elements.
+func (c *LocationCoverage) TotalBranches() int {
+	totalBranches := 0
+	for _, branchCoverage := range c.Branches {
+		totalBranches += len(branchCoverage.Hits)
+	}
+	return totalBranches
+}
+
+// CoveredBranches returns the number of branches which were taken at least once.
+func (c *LocationCoverage) CoveredBranches() int {
+	coveredBranches := 0
+	for _, branchCoverage := range c.Branches {
+		coveredBranches += branchCoverage.CoveredBranches()
+	}
+	return coveredBranches
+}
+
+// CoveredFunctions returns the number of functions which were invoked at least once.
+func (c *LocationCoverage) CoveredFunctions() int {
+	coveredFunctions := 0
+	for _, functionCoverage := range c.Functions {
+		if functionCoverage.Hits > 0 {
+			coveredFunctions++
+		}
+	}
+	return coveredFunctions
+}
+
+// LinePercentage returns the percentage of lines which were hit.
+func (c *LocationCoverage) LinePercentage() float64 {
+	return coveragePercentage(c.CoveredLines(), len(c.LineHits))
+}
+
+// BranchPercentage returns the percentage of branches which were taken.
+func (c *LocationCoverage) BranchPercentage() float64 {
+	return coveragePercentage(c.CoveredBranches(), c.TotalBranches())
+}
+
+// FunctionPercentage returns the percentage of functions which were invoked.
+func (c *LocationCoverage) FunctionPercentage() float64 {
+	return coveragePercentage(c.CoveredFunctions(), len(c.Functions))
+}
+
+// coveragePercentage returns the percentage of covered items.
+// If there are no items, nothing is uncovered, so the percentage is 100
+func coveragePercentage(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) / float64(total) * 100
+}
+
 func NewLocationCoverage() *LocationCoverage {
 	return &LocationCoverage{
 		LineHits: map[int]int{},
 	}
 }
 
+// Merge adds the hits of the given coverage to this coverage.
+// Lines, conditional elements, and functions which are only recorded in the given coverage are added.
+func (c *LocationCoverage) Merge(other *LocationCoverage) {
+	for line, hits := range other.LineHits { // nolint:maprangecheck
+		c.LineHits[line] += hits
+	}
+
+	if other.Statements > c.Statements {
+		c.Statements = other.Statements
+	}
+
+	for _, otherBranchCoverage := range other.Branches {
+		branchCoverage := c.branch(otherBranchCoverage.key(), len(otherBranchCoverage.Hits))
+		for branch, hits := range otherBranchCoverage.Hits {
+			branchCoverage.Hits[branch] += hits
+		}
+	}
+
+	c.ensureIndices()
+
+	mergedFunctions := make(map[*FunctionCoverage]*FunctionCoverage, len(other.Functions))
+	for _, otherFunctionCoverage := range other.Functions {
+		functionCoverage := c.function(otherFunctionCoverage.Name, otherFunctionCoverage.Line)
+		functionCoverage.Hits += otherFunctionCoverage.Hits
+		mergedFunctions[otherFunctionCoverage] = functionCoverage
+	}
+
+	// Keep the functions of the other coverage identifiable,
+	// so invocations can still be recorded after merging
+
+	for key, otherFunctionCoverage := range other.functionsByParameters { // nolint:maprangecheck
+		if _, ok := c.functionsByParameters[key]; ok {
+			continue
+		}
+		c.functionsByParameters[key] = mergedFunctions[otherFunctionCoverage]
+	}
+}
+
+// function returns the coverage of the function with the given name which is declared at the given line
+func (c *LocationCoverage) function(name string, line int) *FunctionCoverage {
+	for _, functionCoverage := range c.Functions {
+		if functionCoverage.Name == name && functionCoverage.Line == line {
+			return functionCoverage
+		}
+	}
+
+	functionCoverage := &FunctionCoverage{
+		Name: name,
+		Line: line,
+	}
+
+	index := sort.Search(len(c.Functions), func(index int) bool {
+		return c.Functions[index].Line > line
+	})
+	c.Functions = append(c.Functions, nil)
+	copy(c.Functions[index+1:], c.Functions[index:])
+	c.Functions[index] = functionCoverage
+
+	return functionCoverage
+}
+
+// Summary returns the covered and total number of lines, branches, and functions.
+func (c *LocationCoverage) Summary() CoverageSummary {
+	return CoverageSummary{
+		Lines:            len(c.LineHits),
+		CoveredLines:     c.CoveredLines(),
+		Branches:         c.TotalBranches(),
+		CoveredBranches:  c.CoveredBranches(),
+		Functions:        len(c.Functions),
+		CoveredFunctions: c.CoveredFunctions(),
+	}
+}
+
+// CoverageSummary is the number of covered and total lines, branches, and functions
+// of a location, or of all locations of a report
+type CoverageSummary struct {
+	Lines            int `json:"lines"`
+	CoveredLines     int `json:"covered_lines"`
+	Branches         int `json:"branches"`
+	CoveredBranches  int `json:"covered_branches"`
+	Functions        int `json:"functions"`
+	CoveredFunctions int `json:"covered_functions"`
+}
+
+func (s CoverageSummary) add(other CoverageSummary) CoverageSummary {
+	return CoverageSummary{
+		Lines:            s.Lines + other.Lines,
+		CoveredLines:     s.CoveredLines + other.CoveredLines,
+		Branches:         s.Branches + other.Branches,
+		CoveredBranches:  s.CoveredBranches + other.CoveredBranches,
+		Functions:        s.Functions + other.Functions,
+		CoveredFunctions: s.CoveredFunctions + other.CoveredFunctions,
+	}
+}
+
+// LinePercentage returns the percentage of lines which were hit.
+func (s CoverageSummary) LinePercentage() float64 {
+	return coveragePercentage(s.CoveredLines, s.Lines)
+}
+
+// BranchPercentage returns the percentage of branches which were taken.
+func (s CoverageSummary) BranchPercentage() float64 {
+	return coveragePercentage(s.CoveredBranches, s.Branches)
+}
+
+// FunctionPercentage returns the percentage of functions which were invoked.
+func (s CoverageSummary) FunctionPercentage() float64 {
+	return coveragePercentage(s.CoveredFunctions, s.Functions)
+}
+
+// CoverageReportSummary is the coverage summary of each location of a report,
+// and the summary of all locations
+type CoverageReportSummary struct {
+	Locations map[common.Location]CoverageSummary `json:"-"`
+	Total     CoverageSummary                     `json:"total"`
+}
+
+func (s CoverageReportSummary) MarshalJSON() ([]byte, error) {
+	locations := make(map[string]CoverageSummary, len(s.Locations))
+	for location, summary := range s.Locations { // nolint:maprangecheck
+		locations[coverageLocationID(location)] = summary
+	}
+	return json.Marshal(&struct {
+		Locations map[string]CoverageSummary `json:"locations"`
+		Total     CoverageSummary            `json:"total"`
+	}{
+		Locations: locations,
+		Total:     s.Total,
+	})
+}
+
+// CoverageLocationFilter returns true if coverage should be collected for the given location
+type CoverageLocationFilter func(location Location) bool
+
 // CoverageReport is a collection of coverage per location
 type CoverageReport struct {
 	Coverage map[common.Location]*LocationCoverage `json:"-"`
+	// locationFilter optionally restricts the locations for which coverage is collected
+	locationFilter CoverageLocationFilter
+	// excludedLocations are the locations for which coverage is never collected
+	excludedLocations map[common.Location]struct{}
+}
+
+// WithLocationFilter restricts the report to the locations for which the given filter returns true.
+// Coverage which was already collected for other locations is removed.
+func (r *CoverageReport) WithLocationFilter(filter CoverageLocationFilter) *CoverageReport {
+	r.locationFilter = filter
+	r.removeExcludedLocations()
+	return r
+}
+
+// ExcludeLocation excludes the given location from the report,
+// e.g. the location of a standard library contract or of a test helper.
+// Coverage which was already collected for the location is removed.
+func (r *CoverageReport) ExcludeLocation(location Location) {
+	if r.excludedLocations == nil {
+		r.excludedLocations = map[common.Location]struct{}{}
+	}
+	r.excludedLocations[location] = struct{}{}
+	r.removeExcludedLocations()
+}
+
+// IsLocationIncluded returns true if coverage is collected for the given location.
+func (r *CoverageReport) IsLocationIncluded(location Location) bool {
+	if _, ok := r.excludedLocations[location]; ok {
+		return false
+	}
+	return r.locationFilter == nil || r.locationFilter(location)
+}
+
+func (r *CoverageReport) removeExcludedLocations() {
+	for location := range r.Coverage { // nolint:maprangecheck
+		if !r.IsLocationIncluded(location) {
+			delete(r.Coverage, location)
+		}
+	}
 }
 
 func (r *CoverageReport) AddLineHit(location Location, line int) {
+	locationCoverage := r.locationCoverage(location)
+	if locationCoverage == nil {
+		return
+	}
+	locationCoverage.AddLineHit(line)
+}
+
+// AddBranchHit records that the branch with the given index
+// of the given conditional element was taken.
+func (r *CoverageReport) AddBranchHit(location Location, element ast.Element, branch int) {
+	locationCoverage := r.locationCoverage(location)
+	if locationCoverage == nil {
+		return
+	}
+	locationCoverage.AddBranchHit(element, branch)
+}
+
+// AddFunctionHit records an invocation of the function with the parameter list at the given position.
+func (r *CoverageReport) AddFunctionHit(location Location, parameterListPosition ast.Position) {
+	locationCoverage := r.locationCoverage(location)
+	if locationCoverage == nil {
+		return
+	}
+	locationCoverage.AddFunctionHit(parameterListPosition)
+}
+
+// Merge adds the coverage of the given report to this report,
+// e.g. to combine the coverage of several runtime instances.
+// Locations which are not included in this report are skipped.
+func (r *CoverageReport) Merge(other *CoverageReport) {
+	for location, otherLocationCoverage := range other.Coverage { // nolint:maprangecheck
+		locationCoverage := r.locationCoverage(location)
+		if locationCoverage == nil {
+			continue
+		}
+		locationCoverage.Merge(otherLocationCoverage)
+	}
+}
+
+// Summary returns the covered and total number of lines, branches, and functions
+// of each location, and of all locations.
+func (r *CoverageReport) Summary() CoverageReportSummary {
+	summary := CoverageReportSummary{
+		Locations: make(map[common.Location]CoverageSummary, len(r.Coverage)),
+	}
+	for location, locationCoverage := range r.Coverage { // nolint:maprangecheck
+		locationSummary := locationCoverage.Summary()
+		summary.Locations[location] = locationSummary
+		summary.Total = summary.Total.add(locationSummary)
+	}
+	return summary
+}
+
+// locationCoverage returns the coverage of the given location,
+// or nil if the location is not included in the report
+func (r *CoverageReport) locationCoverage(location Location) *LocationCoverage {
+	if !r.IsLocationIncluded(location) {
+		return nil
+	}
+
 	locationCoverage := r.Coverage[location]
 	if locationCoverage == nil {
 		locationCoverage = NewLocationCoverage()
 		r.Coverage[location] = locationCoverage
 	}
-	locationCoverage.AddLineHit(line)
+	return locationCoverage
+}
+
+// InspectProgram records the statements, conditional elements, and functions of the given program,
+// so that lines, branches, and functions which are never executed are reported with zero hits.
+// Hits which were already recorded for the location are kept.
+func (r *CoverageReport) InspectProgram(location Location, program *ast.Program) {
+	locationCoverage := r.locationCoverage(location)
+	if locationCoverage == nil {
+		return
+	}
+	locationCoverage.ensureIndices()
+
+	inspector := &coverageInspector{
+		coverage: locationCoverage,
+		lines:    map[int]struct{}{},
+	}
+	ast.Walk(inspector, program)
+
+	for line := range inspector.lines { // nolint:maprangecheck
+		if _, ok := locationCoverage.LineHits[line]; !ok {
+			locationCoverage.LineHits[line] = 0
+		}
+	}
+
+	locationCoverage.Statements = len(inspector.lines)
+}
+
+// coverageInspector is an AST walker which records the coverable elements of a program:
+//   - The lines of the statements, i.e. the lines which are reported to the statement handler
+//     of the interpreter when they are executed. This includes the conditions of functions.
+//   - The conditional elements, i.e. if-statements, switch-statements,
+//     conditional expressions, and nil-coalescing expressions.
+//   - The function declarations, with their names qualified by the enclosing composite declarations.
+type coverageInspector struct {
+	coverage *LocationCoverage
+	lines    map[int]struct{}
+	// elements are the elements enclosing the currently walked element
+	elements []ast.Element
+}
+
+var _ ast.Walker = &coverageInspector{}
+
+func (i *coverageInspector) Walk(element ast.Element) ast.Walker {
+	if element == nil {
+		i.elements = i.elements[:len(i.elements)-1]
+		return nil
+	}
+
+	switch element := element.(type) {
+	case *ast.Block:
+		i.addStatementLines(element.Statements)
+
+	case *ast.FunctionBlock:
+		i.addConditionLines(element.PreConditions)
+		i.addConditionLines(element.PostConditions)
+
+	case *ast.IfStatement:
+		i.addBranches(element, BranchKindIf, 2)
+
+	case *ast.SwitchStatement:
+		branches := len(element.Cases)
+		hasDefault := false
+		for _, switchCase := range element.Cases {
+			i.addStatementLines(switchCase.Statements)
+			if switchCase.Expression == nil {
+				hasDefault = true
+			}
+		}
+		// Without a default case, it is possible that no case is executed
+		if !hasDefault {
+			branches++
+		}
+		i.addBranches(element, BranchKindSwitch, branches)
+
+	case *ast.ConditionalExpression:
+		i.addBranches(element, BranchKindConditional, 2)
+
+	case *ast.BinaryExpression:
+		if element.Operation == ast.OperationNilCoalesce {
+			i.addBranches(element, BranchKindNilCoalescing, 2)
+		}
+
+	case *ast.FunctionDeclaration:
+		i.addFunction(element)
+
+	case *ast.SpecialFunctionDeclaration:
+		// The walk of a special function declaration
+		// does not include the function declaration itself
+		i.addFunction(element.FunctionDeclaration)
+	}
+
+	i.elements = append(i.elements, element)
+
+	return i
+}
+
+func (i *coverageInspector) addStatementLines(statements []ast.Statement) {
+	for _, statement := range statements {
+		i.lines[statement.StartPosition().Line] = struct{}{}
+	}
+}
+
+func (i *coverageInspector) addConditionLines(conditions *ast.Conditions) {
+	if conditions == nil {
+		return
+	}
+	for _, condition := range *conditions {
+		i.lines[condition.Test.StartPosition().Line] = struct{}{}
+	}
+}
+
+func (i *coverageInspector) addBranches(element ast.Element, kind BranchKind, branches int) {
+	i.coverage.branch(newBranchKey(element, kind), branches)
+}
+
+func (i *coverageInspector) addFunction(declaration *ast.FunctionDeclaration) {
+	// Functions without a body, e.g. functions of interfaces, are never invoked
+	if declaration.FunctionBlock == nil || declaration.ParameterList == nil {
+		return
+	}
+
+	// Qualify the name with the enclosing composite declarations
+
+	name := declaration.Identifier.Identifier
+
+	for index := len(i.elements) - 1; index >= 0; index-- {
+		switch enclosing := i.elements[index].(type) {
+		case *ast.CompositeDeclaration:
+			name = enclosing.Identifier.Identifier + "." + name
+
+		case *ast.InterfaceDeclaration:
+			// Functions of interfaces are never invoked, only their conditions
+			return
+		}
+	}
+
+	// An existing record is kept, e.g. when the program is inspected again
+
+	functionCoverage := i.coverage.function(name, declaration.StartPosition().Line)
+
+	key := newCoveragePosition(declaration.ParameterList.StartPos)
+	i.coverage.functionsByParameters[key] = functionCoverage
 }
 
 func NewCoverageReport() *CoverageReport {
@@ -64,9 +730,7 @@ func (r *CoverageReport) MarshalJSON() ([]byte, error) {
 
 	coverage := make(map[string]*LocationCoverage, len(r.Coverage))
 	for location, locationCoverage := range r.Coverage { // nolint:maprangecheck
-		typeID := location.TypeID(nil, "")
-		locationID := typeID[:len(typeID)-1]
-		coverage[string(locationID)] = locationCoverage
+		coverage[coverageLocationID(location)] = locationCoverage
 	}
 	return json.Marshal(&struct {
 		Coverage map[string]*LocationCoverage `json:"coverage"`
@@ -76,3 +740,70 @@ func (r *CoverageReport) MarshalJSON() ([]byte, error) {
 		Alias:    (*Alias)(r),
 	})
 }
+
+func (r *CoverageReport) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Coverage map[string]*LocationCoverage `json:"coverage"`
+	}
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	r.Coverage = make(map[common.Location]*LocationCoverage, len(decoded.Coverage))
+	for locationID, locationCoverage := range decoded.Coverage { // nolint:maprangecheck
+		location, err := decodeCoverageLocationID(locationID)
+		if err != nil {
+			return err
+		}
+		if locationCoverage.LineHits == nil {
+			locationCoverage.LineHits = map[int]int{}
+		}
+		r.Coverage[location] = locationCoverage
+	}
+
+	return nil
+}
+
+// coverageLocationID returns the ID of the given location,
+// e.g. `S.imported`, or `A.0000000000000001.Foo`
+func coverageLocationID(location Location) string {
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		return string(addressLocation.TypeID(nil, addressLocation.Name))
+	}
+
+	typeID := location.TypeID(nil, "")
+	return string(typeID[:len(typeID)-1])
+}
+
+// decodeCoverageLocationID returns the location with the given ID.
+// See coverageLocationID
+func decodeCoverageLocationID(locationID string) (Location, error) {
+	prefix, rest, _ := strings.Cut(locationID, ".")
+
+	switch prefix {
+	case common.StringLocationPrefix:
+		// String locations may contain dots, e.g. file names
+		return common.StringLocation(rest), nil
+
+	case common.AddressLocationPrefix:
+		addressHex, name, _ := strings.Cut(rest, ".")
+		address, err := common.HexToAddress(addressHex)
+		if err != nil {
+			return nil, err
+		}
+		return common.AddressLocation{
+			Address: address,
+			Name:    name,
+		}, nil
+	}
+
+	location, _, err := common.DecodeTypeID(nil, locationID+".")
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, errors.NewDefaultUserError("invalid coverage location: %s", locationID)
+	}
+	return location, nil
+}
@@ -0,0 +1,392 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// CoverageLocationPathMapper maps a location to the path of its source file,
+// which is used to refer to the location in exported coverage reports.
+type CoverageLocationPathMapper func(location common.Location) string
+
+// DefaultCoverageLocationPath is the default CoverageLocationPathMapper.
+// String locations are mapped to their string, i.e. usually a path,
+// and all other locations are mapped to their ID, e.g. `A.0000000000000001.Foo`.
+func DefaultCoverageLocationPath(location common.Location) string {
+	if stringLocation, ok := location.(common.StringLocation); ok {
+		return string(stringLocation)
+	}
+	return coverageLocationID(location)
+}
+
+type locationCoveragePath struct {
+	path     string
+	coverage *LocationCoverage
+}
+
+// locationCoveragePaths returns the coverage of all locations with their paths, sorted by path
+func (r *CoverageReport) locationCoveragePaths(paths CoverageLocationPathMapper) []locationCoveragePath {
+	if paths == nil {
+		paths = DefaultCoverageLocationPath
+	}
+
+	result := make([]locationCoveragePath, 0, len(r.Coverage))
+	for location, coverage := range r.Coverage { // nolint:maprangecheck
+		result = append(result, locationCoveragePath{
+			path:     paths(location),
+			coverage: coverage,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].path < result[j].path
+	})
+
+	return result
+}
+
+// WriteLCOV writes the report in the LCOV tracefile format.
+// The given function maps locations to source file paths.
+// If it is nil, DefaultCoverageLocationPath is used.
+func (r *CoverageReport) WriteLCOV(writer io.Writer, paths CoverageLocationPathMapper) error {
+	w := bufio.NewWriter(writer)
+
+	for _, locationCoverage := range r.locationCoveragePaths(paths) {
+		coverage := locationCoverage.coverage
+
+		_, _ = fmt.Fprintf(w, "TN:\nSF:%s\n", locationCoverage.path)
+
+		// Functions
+
+		for _, function := range coverage.Functions {
+			_, _ = fmt.Fprintf(w, "FN:%d,%s\n", function.Line, function.Name)
+		}
+		for _, function := range coverage.Functions {
+			_, _ = fmt.Fprintf(w, "FNDA:%d,%s\n", function.Hits, function.Name)
+		}
+		_, _ = fmt.Fprintf(
+			w,
+			"FNF:%d\nFNH:%d\n",
+			len(coverage.Functions),
+			coverage.CoveredFunctions(),
+		)
+
+		// Branches.
+		// The block number is the index of the conditional element in its line
+
+		block := 0
+		previousLine := 0
+		for _, branch := range coverage.Branches {
+			if branch.Line == previousLine {
+				block++
+			} else {
+				block = 0
+				previousLine = branch.Line
+			}
+
+			reached := false
+			for _, hits := range branch.Hits {
+				if hits > 0 {
+					reached = true
+					break
+				}
+			}
+
+			for index, hits := range branch.Hits {
+				// A dash indicates that the conditional element was never reached
+				taken := "-"
+				if reached {
+					taken = strconv.Itoa(hits)
+				}
+				_, _ = fmt.Fprintf(w, "BRDA:%d,%d,%d,%s\n", branch.Line, block, index, taken)
+			}
+		}
+		_, _ = fmt.Fprintf(
+			w,
+			"BRF:%d\nBRH:%d\n",
+			coverage.TotalBranches(),
+			coverage.CoveredBranches(),
+		)
+
+		// Lines
+
+		for _, line := range coverage.Lines() {
+			_, _ = fmt.Fprintf(w, "DA:%d,%d\n", line, coverage.LineHits[line])
+		}
+
+		_, _ = fmt.Fprintf(
+			w,
+			"LF:%d\nLH:%d\nend_of_record\n",
+			len(coverage.LineHits),
+			coverage.CoveredLines(),
+		)
+	}
+
+	return w.Flush()
+}
+
+// MarshalLCOV returns the report in the LCOV tracefile format,
+// using DefaultCoverageLocationPath to map locations to source file paths.
+func (r *CoverageReport) MarshalLCOV() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := r.WriteLCOV(&buffer, nil)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+const sourceFileExtension = ".cdc"
+
+const coberturaDocType = `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`
+
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        float64            `xml:"line-rate,attr"`
+	BranchRate      float64            `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      float64            `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity float64          `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string           `xml:"name,attr"`
+	Filename   string           `xml:"filename,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity float64          `xml:"complexity,attr"`
+	Methods    coberturaMethods `xml:"methods"`
+	Lines      []coberturaLine  `xml:"lines>line"`
+}
+
+type coberturaMethods struct {
+	Methods []coberturaMethod `xml:"method"`
+}
+
+type coberturaMethod struct {
+	Name       string          `xml:"name,attr"`
+	Signature  string          `xml:"signature,attr"`
+	LineRate   float64         `xml:"line-rate,attr"`
+	BranchRate float64         `xml:"branch-rate,attr"`
+	Complexity float64         `xml:"complexity,attr"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr,omitempty"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+type coberturaTotals struct {
+	linesCovered    int
+	linesValid      int
+	branchesCovered int
+	branchesValid   int
+}
+
+// coberturaLines returns the lines of the given coverage.
+// Lines with conditional elements are reported as branches,
+// with the number of covered branches of all conditional elements in the line
+func coberturaLines(coverage *LocationCoverage) []coberturaLine {
+	type lineBranchCounts struct {
+		covered int
+		total   int
+	}
+
+	lineBranches := map[int]lineBranchCounts{}
+	for _, branch := range coverage.Branches {
+		counts := lineBranches[branch.Line]
+		counts.covered += branch.CoveredBranches()
+		counts.total += len(branch.Hits)
+		lineBranches[branch.Line] = counts
+	}
+
+	lines := make([]coberturaLine, 0, len(coverage.LineHits))
+
+	for _, line := range coverage.Lines() {
+		coberturaLine := coberturaLine{
+			Number: line,
+			Hits:   coverage.LineHits[line],
+		}
+
+		if counts, ok := lineBranches[line]; ok {
+			coberturaLine.Branch = true
+			coberturaLine.ConditionCoverage = fmt.Sprintf(
+				"%d%% (%d/%d)",
+				int(coveragePercentage(counts.covered, counts.total)),
+				counts.covered,
+				counts.total,
+			)
+		}
+
+		lines = append(lines, coberturaLine)
+	}
+
+	return lines
+}
+
+func coverageRate(covered, valid int) float64 {
+	return coveragePercentage(covered, valid) / 100
+}
+
+// WriteCobertura writes the report in the Cobertura XML format.
+// The given function maps locations to source file paths.
+// If it is nil, DefaultCoverageLocationPath is used.
+//
+// Each source file is reported as a class,
+// and the classes are grouped into packages by the directories of their paths.
+func (r *CoverageReport) WriteCobertura(writer io.Writer, paths CoverageLocationPathMapper) error {
+	report := coberturaCoverage{
+		Sources: []string{"."},
+	}
+
+	var packageNames []string
+	packages := map[string]*coberturaPackage{}
+	packageTotals := map[string]coberturaTotals{}
+
+	for _, locationCoverage := range r.locationCoveragePaths(paths) {
+		filename := locationCoverage.path
+		coverage := locationCoverage.coverage
+
+		packageName := path.Dir(filename)
+		if packageName == "." {
+			packageName = ""
+		}
+
+		pkg, ok := packages[packageName]
+		if !ok {
+			pkg = &coberturaPackage{
+				Name: packageName,
+			}
+			packages[packageName] = pkg
+			packageNames = append(packageNames, packageName)
+		}
+
+		coveredLines := coverage.CoveredLines()
+		validLines := len(coverage.LineHits)
+		coveredBranches := coverage.CoveredBranches()
+		validBranches := coverage.TotalBranches()
+
+		class := coberturaClass{
+			Name:       strings.TrimSuffix(path.Base(filename), sourceFileExtension),
+			Filename:   filename,
+			LineRate:   coverageRate(coveredLines, validLines),
+			BranchRate: coverageRate(coveredBranches, validBranches),
+			Lines:      coberturaLines(coverage),
+		}
+
+		for _, function := range coverage.Functions {
+			lineRate := 0.0
+			if function.Hits > 0 {
+				lineRate = 1
+			}
+
+			class.Methods.Methods = append(class.Methods.Methods, coberturaMethod{
+				Name:     function.Name,
+				LineRate: lineRate,
+				Lines: []coberturaLine{
+					{
+						Number: function.Line,
+						Hits:   function.Hits,
+					},
+				},
+			})
+		}
+
+		pkg.Classes = append(pkg.Classes, class)
+
+		totals := packageTotals[packageName]
+		totals.linesCovered += coveredLines
+		totals.linesValid += validLines
+		totals.branchesCovered += coveredBranches
+		totals.branchesValid += validBranches
+		packageTotals[packageName] = totals
+
+		report.LinesCovered += coveredLines
+		report.LinesValid += validLines
+		report.BranchesCovered += coveredBranches
+		report.BranchesValid += validBranches
+	}
+
+	sort.Strings(packageNames)
+
+	for _, packageName := range packageNames {
+		pkg := packages[packageName]
+		totals := packageTotals[packageName]
+		pkg.LineRate = coverageRate(totals.linesCovered, totals.linesValid)
+		pkg.BranchRate = coverageRate(totals.branchesCovered, totals.branchesValid)
+		report.Packages = append(report.Packages, *pkg)
+	}
+
+	report.LineRate = coverageRate(report.LinesCovered, report.LinesValid)
+	report.BranchRate = coverageRate(report.BranchesCovered, report.BranchesValid)
+
+	_, err := io.WriteString(writer, xml.Header+coberturaDocType+"\n")
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(report)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+// MarshalCobertura returns the report in the Cobertura XML format,
+// using DefaultCoverageLocationPath to map locations to source file paths.
+func (r *CoverageReport) MarshalCobertura() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := r.WriteCobertura(&buffer, nil)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
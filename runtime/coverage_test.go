@@ -21,13 +21,17 @@ package runtime
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/stdlib"
 )
 
 func TestRuntimeCoverage(t *testing.T) {
@@ -102,14 +106,26 @@ func TestRuntimeCoverage(t *testing.T) {
                "4": 1,
                "5": 42,
                "7": 1
-             }
+             },
+             "statements": 4,
+             "functions": [
+               {"name": "answer", "line": 2, "hits": 1}
+             ]
            },
            "t.0000000000000000000000000000000000000000000000000000000000000000": {
              "line_hits": {
                "5": 1,
                "6": 1,
+               "7": 0,
                "9": 1
-             }
+             },
+             "statements": 4,
+             "branches": [
+               {"kind": "if", "line": 6, "column": 9, "end_line": 8, "end_column": 9, "hits": [0, 1]}
+             ],
+             "functions": [
+               {"name": "main", "line": 4, "hits": 1}
+             ]
            }
          }
        }
@@ -117,3 +133,612 @@ func TestRuntimeCoverage(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestRuntimeCoverageBranchesAndFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime(Config{
+		CoverageReportingEnabled: true,
+	})
+
+	script := []byte(`
+      pub fun classify(_ n: Int): String {
+          switch n {
+          case 0:
+              return "zero"
+          case 1:
+              return "one"
+          }
+          return n > 0 ? "many" : "negative"
+      }
+
+      pub fun unused() {}
+
+      pub fun main(): String {
+          let value: String? = nil
+          return value ?? classify(2)
+      }
+    `)
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+	location := nextTransactionLocation()
+
+	coverageReport := NewCoverageReport()
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      &testRuntimeInterface{},
+			Location:       location,
+			CoverageReport: coverageReport,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.String("many"), value)
+
+	locationCoverage := coverageReport.Coverage[location]
+	require.NotNil(t, locationCoverage)
+
+	assert.Equal(t,
+		[]*BranchCoverage{
+			// switch: two cases, and no case matched
+			{Kind: BranchKindSwitch, Line: 3, Column: 10, EndLine: 8, EndColumn: 10, Hits: []int{0, 0, 1}},
+			// conditional: then and else
+			{Kind: BranchKindConditional, Line: 9, Column: 17, EndLine: 9, EndColumn: 43, Hits: []int{1, 0}},
+			// nil-coalescing: left and right
+			{Kind: BranchKindNilCoalescing, Line: 16, Column: 17, EndLine: 16, EndColumn: 36, Hits: []int{0, 1}},
+		},
+		locationCoverage.Branches,
+	)
+
+	assert.Equal(t,
+		[]*FunctionCoverage{
+			{Name: "classify", Line: 2, Hits: 1},
+			{Name: "unused", Line: 12, Hits: 0},
+			{Name: "main", Line: 14, Hits: 1},
+		},
+		locationCoverage.Functions,
+	)
+
+	assert.Equal(t, 7, locationCoverage.TotalBranches())
+	assert.Equal(t, 3, locationCoverage.CoveredBranches())
+	assert.Equal(t, 2, locationCoverage.CoveredFunctions())
+	assert.InDelta(t, 42.857, locationCoverage.BranchPercentage(), 0.001)
+	assert.InDelta(t, 66.667, locationCoverage.FunctionPercentage(), 0.001)
+}
+
+func TestRuntimeCoverageNestedBranches(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime(Config{
+		CoverageReportingEnabled: true,
+	})
+
+	// The nil-coalescing expression and the conditional expression
+	// start at the same position
+
+	script := []byte(`
+      pub fun main(): Int {
+          let a: Bool? = nil
+          return (a ?? false) ? 1 : 2
+      }
+    `)
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+	location := nextTransactionLocation()
+
+	coverageReport := NewCoverageReport()
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      &testRuntimeInterface{},
+			Location:       location,
+			CoverageReport: coverageReport,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(2), value)
+
+	locationCoverage := coverageReport.Coverage[location]
+	require.NotNil(t, locationCoverage)
+
+	assert.Equal(t,
+		[]*BranchCoverage{
+			// conditional: else
+			{Kind: BranchKindConditional, Line: 4, Column: 18, EndLine: 4, EndColumn: 36, Hits: []int{0, 1}},
+			// nil-coalescing: right
+			{Kind: BranchKindNilCoalescing, Line: 4, Column: 18, EndLine: 4, EndColumn: 27, Hits: []int{0, 1}},
+		},
+		locationCoverage.Branches,
+	)
+
+	assert.Equal(t, 4, locationCoverage.TotalBranches())
+	assert.Equal(t, 2, locationCoverage.CoveredBranches())
+}
+
+func parseTestCoverageProgram(t *testing.T) *ast.Program {
+
+	program, err := parser.ParseProgram(
+		[]byte(`
+          pub fun answer(): Int {
+              var i = 0
+              if i > 0 {
+                  panic("unreachable")
+              }
+              return 42
+          }
+        `),
+		nil,
+	)
+	require.NoError(t, err)
+
+	return program
+}
+
+// testCoverageIfStatement returns the if-statement of the program of newTestCoverageReport
+func testCoverageIfStatement(t *testing.T) ast.Statement {
+	function := parseTestCoverageProgram(t).FunctionDeclarations()[0]
+	return function.FunctionBlock.Block.Statements[1]
+}
+
+func newTestCoverageReport(t *testing.T) *CoverageReport {
+
+	program := parseTestCoverageProgram(t)
+
+	report := NewCoverageReport()
+
+	scriptLocation := common.StringLocation("scripts/answer.cdc")
+	report.InspectProgram(scriptLocation, program)
+	report.AddLineHit(scriptLocation, 3)
+	report.AddLineHit(scriptLocation, 4)
+	report.AddLineHit(scriptLocation, 7)
+
+	function := program.FunctionDeclarations()[0]
+	report.AddFunctionHit(scriptLocation, function.ParameterList.StartPos)
+
+	ifStatement := function.FunctionBlock.Block.Statements[1]
+	report.AddBranchHit(scriptLocation, ifStatement, 1)
+
+	contractLocation := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Foo",
+	}
+	report.AddLineHit(contractLocation, 2)
+	report.AddLineHit(contractLocation, 2)
+
+	return report
+}
+
+func TestCoverageReportInspectProgram(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+
+	coverage := report.Coverage[common.StringLocation("scripts/answer.cdc")]
+	require.NotNil(t, coverage)
+
+	assert.Equal(t,
+		map[int]int{
+			3: 1,
+			4: 1,
+			5: 0,
+			7: 1,
+		},
+		coverage.LineHits,
+	)
+	assert.Equal(t, 4, coverage.Statements)
+	assert.Equal(t, 3, coverage.CoveredLines())
+}
+
+func TestCoverageReportInspectProgramFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	program, err := parser.ParseProgram(
+		[]byte(`
+          pub contract Foo {
+
+              pub resource interface Receiver {
+                  pub fun receive()
+              }
+
+              pub resource Vault: Receiver {
+                  pub fun receive() {}
+              }
+
+              init() {}
+          }
+        `),
+		nil,
+	)
+	require.NoError(t, err)
+
+	location := common.StringLocation("contracts/Foo.cdc")
+
+	report := NewCoverageReport()
+	report.InspectProgram(location, program)
+
+	coverage := report.Coverage[location]
+	require.NotNil(t, coverage)
+
+	assert.Equal(t,
+		[]*FunctionCoverage{
+			{Name: "Foo.Vault.receive", Line: 9, Hits: 0},
+			{Name: "Foo.init", Line: 12, Hits: 0},
+		},
+		coverage.Functions,
+	)
+	assert.Equal(t, 0, coverage.CoveredFunctions())
+	assert.Equal(t, float64(0), coverage.FunctionPercentage())
+	assert.Equal(t, float64(100), coverage.BranchPercentage())
+}
+
+func TestCoverageReportLCOV(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+
+	var builder strings.Builder
+	err := report.WriteLCOV(
+		&builder,
+		func(location common.Location) string {
+			if addressLocation, ok := location.(common.AddressLocation); ok {
+				return "contracts/" + addressLocation.Name + ".cdc"
+			}
+			return DefaultCoverageLocationPath(location)
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"TN:\n"+
+			"SF:contracts/Foo.cdc\n"+
+			"FNF:0\n"+
+			"FNH:0\n"+
+			"BRF:0\n"+
+			"BRH:0\n"+
+			"DA:2,2\n"+
+			"LF:1\n"+
+			"LH:1\n"+
+			"end_of_record\n"+
+			"TN:\n"+
+			"SF:scripts/answer.cdc\n"+
+			"FN:2,answer\n"+
+			"FNDA:1,answer\n"+
+			"FNF:1\n"+
+			"FNH:1\n"+
+			"BRDA:4,0,0,0\n"+
+			"BRDA:4,0,1,1\n"+
+			"BRF:2\n"+
+			"BRH:1\n"+
+			"DA:3,1\n"+
+			"DA:4,1\n"+
+			"DA:5,0\n"+
+			"DA:7,1\n"+
+			"LF:4\n"+
+			"LH:3\n"+
+			"end_of_record\n",
+		builder.String(),
+	)
+
+	// Without a path mapper, locations are identified by their ID
+
+	lcov, err := report.MarshalLCOV()
+	require.NoError(t, err)
+	assert.Contains(t, string(lcov), "SF:A.0000000000000001.Foo\n")
+}
+
+func TestCoverageReportCobertura(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+
+	cobertura, err := report.MarshalCobertura()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.8" branch-rate="0.5" lines-covered="4" lines-valid="5" branches-covered="1" branches-valid="2" complexity="0" version="" timestamp="0">
+  <sources>
+    <source>.</source>
+  </sources>
+  <packages>
+    <package name="" line-rate="1" branch-rate="1" complexity="0">
+      <classes>
+        <class name="A.0000000000000001.Foo" filename="A.0000000000000001.Foo" line-rate="1" branch-rate="1" complexity="0">
+          <methods></methods>
+          <lines>
+            <line number="2" hits="2"></line>
+          </lines>
+        </class>
+      </classes>
+    </package>
+    <package name="scripts" line-rate="0.75" branch-rate="0.5" complexity="0">
+      <classes>
+        <class name="answer" filename="scripts/answer.cdc" line-rate="0.75" branch-rate="0.5" complexity="0">
+          <methods>
+            <method name="answer" signature="" line-rate="1" branch-rate="0" complexity="0">
+              <lines>
+                <line number="2" hits="1"></line>
+              </lines>
+            </method>
+          </methods>
+          <lines>
+            <line number="3" hits="1"></line>
+            <line number="4" hits="1" branch="true" condition-coverage="50% (1/2)"></line>
+            <line number="5" hits="0"></line>
+            <line number="7" hits="1"></line>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`,
+		string(cobertura),
+	)
+}
+
+func TestCoverageReportMerge(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+	other := newTestCoverageReport(t)
+
+	scriptLocation := common.StringLocation("scripts/answer.cdc")
+	otherLocation := common.StringLocation("scripts/other.cdc")
+
+	other.AddLineHit(otherLocation, 1)
+	other.AddBranchHit(scriptLocation, testCoverageIfStatement(t), 0)
+
+	report.Merge(other)
+
+	require.Len(t, report.Coverage, 3)
+
+	coverage := report.Coverage[scriptLocation]
+	require.NotNil(t, coverage)
+
+	assert.Equal(t,
+		map[int]int{
+			3: 2,
+			4: 2,
+			5: 0,
+			7: 2,
+		},
+		coverage.LineHits,
+	)
+	assert.Equal(t, 4, coverage.Statements)
+	assert.Equal(t,
+		[]*BranchCoverage{
+			{Kind: BranchKindIf, Line: 4, Column: 14, EndLine: 6, EndColumn: 14, Hits: []int{1, 2}},
+		},
+		coverage.Branches,
+	)
+	assert.Equal(t,
+		[]*FunctionCoverage{
+			{Name: "answer", Line: 2, Hits: 2},
+		},
+		coverage.Functions,
+	)
+
+	assert.Equal(t,
+		map[int]int{1: 1},
+		report.Coverage[otherLocation].LineHits,
+	)
+
+	// The merged report is not affected by hits recorded in the other report afterwards
+
+	other.AddLineHit(scriptLocation, 3)
+	assert.Equal(t, 2, coverage.LineHits[3])
+}
+
+func TestCoverageReportLocationFilter(t *testing.T) {
+
+	t.Parallel()
+
+	scriptLocation := common.StringLocation("scripts/answer.cdc")
+	contractLocation := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Foo",
+	}
+
+	t.Run("exclude", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := newTestCoverageReport(t)
+
+		report.ExcludeLocation(contractLocation)
+		report.ExcludeLocation(stdlib.TestContractLocation)
+
+		assert.False(t, report.IsLocationIncluded(contractLocation))
+		assert.False(t, report.IsLocationIncluded(stdlib.TestContractLocation))
+		assert.True(t, report.IsLocationIncluded(scriptLocation))
+
+		report.AddLineHit(contractLocation, 2)
+		report.AddLineHit(stdlib.TestContractLocation, 1)
+
+		require.Len(t, report.Coverage, 1)
+		assert.NotNil(t, report.Coverage[scriptLocation])
+	})
+
+	t.Run("include", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := newTestCoverageReport(t).
+			WithLocationFilter(func(location Location) bool {
+				_, ok := location.(common.AddressLocation)
+				return ok
+			})
+
+		report.AddLineHit(scriptLocation, 3)
+
+		require.Len(t, report.Coverage, 1)
+		assert.Equal(t,
+			map[int]int{2: 2},
+			report.Coverage[contractLocation].LineHits,
+		)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := NewCoverageReport()
+		report.ExcludeLocation(scriptLocation)
+
+		report.Merge(newTestCoverageReport(t))
+
+		require.Len(t, report.Coverage, 1)
+		assert.NotNil(t, report.Coverage[contractLocation])
+	})
+}
+
+func TestCoverageReportSummary(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+
+	summary := report.Summary()
+
+	scriptSummary := CoverageSummary{
+		Lines:            4,
+		CoveredLines:     3,
+		Branches:         2,
+		CoveredBranches:  1,
+		Functions:        1,
+		CoveredFunctions: 1,
+	}
+	contractSummary := CoverageSummary{
+		Lines:        1,
+		CoveredLines: 1,
+	}
+
+	assert.Equal(t,
+		map[common.Location]CoverageSummary{
+			common.StringLocation("scripts/answer.cdc"): scriptSummary,
+			common.AddressLocation{
+				Address: common.MustBytesToAddress([]byte{0x1}),
+				Name:    "Foo",
+			}: contractSummary,
+		},
+		summary.Locations,
+	)
+
+	assert.Equal(t,
+		CoverageSummary{
+			Lines:            5,
+			CoveredLines:     4,
+			Branches:         2,
+			CoveredBranches:  1,
+			Functions:        1,
+			CoveredFunctions: 1,
+		},
+		summary.Total,
+	)
+	assert.Equal(t, float64(80), summary.Total.LinePercentage())
+	assert.Equal(t, float64(50), summary.Total.BranchPercentage())
+	assert.Equal(t, float64(100), summary.Total.FunctionPercentage())
+
+	actual, err := json.Marshal(summary)
+	require.NoError(t, err)
+
+	require.JSONEq(t,
+		`
+        {
+          "locations": {
+            "S.scripts/answer.cdc": {
+              "lines": 4,
+              "covered_lines": 3,
+              "branches": 2,
+              "covered_branches": 1,
+              "functions": 1,
+              "covered_functions": 1
+            },
+            "A.0000000000000001.Foo": {
+              "lines": 1,
+              "covered_lines": 1,
+              "branches": 0,
+              "covered_branches": 0,
+              "functions": 0,
+              "covered_functions": 0
+            }
+          },
+          "total": {
+            "lines": 5,
+            "covered_lines": 4,
+            "branches": 2,
+            "covered_branches": 1,
+            "functions": 1,
+            "covered_functions": 1
+          }
+        }
+        `,
+		string(actual),
+	)
+}
+
+func TestCoverageReportJSON(t *testing.T) {
+
+	t.Parallel()
+
+	report := newTestCoverageReport(t)
+
+	transactionLocation := common.TransactionLocation{0x1}
+	report.AddLineHit(transactionLocation, 1)
+	report.AddLineHit(stdlib.TestContractLocation, 1)
+
+	encoded, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	decoded := NewCoverageReport()
+	err = json.Unmarshal(encoded, decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, report.Summary(), decoded.Summary())
+
+	for location, coverage := range report.Coverage { // nolint:maprangecheck
+		decodedCoverage := decoded.Coverage[location]
+		require.NotNil(t, decodedCoverage, location.String())
+		assert.Equal(t, coverage.LineHits, decodedCoverage.LineHits)
+		assert.Equal(t, coverage.Statements, decodedCoverage.Statements)
+		assert.Equal(t, coverage.Branches, decodedCoverage.Branches)
+		assert.Equal(t, coverage.Functions, decodedCoverage.Functions)
+	}
+
+	// Hits can be recorded for the decoded branches
+
+	scriptLocation := common.StringLocation("scripts/answer.cdc")
+	decoded.AddBranchHit(scriptLocation, testCoverageIfStatement(t), 0)
+	assert.Equal(t,
+		[]int{1, 1},
+		decoded.Coverage[scriptLocation].Branches[0].Hits,
+	)
+
+	t.Run("invalid location", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := json.Unmarshal(
+			[]byte(`{"coverage": {"A.xyz": {"line_hits": {}}}}`),
+			NewCoverageReport(),
+		)
+		require.Error(t, err)
+	})
+}
@@ -24,9 +24,13 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/sema"
+	. "github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func TestRuntimeDebugger(t *testing.T) {
@@ -206,3 +210,512 @@ func TestRuntimeDebuggerBreakpoints(t *testing.T) {
 
 	require.True(t, logged)
 }
+
+func TestRuntimeDebuggerStepping(t *testing.T) {
+
+	t.Parallel()
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+	location := nextTransactionLocation()
+
+	// Prepare the debugger
+
+	debugger := interpreter.NewDebugger()
+
+	// Add a breakpoint
+	debugger.AddBreakpoint(location, 9)
+
+	// Run the transaction.
+	// It will pause/block at the breakpoint,
+	// so run it in a goroutine
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		runtime := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled: true,
+			Debugger:               debugger,
+		})
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			log: func(message string) {},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  pub fun add(_ a: Int, _ b: Int): Int {
+                      let sum = a + b
+                      return sum
+                  }
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let answer = add(40, 2)
+                          let other = add(1, 2)
+                          log(answer)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+			},
+		)
+		require.NoError(t, err)
+	}()
+
+	stopLines := func(stop interpreter.Stop) []int {
+		lines := make([]int, 0, len(stop.CallStack))
+		for _, frame := range stop.CallStack {
+			require.Equal(t, location, frame.Location)
+			lines = append(lines, frame.Range.StartPos.Line)
+		}
+		return lines
+	}
+
+	// Wait for the transaction to run into the breakpoint
+	stop := <-debugger.Stops()
+	require.Equal(t, []int{9}, stopLines(stop))
+	require.Equal(t, "", stop.CallStack[0].FunctionName)
+
+	// Step into the invoked function
+	stop = debugger.StepIn()
+	require.Equal(t, []int{3, 9}, stopLines(stop))
+	require.Equal(t, "add", stop.CallStack[0].FunctionName)
+	require.Equal(t, "", stop.CallStack[1].FunctionName)
+
+	// Step over the statements of the invoked function
+	stop = debugger.StepOver()
+	require.Equal(t, []int{4, 9}, stopLines(stop))
+
+	// Step out of the invoked function
+	stop = debugger.StepOut()
+	require.Equal(t, []int{10}, stopLines(stop))
+
+	// Step over the next invocation
+	stop = debugger.StepOver()
+	require.Equal(t, []int{11}, stopLines(stop))
+
+	debugger.Continue()
+
+	// Wait for the transaction to finish execution
+	wg.Wait()
+}
+
+func TestRuntimeDebuggerConditionalBreakpoints(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      transaction {
+          prepare(signer: AuthAccount) {
+              var i = 0
+              while i < 10 {
+                  i = i + 1
+              }
+          }
+      }
+    `
+
+	// run runs the transaction with a breakpoint at line 6,
+	// and returns the value of `i` at each stop, and the logged messages
+
+	run := func(t *testing.T, options interpreter.BreakpointOptions) (values []string, logs []string) {
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+		location := nextTransactionLocation()
+
+		debugger := interpreter.NewDebugger()
+		debugger.SetExpressionParser(parser.ParseExpression)
+		debugger.SetLogHandler(func(message string) {
+			logs = append(logs, message)
+		})
+
+		_, err := debugger.AddBreakpointWithOptions(location, 6, options)
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			runtime := NewInterpreterRuntime(Config{
+				AtreeValidationEnabled: true,
+				Debugger:               debugger,
+			})
+
+			address := common.MustBytesToAddress([]byte{0x1})
+
+			runtimeInterface := &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getSigningAccounts: func() ([]Address, error) {
+					return []Address{address}, nil
+				},
+			}
+
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: []byte(code),
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  location,
+				},
+			)
+			require.NoError(t, err)
+		}()
+
+		for {
+			select {
+			case stop := <-debugger.Stops():
+				require.NotNil(t, stop.Breakpoint)
+
+				variable := debugger.CurrentActivation(stop.Interpreter).Find("i")
+				require.NotNil(t, variable)
+				values = append(values, variable.GetValue().String())
+
+				debugger.Continue()
+
+			case <-done:
+				return
+			}
+		}
+	}
+
+	t.Run("condition", func(t *testing.T) {
+		t.Parallel()
+
+		values, logs := run(t, interpreter.BreakpointOptions{
+			Condition: "i == 3",
+		})
+		require.Equal(t, []string{"3"}, values)
+		require.Empty(t, logs)
+	})
+
+	t.Run("hit condition", func(t *testing.T) {
+		t.Parallel()
+
+		hitCondition, err := interpreter.ParseHitCondition("% 4")
+		require.NoError(t, err)
+
+		values, _ := run(t, interpreter.BreakpointOptions{
+			HitCondition: hitCondition,
+		})
+		require.Equal(t, []string{"3", "7"}, values)
+	})
+
+	t.Run("condition and hit condition", func(t *testing.T) {
+		t.Parallel()
+
+		hitCondition, err := interpreter.ParseHitCondition(">= 2")
+		require.NoError(t, err)
+
+		values, _ := run(t, interpreter.BreakpointOptions{
+			Condition:    "i > 5",
+			HitCondition: hitCondition,
+		})
+		require.Equal(t, []string{"7", "8", "9"}, values)
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+		t.Parallel()
+
+		values, logs := run(t, interpreter.BreakpointOptions{
+			Condition: "i",
+		})
+		require.Len(t, values, 10)
+		require.Len(t, logs, 10)
+		require.Contains(t, logs[0], "failed to evaluate condition")
+	})
+
+	t.Run("logpoint", func(t *testing.T) {
+		t.Parallel()
+
+		values, logs := run(t, interpreter.BreakpointOptions{
+			Condition:  "i % 5 == 0",
+			LogMessage: `i is {i}, next is {i + 1}, \{literal\}`,
+		})
+		require.Empty(t, values)
+		require.Equal(t,
+			[]string{
+				"i is 0, next is 1, {literal}",
+				"i is 5, next is 6, {literal}",
+			},
+			logs,
+		)
+	})
+}
+
+func TestRuntimeDebuggerEvaluate(t *testing.T) {
+
+	t.Parallel()
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub struct Point {
+              pub let x: Int
+              priv let y: Int
+
+              init(x: Int, y: Int) {
+                  self.x = x
+                  self.y = y
+              }
+
+              pub fun sum(): Int {
+                  return self.x + self.y
+              }
+          }
+
+          pub struct Counter {
+              pub var count: Int
+
+              init() {
+                  self.count = 0
+              }
+
+              pub fun increment() {
+                  self.count = self.count + 1
+              }
+          }
+
+          pub resource R {}
+
+          pub event Incremented()
+
+          pub fun createR(): @R {
+              return <- create R()
+          }
+
+          pub fun newCounter(): Counter {
+              let counter = Counter()
+              counter.increment()
+              return counter
+          }
+
+          pub fun newNumbers(): [Int] {
+              let numbers: [Int] = []
+              numbers.append(1)
+              return numbers
+          }
+
+          pub fun appendNumber(_ numbers: &[Int]) {
+              numbers.append(4)
+          }
+
+          pub fun setNumber(_ numbers: &{String: Int}) {
+              numbers["one"] = 1
+          }
+
+          pub fun emitIncremented() {
+              emit Incremented()
+          }
+      }
+    `)
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	var accountCode []byte
+	var logs []string
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(_ Location) (bytes []byte, err error) {
+			return accountCode, nil
+		},
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		log: func(message string) {
+			logs = append(logs, message)
+		},
+	}
+
+	// Deploy the contract without the debugger
+
+	err := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled: true,
+	}).ExecuteTransaction(
+		Script{
+			Source: DeploymentTransaction("Test", contract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	// Prepare the debugger
+
+	location := nextTransactionLocation()
+
+	debugger := interpreter.NewDebugger()
+	debugger.SetExpressionParser(parser.ParseExpression)
+
+	// Add a breakpoint
+	debugger.AddBreakpoint(location, 21)
+
+	// Run the transaction.
+	// It will pause/block at the breakpoint,
+	// so run it in a goroutine
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	var transactionErr error
+
+	go func() {
+		defer wg.Done()
+
+		runtime := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled: true,
+			Debugger:               debugger,
+		})
+
+		transactionErr = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let point = Test.Point(x: 1, y: 2)
+                          let numbers = [1, 2, 3]
+                          let counter = Test.Counter()
+                          let names = {"zero": 0}
+                          var total = 0
+                          let add = fun (n: Int): Int {
+                              total = total + n
+                              return total
+                          }
+                          let double = fun (n: Int): Int {
+                              var doubled = n
+                              doubled = doubled * 2
+                              return doubled
+                          }
+                          let r <- Test.createR()
+                          log(point.sum())
+                          destroy r
+                          log(signer.borrow<&Int>(from: /storage/foo) == nil)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+			},
+		)
+	}()
+
+	// Wait for the transaction to run into the breakpoint
+	stop := <-debugger.Stops()
+
+	evaluate := func(code string) string {
+		value, err := debugger.Evaluate(stop, code)
+		require.NoError(t, err)
+		return value.String()
+	}
+
+	require.Equal(t, "4", evaluate("point.x + numbers[2]"))
+	require.Equal(t, "2", evaluate("point.y"))
+	require.Equal(t, "30", evaluate("point.sum() * 10"))
+	require.Equal(t, `"A.0000000000000001.Test.Point"`, evaluate("point.getType().identifier"))
+
+	// Unknown variables are rejected by the checker
+
+	_, err = debugger.Evaluate(stop, "unknown")
+	var checkerErr *sema.CheckerError
+	require.ErrorAs(t, err, &checkerErr)
+
+	// Resources may not be created, destroyed, or moved
+
+	for _, code := range []string{
+		"create Test.R()",
+		"Test.createR()",
+		"destroy r",
+	} {
+		_, err = debugger.Evaluate(stop, code)
+		require.ErrorAs(t, err, &interpreter.InvalidEvaluationError{})
+	}
+
+	_, err = debugger.Evaluate(stop, "r")
+	require.ErrorAs(t, err, &checkerErr)
+
+	// Storage may not be modified
+
+	_, err = debugger.Evaluate(stop, "signer.save(1, to: /storage/foo)")
+	require.ErrorAs(t, err, &interpreter.StorageMutatedDuringEvaluationError{})
+
+	// Existing values may not be modified
+
+	for _, code := range []string{
+		"counter.increment()",
+		"Test.appendNumber(&numbers as &[Int])",
+		"Test.setNumber(&names as &{String: Int})",
+	} {
+		_, err = debugger.Evaluate(stop, code)
+		require.ErrorAs(t, err, &interpreter.ValueMutatedDuringEvaluationError{})
+	}
+
+	require.Equal(t, "0", evaluate("counter.count"))
+	require.Equal(t, "3", evaluate("numbers.length"))
+	require.Equal(t, "1", evaluate("names.length"))
+
+	// Values created during the evaluation may be modified
+
+	require.Equal(t, "1", evaluate("Test.newCounter().count"))
+	require.Equal(t, "1", evaluate("Test.newNumbers().length"))
+
+	// Existing variables may not be assigned,
+	// e.g. variables captured by functions
+
+	_, err = debugger.Evaluate(stop, "add(1)")
+	var assignmentErr interpreter.VariableAssignedDuringEvaluationError
+	require.ErrorAs(t, err, &assignmentErr)
+	require.Equal(t, "total", assignmentErr.Name)
+
+	require.Equal(t, "0", evaluate("total"))
+
+	// Variables declared during the evaluation may be assigned
+
+	require.Equal(t, "4", evaluate("double(2)"))
+
+	// Events may not be emitted
+
+	_, err = debugger.Evaluate(stop, "Test.emitIncremented()")
+	require.ErrorAs(t, err, &interpreter.EventEmittedDuringEvaluationError{})
+
+	debugger.Continue()
+
+	// Wait for the transaction to finish execution
+	wg.Wait()
+
+	require.NoError(t, transactionErr)
+	require.Equal(t, []string{"3", "true"}, logs)
+}
@@ -139,12 +139,14 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		// and disable storage validation after each value modification.
 		// Instead, storage is validated after commits (if validation is enabled),
 		// see interpreterEnvironment.CommitStorage
-		AtreeStorageValidationEnabled: false,
-		Debugger:                      e.config.Debugger,
-		OnStatement:                   e.newOnStatementHandler(),
-		OnMeterComputation:            e.newOnMeterComputation(),
-		OnFunctionInvocation:          e.newOnFunctionInvocationHandler(),
-		OnInvokedFunctionReturn:       e.newOnInvokedFunctionReturnHandler(),
+		AtreeStorageValidationEnabled:   false,
+		Debugger:                        e.config.Debugger,
+		OnStatement:                     e.newOnStatementHandler(),
+		OnBranch:                        e.newOnBranchHandler(),
+		OnMeterComputation:              e.newOnMeterComputation(),
+		OnFunctionInvocation:            e.newOnFunctionInvocationHandler(),
+		OnInterpretedFunctionInvocation: e.newOnInterpretedFunctionInvocationHandler(),
+		OnInvokedFunctionReturn:         e.newOnInvokedFunctionReturnHandler(),
 	}
 }
 
@@ -563,6 +565,8 @@ func (e *interpreterEnvironment) newInterpreter(
 	program *interpreter.Program,
 ) (*interpreter.Interpreter, error) {
 
+	e.inspectProgramCoverage(location, program)
+
 	sharedState := e.runtimeInterface.GetInterpreterSharedState()
 	if sharedState != nil {
 		// NOTE: no need to reset storage, as each top-level entry call
@@ -591,6 +595,16 @@ func (e *interpreterEnvironment) newInterpreter(
 	return inter, nil
 }
 
+// inspectProgramCoverage records the statements of the given program in the coverage report,
+// if coverage reporting is enabled
+func (e *interpreterEnvironment) inspectProgramCoverage(location common.Location, program *interpreter.Program) {
+	if !e.config.CoverageReportingEnabled {
+		return
+	}
+
+	e.coverageReport.InspectProgram(location, program.Program)
+}
+
 func (e *interpreterEnvironment) newOnStatementHandler() interpreter.OnStatementFunc {
 	if !e.config.CoverageReportingEnabled {
 		return nil
@@ -603,6 +617,32 @@ func (e *interpreterEnvironment) newOnStatementHandler() interpreter.OnStatement
 	}
 }
 
+func (e *interpreterEnvironment) newOnBranchHandler() interpreter.OnBranchFunc {
+	if !e.config.CoverageReportingEnabled {
+		return nil
+	}
+
+	return func(inter *interpreter.Interpreter, element ast.Element, branch int) {
+		e.coverageReport.AddBranchHit(inter.Location, element, branch)
+	}
+}
+
+func (e *interpreterEnvironment) newOnInterpretedFunctionInvocationHandler() interpreter.OnInterpretedFunctionInvocationFunc {
+	if !e.config.CoverageReportingEnabled {
+		return nil
+	}
+
+	return func(_ *interpreter.Interpreter, function *interpreter.InterpretedFunctionValue) {
+		if function.ParameterList == nil {
+			return
+		}
+		e.coverageReport.AddFunctionHit(
+			function.Interpreter.Location,
+			function.ParameterList.StartPos,
+		)
+	}
+}
+
 func (e *interpreterEnvironment) newOnRecordTraceHandler() interpreter.OnRecordTraceFunc {
 	return func(
 		interpreter *interpreter.Interpreter,
@@ -797,6 +837,8 @@ func (e *interpreterEnvironment) newImportLocationHandler() interpreter.ImportLo
 				panic(err)
 			}
 
+			e.inspectProgramCoverage(location, program)
+
 			subInterpreter, err := inter.NewSubInterpreter(program, location)
 			if err != nil {
 				panic(err)
@@ -88,6 +88,20 @@ type HasPrefix interface {
 	Prefix() string
 }
 
+// HasSuggestedFixes is an interface for errors that can suggest fixes for the given code.
+//
+// The type parameter is the type of the text edits, i.e. ast.TextEdit,
+// as this package cannot depend on the AST.
+type HasSuggestedFixes[T any] interface {
+	SuggestedFixes(code string) []SuggestedFix[T]
+}
+
+// SuggestedFix is a fix for an error, which consists of one or more text edits
+type SuggestedFix[T any] struct {
+	Message   string
+	TextEdits []T
+}
+
 // MemoryError indicates a memory limit has reached and should end
 // the Cadence parsing, checking, or interpretation.
 type MemoryError struct {
@@ -31,6 +31,10 @@ type Config struct {
 	OnFunctionInvocation OnFunctionInvocationFunc
 	// OnInvokedFunctionReturn is triggered when an invoked function returned.
 	OnInvokedFunctionReturn OnInvokedFunctionReturnFunc
+	// OnBranch is triggered when a branch of a conditional element is taken.
+	OnBranch OnBranchFunc
+	// OnInterpretedFunctionInvocation is triggered when the body of an interpreted function is about to be executed.
+	OnInterpretedFunctionInvocation OnInterpretedFunctionInvocationFunc
 	// OnRecordTrace is triggered when a trace is recorded.
 	OnRecordTrace OnRecordTraceFunc
 	// OnResourceOwnerChange is triggered when the owner of a resource changes.
@@ -19,31 +19,122 @@
 package interpreter
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 
-	"github.com/bits-and-blooms/bitset"
-
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 type Stop struct {
 	Interpreter *Interpreter
 	Statement   ast.Statement
+	// CallStack contains the frames of the function invocations which lead to the statement.
+	// The first frame is the innermost frame, i.e. the one of the statement,
+	// the last frame is the one of the program's entry point
+	CallStack []StackFrame
+	// Breakpoint is the breakpoint which was hit,
+	// or nil if the program was paused or stepped
+	Breakpoint *Breakpoint
+}
+
+// StackFrame is a frame of the call stack.
+type StackFrame struct {
+	// FunctionName is the name of the function of the frame,
+	// or empty if the frame is the one of the program's entry point,
+	// e.g. a transaction or script
+	FunctionName string
+	// Location is the location of the program which contains the position of the frame
+	Location common.Location
+	// Range is the range of the frame's current position,
+	// i.e. the statement for the innermost frame,
+	// and the invocation of the next inner frame for all other frames
+	Range ast.Range
+}
+
+// debuggerInvocation is a function invocation in progress
+type debuggerInvocation struct {
+	functionName string
+	location     common.Location
+	rang         ast.Range
 }
 
+type stepMode uint8
+
+const (
+	stepModeNone stepMode = iota
+	// stepModeIn pauses at the next statement
+	stepModeIn
+	// stepModeOver pauses at the next statement which is not in a function invoked from the current one
+	stepModeOver
+	// stepModeOut pauses at the next statement after the current function returned
+	stepModeOut
+)
+
 type Debugger struct {
 	pauseRequested uint32
 	stops          chan Stop
 	continues      chan struct{}
-	breakpoints    map[common.Location]*bitset.BitSet
+	// breakpoints are the breakpoints by location and line
+	breakpoints      map[common.Location]map[uint]*Breakpoint
+	nextBreakpointID int
+	logHandler       func(message string)
+	parseExpression  ExpressionParser
+	// evaluating is set while an expression is evaluated,
+	// so that statements of invoked functions do not pause the program
+	evaluating bool
+	// invocations are the function invocations in progress,
+	// the last element is the innermost invocation
+	invocations []debuggerInvocation
+	// stepMode and stepDepth are only accessed while the program is paused
+	// or by the program itself, synchronized by the stops and continues channels
+	stepMode  stepMode
+	stepDepth int
+	// breakpointsLock guards breakpoints, nextBreakpointID, logHandler, and parseExpression,
+	// which may be modified while the program is running,
+	// e.g. by a debug adapter
+	breakpointsLock sync.RWMutex
 }
 
 func NewDebugger() *Debugger {
 	return &Debugger{
-		stops:       make(chan Stop),
-		continues:   make(chan struct{}),
-		breakpoints: map[common.Location]*bitset.BitSet{},
+		stops:            make(chan Stop),
+		continues:        make(chan struct{}),
+		breakpoints:      map[common.Location]map[uint]*Breakpoint{},
+		nextBreakpointID: 1,
+	}
+}
+
+// SetLogHandler sets the function which is called with the messages of logpoints,
+// and with errors which occur when evaluating breakpoint conditions.
+func (d *Debugger) SetLogHandler(handler func(message string)) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	d.logHandler = handler
+}
+
+// SetExpressionParser sets the function which is used to parse
+// breakpoint conditions, expressions in log messages, and evaluated expressions,
+// e.g. parser.ParseExpression.
+func (d *Debugger) SetExpressionParser(parseExpression ExpressionParser) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	d.parseExpression = parseExpression
+}
+
+func (d *Debugger) log(message string) {
+	d.breakpointsLock.RLock()
+	handler := d.logHandler
+	d.breakpointsLock.RUnlock()
+
+	if handler != nil {
+		handler(message)
 	}
 }
 
@@ -51,54 +142,305 @@ func (d *Debugger) Stops() <-chan Stop {
 	return d.stops
 }
 
-func (d *Debugger) AddBreakpoint(location common.Location, line uint) {
+// AddBreakpoint adds an unconditional breakpoint at the given line.
+func (d *Debugger) AddBreakpoint(location common.Location, line uint) *Breakpoint {
+	// Options without condition and log message are always valid
+	breakpoint, _ := d.AddBreakpointWithOptions(location, line, BreakpointOptions{})
+	return breakpoint
+}
+
+// AddBreakpointWithOptions adds a breakpoint at the given line,
+// which may have a condition, a hit condition, and a log message.
+// An existing breakpoint at the line is replaced.
+func (d *Debugger) AddBreakpointWithOptions(
+	location common.Location,
+	line uint,
+	options BreakpointOptions,
+) (*Breakpoint, error) {
+
+	err := d.validateBreakpointOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
 	breakpoints, ok := d.breakpoints[location]
 	if !ok {
-		breakpoints = bitset.New(1024)
+		breakpoints = map[uint]*Breakpoint{}
 		d.breakpoints[location] = breakpoints
 	}
-	breakpoints.Set(line)
+
+	breakpoint := &Breakpoint{
+		ID:                d.nextBreakpointID,
+		Location:          location,
+		Line:              line,
+		BreakpointOptions: options,
+	}
+	d.nextBreakpointID++
+
+	breakpoints[line] = breakpoint
+
+	return breakpoint, nil
 }
 
 func (d *Debugger) RemoveBreakpoint(location common.Location, line uint) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
 	breakpoints, ok := d.breakpoints[location]
 	if !ok {
 		return
 	}
-	breakpoints.Clear(line)
+	delete(breakpoints, line)
+}
+
+// RemoveBreakpointByID removes the breakpoint with the given ID.
+// It returns false if there is no such breakpoint.
+func (d *Debugger) RemoveBreakpointByID(id int) bool {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	for _, breakpoints := range d.breakpoints { //nolint:maprangecheck
+		for line, breakpoint := range breakpoints { //nolint:maprangecheck
+			if breakpoint.ID == id {
+				delete(breakpoints, line)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Breakpoints returns copies of all breakpoints, ordered by ID.
+func (d *Debugger) Breakpoints() []Breakpoint {
+	d.breakpointsLock.RLock()
+	defer d.breakpointsLock.RUnlock()
+
+	var result []Breakpoint
+
+	for _, breakpoints := range d.breakpoints { //nolint:maprangecheck
+		for _, breakpoint := range breakpoints { //nolint:maprangecheck
+			result = append(result, *breakpoint)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
 }
 
 func (d *Debugger) ClearBreakpoints() {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
 	for location := range d.breakpoints { //nolint:maprangecheck
 		delete(d.breakpoints, location)
 	}
 }
 
 func (d *Debugger) ClearBreakpointsForLocation(location common.Location) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
 	delete(d.breakpoints, location)
 }
 
 func (d *Debugger) onStatement(interpreter *Interpreter, statement ast.Statement) {
-	if !atomic.CompareAndSwapUint32(&d.pauseRequested, 1, 0) {
-		breakpoints, ok := d.breakpoints[interpreter.Location]
-		if !ok {
-			return
-		}
+	if d.evaluating {
+		return
+	}
+
+	var breakpoint *Breakpoint
 
-		startPosition := statement.StartPosition()
-		if !breakpoints.Test(uint(startPosition.Line)) {
+	if !atomic.CompareAndSwapUint32(&d.pauseRequested, 1, 0) &&
+		!d.stepCompleted() {
+
+		breakpoint = d.hitBreakpoint(interpreter, statement)
+		if breakpoint == nil {
 			return
 		}
 	}
 
+	d.stepMode = stepModeNone
+
 	d.stops <- Stop{
 		Interpreter: interpreter,
 		Statement:   statement,
+		CallStack:   d.callStack(interpreter, statement),
+		Breakpoint:  breakpoint,
 	}
 
 	<-d.continues
 }
 
+func (d *Debugger) onFunctionInvocation(
+	interpreter *Interpreter,
+	invocationExpression *ast.InvocationExpression,
+) {
+	d.invocations = append(
+		d.invocations,
+		debuggerInvocation{
+			functionName: invocationExpression.InvokedExpression.String(),
+			location:     interpreter.Location,
+			rang:         ast.NewUnmeteredRangeFromPositioned(invocationExpression),
+		},
+	)
+}
+
+func (d *Debugger) onInvokedFunctionReturn() {
+	lastIndex := len(d.invocations) - 1
+	if lastIndex < 0 {
+		return
+	}
+	d.invocations[lastIndex] = debuggerInvocation{}
+	d.invocations = d.invocations[:lastIndex]
+}
+
+// stepCompleted returns true if a step was requested,
+// and the program should be paused at the current depth
+func (d *Debugger) stepCompleted() bool {
+	depth := len(d.invocations)
+
+	switch d.stepMode {
+	case stepModeIn:
+		return true
+	case stepModeOver:
+		return depth <= d.stepDepth
+	case stepModeOut:
+		return depth < d.stepDepth
+	default:
+		return false
+	}
+}
+
+func (d *Debugger) callStack(interpreter *Interpreter, statement ast.Statement) []StackFrame {
+	count := len(d.invocations)
+
+	functionName := func(depth int) string {
+		if depth == 0 {
+			return ""
+		}
+		return d.invocations[depth-1].functionName
+	}
+
+	frames := make([]StackFrame, 0, count+1)
+
+	frames = append(
+		frames,
+		StackFrame{
+			FunctionName: functionName(count),
+			Location:     interpreter.Location,
+			Range:        ast.NewUnmeteredRangeFromPositioned(statement),
+		},
+	)
+
+	for depth := count - 1; depth >= 0; depth-- {
+		invocation := d.invocations[depth]
+		frames = append(
+			frames,
+			StackFrame{
+				FunctionName: functionName(depth),
+				Location:     invocation.location,
+				Range:        invocation.rang,
+			},
+		)
+	}
+
+	return frames
+}
+
+func (d *Debugger) breakpoint(location common.Location, statement ast.Statement) *Breakpoint {
+	d.breakpointsLock.RLock()
+	defer d.breakpointsLock.RUnlock()
+
+	breakpoints, ok := d.breakpoints[location]
+	if !ok {
+		return nil
+	}
+
+	startPosition := statement.StartPosition()
+	return breakpoints[uint(startPosition.Line)]
+}
+
+// hitBreakpoint returns a copy of the breakpoint at the given statement,
+// if there is one, its conditions are satisfied, and it is not a logpoint.
+// The messages of logpoints are logged.
+func (d *Debugger) hitBreakpoint(interpreter *Interpreter, statement ast.Statement) *Breakpoint {
+	breakpoint := d.breakpoint(interpreter.Location, statement)
+	if breakpoint == nil {
+		return nil
+	}
+
+	activation := d.CurrentActivation(interpreter)
+
+	if breakpoint.Condition != "" {
+		value, err := d.evaluate(interpreter, activation, breakpoint.Condition, sema.BoolType)
+		if err != nil {
+			// Stop, so the failing condition can be investigated
+			d.log(fmt.Sprintf("%s: failed to evaluate condition: %s", breakpoint, err))
+			result := *breakpoint
+			return &result
+		}
+
+		if value != BoolValue(true) {
+			return nil
+		}
+	}
+
+	d.breakpointsLock.Lock()
+	breakpoint.Hits++
+	result := *breakpoint
+	d.breakpointsLock.Unlock()
+
+	if result.HitCondition != nil && !result.HitCondition.Test(result.Hits) {
+		return nil
+	}
+
+	if result.IsLogpoint() {
+		d.log(d.logMessage(interpreter, activation, result.LogMessage))
+		return nil
+	}
+
+	return &result
+}
+
+// logMessage interpolates the expressions in the given log message
+func (d *Debugger) logMessage(interpreter *Interpreter, activation *VariableActivation, message string) string {
+	parts, err := parseLogMessage(message)
+	if err != nil {
+		return message
+	}
+
+	var builder strings.Builder
+
+	for _, part := range parts {
+		if !part.isExpression {
+			builder.WriteString(part.text)
+			continue
+		}
+
+		value, err := d.evaluate(interpreter, activation, part.text, nil)
+		if err != nil {
+			_, _ = fmt.Fprintf(&builder, "<error: %s>", err)
+			continue
+		}
+
+		// Print strings without quotes
+		if stringValue, ok := value.(*StringValue); ok {
+			builder.WriteString(stringValue.Str)
+		} else {
+			builder.WriteString(value.String())
+		}
+	}
+
+	return builder.String()
+}
+
 func (d *Debugger) RequestPause() {
 	atomic.StoreUint32(&d.pauseRequested, 1)
 }
@@ -112,8 +454,58 @@ func (d *Debugger) Pause() Stop {
 	return <-d.Stops()
 }
 
+// Next continues the program and pauses it at the next statement.
+// It is equivalent to StepIn.
 func (d *Debugger) Next() Stop {
-	d.RequestPause()
+	return d.StepIn()
+}
+
+func (d *Debugger) requestStep(mode stepMode) {
+	d.stepMode = mode
+	d.stepDepth = len(d.invocations)
+}
+
+// RequestStepIn requests the paused program to pause at the next statement,
+// which might be in a function invoked by the current statement.
+// The program must be paused, and must be continued afterwards.
+func (d *Debugger) RequestStepIn() {
+	d.requestStep(stepModeIn)
+}
+
+// RequestStepOver requests the paused program to pause at the next statement
+// which is not in a function invoked by the current statement.
+// The program must be paused, and must be continued afterwards.
+func (d *Debugger) RequestStepOver() {
+	d.requestStep(stepModeOver)
+}
+
+// RequestStepOut requests the paused program to pause at the next statement
+// after the current function returned.
+// The program must be paused, and must be continued afterwards.
+func (d *Debugger) RequestStepOut() {
+	d.requestStep(stepModeOut)
+}
+
+// StepIn continues the paused program and pauses it at the next statement,
+// which might be in a function invoked by the current statement.
+func (d *Debugger) StepIn() Stop {
+	d.RequestStepIn()
+	d.Continue()
+	return <-d.Stops()
+}
+
+// StepOver continues the paused program and pauses it at the next statement
+// which is not in a function invoked by the current statement.
+func (d *Debugger) StepOver() Stop {
+	d.RequestStepOver()
+	d.Continue()
+	return <-d.Stops()
+}
+
+// StepOut continues the paused program and pauses it at the next statement
+// after the current function returned.
+func (d *Debugger) StepOut() Stop {
+	d.RequestStepOut()
 	d.Continue()
 	return <-d.Stops()
 }
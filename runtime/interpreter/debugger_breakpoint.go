@@ -0,0 +1,235 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Breakpoint is a breakpoint at a line of a program.
+type Breakpoint struct {
+	ID       int
+	Location common.Location
+	Line     uint
+	BreakpointOptions
+	// Hits is the number of times the breakpoint was reached
+	// and its condition, if any, was satisfied
+	Hits uint
+}
+
+type BreakpointOptions struct {
+	// Condition is an optional expression of type Bool.
+	// It is evaluated in the scope of the statement at the breakpoint,
+	// and the breakpoint is only hit if it evaluates to true
+	Condition string
+	// HitCondition optionally restricts the hits at which the breakpoint stops
+	HitCondition *HitCondition
+	// LogMessage is an optional message which is logged instead of stopping.
+	// Expressions enclosed in curly braces are evaluated and interpolated,
+	// e.g. `balance is {vault.balance}`
+	LogMessage string
+}
+
+// IsLogpoint returns true if the breakpoint logs a message instead of stopping.
+func (b *Breakpoint) IsLogpoint() bool {
+	return b.LogMessage != ""
+}
+
+func (b *Breakpoint) String() string {
+	var builder strings.Builder
+
+	kind := "breakpoint"
+	if b.IsLogpoint() {
+		kind = "logpoint"
+	}
+
+	_, _ = fmt.Fprintf(&builder, "%s %d at %s:%d", kind, b.ID, b.Location, b.Line)
+
+	if b.HitCondition != nil {
+		_, _ = fmt.Fprintf(&builder, " hits %s", b.HitCondition)
+	}
+	if b.Condition != "" {
+		_, _ = fmt.Fprintf(&builder, " if %s", b.Condition)
+	}
+	if b.IsLogpoint() {
+		_, _ = fmt.Fprintf(&builder, " log %q", b.LogMessage)
+	}
+
+	return builder.String()
+}
+
+type HitConditionOperator string
+
+const (
+	HitConditionOperatorEqual        HitConditionOperator = "=="
+	HitConditionOperatorGreater      HitConditionOperator = ">"
+	HitConditionOperatorGreaterEqual HitConditionOperator = ">="
+	HitConditionOperatorLess         HitConditionOperator = "<"
+	HitConditionOperatorLessEqual    HitConditionOperator = "<="
+	HitConditionOperatorMultiple     HitConditionOperator = "%"
+)
+
+// hitConditionOperators are ordered so that operators which are a prefix of another operator come last
+var hitConditionOperators = []HitConditionOperator{
+	HitConditionOperatorEqual,
+	HitConditionOperatorGreaterEqual,
+	HitConditionOperatorLessEqual,
+	HitConditionOperatorGreater,
+	HitConditionOperatorLess,
+	HitConditionOperatorMultiple,
+}
+
+// HitCondition restricts the hits at which a breakpoint stops,
+// e.g. `>= 3` stops at the third hit and all following hits,
+// and `% 2` stops at every second hit.
+type HitCondition struct {
+	Operator HitConditionOperator
+	Count    uint
+}
+
+// ParseHitCondition parses a hit condition, e.g. `>= 3`.
+// A count without an operator, e.g. `3`, is equivalent to `== 3`.
+func ParseHitCondition(condition string) (*HitCondition, error) {
+	condition = strings.TrimSpace(condition)
+
+	operator := HitConditionOperatorEqual
+	for _, candidate := range hitConditionOperators {
+		if strings.HasPrefix(condition, string(candidate)) {
+			operator = candidate
+			condition = condition[len(candidate):]
+			break
+		}
+	}
+
+	count, err := strconv.ParseUint(strings.TrimSpace(condition), 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hit condition count: %w", err)
+	}
+
+	if operator == HitConditionOperatorMultiple && count == 0 {
+		return nil, fmt.Errorf("invalid hit condition count: must be greater than zero")
+	}
+
+	return &HitCondition{
+		Operator: operator,
+		Count:    uint(count),
+	}, nil
+}
+
+// Test returns true if the given number of hits satisfies the condition.
+func (c *HitCondition) Test(hits uint) bool {
+	switch c.Operator {
+	case HitConditionOperatorEqual:
+		return hits == c.Count
+	case HitConditionOperatorGreater:
+		return hits > c.Count
+	case HitConditionOperatorGreaterEqual:
+		return hits >= c.Count
+	case HitConditionOperatorLess:
+		return hits < c.Count
+	case HitConditionOperatorLessEqual:
+		return hits <= c.Count
+	case HitConditionOperatorMultiple:
+		return hits%c.Count == 0
+	default:
+		return false
+	}
+}
+
+func (c *HitCondition) String() string {
+	return fmt.Sprintf("%s %d", c.Operator, c.Count)
+}
+
+// logMessagePart is a part of a log message:
+// either literal text, or the code of an expression which is interpolated
+type logMessagePart struct {
+	text         string
+	isExpression bool
+}
+
+// parseLogMessage splits the given log message into literal text and interpolated expressions.
+// Expressions are enclosed in curly braces. Literal curly braces can be escaped with a backslash.
+func parseLogMessage(message string) ([]logMessagePart, error) {
+	var parts []logMessagePart
+	var current strings.Builder
+	inExpression := false
+
+	flush := func() {
+		if current.Len() > 0 || inExpression {
+			parts = append(parts, logMessagePart{
+				text:         current.String(),
+				isExpression: inExpression,
+			})
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		switch {
+		case c == '\\' && i+1 < len(message) && (message[i+1] == '{' || message[i+1] == '}'):
+			i++
+			current.WriteByte(message[i])
+
+		case c == '{' && !inExpression:
+			flush()
+			inExpression = true
+
+		case c == '}' && inExpression:
+			if strings.TrimSpace(current.String()) == "" {
+				return nil, fmt.Errorf("empty expression in log message at offset %d", i)
+			}
+			flush()
+			inExpression = false
+
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if inExpression {
+		return nil, fmt.Errorf("unterminated expression in log message")
+	}
+
+	flush()
+
+	return parts, nil
+}
+
+func (d *Debugger) validateBreakpointOptions(options BreakpointOptions) error {
+	if options.Condition != "" {
+		_, err := d.parse(options.Condition)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.LogMessage != "" {
+		_, err := parseLogMessage(options.LogMessage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
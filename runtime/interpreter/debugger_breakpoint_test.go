@@ -0,0 +1,92 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestParseHitCondition(t *testing.T) {
+
+	t.Parallel()
+
+	tests := map[string]HitCondition{
+		"3":    {Operator: HitConditionOperatorEqual, Count: 3},
+		"== 3": {Operator: HitConditionOperatorEqual, Count: 3},
+		">3":   {Operator: HitConditionOperatorGreater, Count: 3},
+		">= 3": {Operator: HitConditionOperatorGreaterEqual, Count: 3},
+		"< 3":  {Operator: HitConditionOperatorLess, Count: 3},
+		"<=3":  {Operator: HitConditionOperatorLessEqual, Count: 3},
+		" % 2": {Operator: HitConditionOperatorMultiple, Count: 2},
+	}
+
+	for input, expected := range tests { //nolint:maprangecheck
+		input := input
+		expected := expected
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := ParseHitCondition(input)
+			require.NoError(t, err)
+			assert.Equal(t, expected, *actual)
+		})
+	}
+
+	for _, input := range []string{"", ">", "% 0", "= 3", "-1"} {
+		input := input
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseHitCondition(input)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestHitConditionTest(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(condition string, expected []uint) {
+		hitCondition, err := ParseHitCondition(condition)
+		require.NoError(t, err)
+
+		var actual []uint
+		for hits := uint(1); hits <= 6; hits++ {
+			if hitCondition.Test(hits) {
+				actual = append(actual, hits)
+			}
+		}
+		assert.Equal(t, expected, actual, condition)
+	}
+
+	test("3", []uint{3})
+	test("> 3", []uint{4, 5, 6})
+	test(">= 3", []uint{3, 4, 5, 6})
+	test("< 3", []uint{1, 2})
+	test("<= 3", []uint{1, 2, 3})
+	test("% 2", []uint{2, 4, 6})
+}
@@ -0,0 +1,426 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/activations"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ExpressionParser parses the given code as an expression.
+//
+// The interpreter does not depend on the parser,
+// so the debugger must be provided a parser, i.e. parser.ParseExpression,
+// to evaluate expressions.
+type ExpressionParser func(input []byte, memoryGauge common.MemoryGauge) (ast.Expression, []error)
+
+// ExpressionParsingError is reported when an expression evaluated by the debugger cannot be parsed.
+type ExpressionParsingError struct {
+	Code   []byte
+	Errors []error
+}
+
+func (e ExpressionParsingError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("Parsing failed:\n")
+	printErr := pretty.NewErrorPrettyPrinter(&sb, false).
+		PrettyPrintError(e, nil, map[common.Location][]byte{nil: e.Code})
+	if printErr != nil {
+		panic(printErr)
+	}
+	return sb.String()
+}
+
+func (e ExpressionParsingError) ChildErrors() []error {
+	return e.Errors
+}
+
+// InvalidEvaluationError is reported when an expression cannot be evaluated by the debugger,
+// because it might have side effects.
+type InvalidEvaluationError struct {
+	Reason string
+	ast.Range
+}
+
+func (e InvalidEvaluationError) Error() string {
+	return fmt.Sprintf("cannot evaluate expression: %s", e.Reason)
+}
+
+// Evaluate evaluates the given expression in the scope of the statement the program is paused at.
+//
+// The expression is checked against the types of the values of the variables in scope,
+// and the types declared in the paused program.
+// Access control is not enforced, so private fields can be inspected.
+//
+// The expression must not have side effects:
+// Expressions which create, destroy, or move resources are rejected,
+// and functions invoked by the expression may not modify account storage,
+// modify values which existed before the evaluation, assign variables declared before the evaluation,
+// or emit events.
+func (d *Debugger) Evaluate(stop Stop, code string) (Value, error) {
+	return d.evaluate(
+		stop.Interpreter,
+		d.CurrentActivation(stop.Interpreter),
+		code,
+		nil,
+	)
+}
+
+// evaluate parses, checks, and evaluates the given expression code
+// in the scope of the given activation of the given interpreter.
+//
+// The expression is checked against the types of the values of the variables in scope.
+// Access control is not enforced, so private fields can be inspected.
+func (d *Debugger) evaluate(
+	inter *Interpreter,
+	activation *VariableActivation,
+	code string,
+	expectedType sema.Type,
+) (
+	value Value,
+	err error,
+) {
+	expression, err := d.parse(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.evaluateExpression(inter, activation, expression, expectedType)
+}
+
+// parse parses the given code as an expression, using the debugger's expression parser
+func (d *Debugger) parse(code string) (ast.Expression, error) {
+	d.breakpointsLock.RLock()
+	parseExpression := d.parseExpression
+	d.breakpointsLock.RUnlock()
+
+	if parseExpression == nil {
+		return nil, errors.NewDefaultUserError("cannot parse expression: debugger has no expression parser")
+	}
+
+	input := []byte(code)
+
+	expression, errs := parseExpression(input, nil)
+	if len(errs) > 0 {
+		return nil, ExpressionParsingError{
+			Code:   input,
+			Errors: errs,
+		}
+	}
+
+	return expression, nil
+}
+
+func (d *Debugger) evaluateExpression(
+	inter *Interpreter,
+	activation *VariableActivation,
+	expression ast.Expression,
+	expectedType sema.Type,
+) (
+	value Value,
+	err error,
+) {
+	err = checkEvaluatedExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(
+		nil,
+		inter.Location,
+		nil,
+		&sema.Config{
+			AccessCheckMode:     sema.AccessCheckModeNone,
+			BaseValueActivation: evaluationValueActivation(inter, activation),
+			BaseTypeActivation:  evaluationTypeActivation(inter),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ty := checker.VisitExpression(expression, expectedType)
+
+	checkerErr := checker.CheckerError()
+	if checkerErr != nil {
+		return nil, checkerErr
+	}
+
+	if ty.IsResourceType() {
+		return nil, InvalidEvaluationError{
+			Reason: "expression has resource type",
+			Range:  ast.NewUnmeteredRangeFromPositioned(expression),
+		}
+	}
+
+	// Evaluate the expression with an interpreter which uses the elaboration of the expression,
+	// but shares the state of the paused interpreter.
+	// The evaluating interpreter is not registered in the shared state,
+	// so the paused interpreter is still used for its location
+
+	evaluator := &Interpreter{
+		Program: &Program{
+			Elaboration: checker.Elaboration,
+		},
+		Location:    inter.Location,
+		SharedState: inter.SharedState,
+		statement:   inter.statement,
+	}
+	evaluator.activations = activations.NewActivations[*Variable](evaluator)
+	evaluator.activations.Push(activation)
+
+	// Statements of functions invoked by the expression must not pause the program,
+	// account storage and existing values must not be modified,
+	// and events must not be emitted
+
+	d.evaluating = true
+	sharedState := inter.SharedState
+	inDebuggerEvaluation := sharedState.inDebuggerEvaluation
+	debuggerEvaluationValues := sharedState.debuggerEvaluationValues
+	debuggerEvaluationVariables := sharedState.debuggerEvaluationVariables
+	sharedState.inDebuggerEvaluation = true
+	sharedState.debuggerEvaluationValues = map[atree.StorageID]struct{}{}
+	sharedState.debuggerEvaluationVariables = map[*Variable]struct{}{}
+
+	defer func() {
+		d.evaluating = false
+		sharedState.inDebuggerEvaluation = inDebuggerEvaluation
+		sharedState.debuggerEvaluationValues = debuggerEvaluationValues
+		sharedState.debuggerEvaluationVariables = debuggerEvaluationVariables
+	}()
+
+	defer evaluator.RecoverErrors(func(internalErr error) {
+		err = internalErr
+	})
+
+	return evaluator.evalExpression(expression), nil
+}
+
+// recordEvaluationValue records that the container value with the given storage ID
+// was created while the debugger evaluates an expression.
+// Such values may be mutated during the evaluation, e.g. by initializers.
+func (interpreter *Interpreter) recordEvaluationValue(storageID atree.StorageID) {
+	sharedState := interpreter.SharedState
+	if !sharedState.inDebuggerEvaluation {
+		return
+	}
+
+	sharedState.debuggerEvaluationValues[storageID] = struct{}{}
+}
+
+// checkEvaluationMutation rejects the mutation of the container value with the given storage ID
+// while the debugger evaluates an expression, unless the value was created during the evaluation
+func (interpreter *Interpreter) checkEvaluationMutation(storageID atree.StorageID, locationRange LocationRange) {
+	sharedState := interpreter.SharedState
+	if !sharedState.inDebuggerEvaluation {
+		return
+	}
+
+	if _, ok := sharedState.debuggerEvaluationValues[storageID]; ok {
+		return
+	}
+
+	panic(ValueMutatedDuringEvaluationError{
+		LocationRange: locationRange,
+	})
+}
+
+// recordEvaluationVariable records that the given variable
+// was declared while the debugger evaluates an expression.
+// Such variables may be assigned during the evaluation, e.g. local variables of invoked functions.
+func (interpreter *Interpreter) recordEvaluationVariable(variable *Variable) {
+	sharedState := interpreter.SharedState
+	if !sharedState.inDebuggerEvaluation {
+		return
+	}
+
+	sharedState.debuggerEvaluationVariables[variable] = struct{}{}
+}
+
+// checkEvaluationAssignment rejects the assignment of the given variable
+// while the debugger evaluates an expression, unless the variable was declared during the evaluation,
+// e.g. assignments to global variables or variables captured by closures are rejected
+func (interpreter *Interpreter) checkEvaluationAssignment(
+	variable *Variable,
+	name string,
+	locationRange LocationRange,
+) {
+	sharedState := interpreter.SharedState
+	if !sharedState.inDebuggerEvaluation {
+		return
+	}
+
+	if _, ok := sharedState.debuggerEvaluationVariables[variable]; ok {
+		return
+	}
+
+	panic(VariableAssignedDuringEvaluationError{
+		Name:          name,
+		LocationRange: locationRange,
+	})
+}
+
+// checkEvaluatedExpression rejects expressions which have side effects on resources
+func checkEvaluatedExpression(expression ast.Expression) (err error) {
+	ast.Inspect(expression, func(element ast.Element) bool {
+		if err != nil {
+			return false
+		}
+
+		var reason string
+
+		switch element := element.(type) {
+		case *ast.CreateExpression:
+			reason = "resources cannot be created"
+
+		case *ast.DestroyExpression:
+			reason = "resources cannot be destroyed"
+
+		case *ast.UnaryExpression:
+			if element.Operation == ast.OperationMove {
+				reason = "resources cannot be moved"
+			}
+		}
+
+		if reason != "" {
+			err = InvalidEvaluationError{
+				Reason: reason,
+				Range:  ast.NewUnmeteredRangeFromPositioned(element.(ast.Expression)),
+			}
+			return false
+		}
+
+		return true
+	})
+
+	return
+}
+
+// evaluationTypeActivation returns a checker activation which declares
+// the top-level composite and interface types of the given interpreter's program
+func evaluationTypeActivation(inter *Interpreter) *sema.VariableActivation {
+	typeActivation := sema.NewVariableActivation(sema.BaseTypeActivation)
+
+	if inter.Program == nil || inter.Program.Elaboration == nil {
+		return typeActivation
+	}
+
+	elaboration := inter.Program.Elaboration
+
+	for _, compositeType := range elaboration.CompositeTypes { //nolint:maprangecheck
+		if compositeType.Location != inter.Location || compositeType.GetContainerType() != nil {
+			continue
+		}
+
+		declareEvaluationType(
+			typeActivation,
+			compositeType.Identifier,
+			compositeType,
+			compositeType.Kind.DeclarationKind(false),
+		)
+	}
+
+	for _, interfaceType := range elaboration.InterfaceTypes { //nolint:maprangecheck
+		if interfaceType.Location != inter.Location || interfaceType.GetContainerType() != nil {
+			continue
+		}
+
+		declareEvaluationType(
+			typeActivation,
+			interfaceType.Identifier,
+			interfaceType,
+			interfaceType.CompositeKind.DeclarationKind(true),
+		)
+	}
+
+	return typeActivation
+}
+
+func declareEvaluationType(
+	typeActivation *sema.VariableActivation,
+	identifier string,
+	ty sema.Type,
+	declarationKind common.DeclarationKind,
+) {
+	typeActivation.Set(
+		identifier,
+		&sema.Variable{
+			Identifier:      identifier,
+			DeclarationKind: declarationKind,
+			Type:            ty,
+			Access:          ast.AccessPublic,
+			IsConstant:      true,
+		},
+	)
+}
+
+// evaluationValueActivation returns a checker activation which declares
+// all variables in the scope of the given activation,
+// with the types of their current values
+func evaluationValueActivation(
+	inter *Interpreter,
+	activation *VariableActivation,
+) *sema.VariableActivation {
+
+	valueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+
+	if activation == nil {
+		return valueActivation
+	}
+
+	activation.ForEach(func(name string, variable *Variable) {
+		// Keep the checker's declarations of base values, e.g. built-in functions
+
+		if sema.BaseValueActivation.Find(name) != nil {
+			return
+		}
+
+		value := variable.GetValue()
+		if value == nil {
+			return
+		}
+
+		semaType, err := inter.ConvertStaticToSemaType(value.StaticType(inter))
+		if err != nil || semaType == nil {
+			return
+		}
+
+		valueActivation.Set(
+			name,
+			&sema.Variable{
+				Identifier:      name,
+				DeclarationKind: common.DeclarationKindConstant,
+				Type:            semaType,
+				Access:          ast.AccessPublic,
+				IsConstant:      true,
+			},
+		)
+	})
+
+	return valueActivation
+}
@@ -1194,7 +1194,9 @@ func (d TypeDecoder) decodeCompositeStaticType() (StaticType, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// NOTE: the type arguments are only encoded for instantiations of generic composite types
+
+	if size != expectedLength && size != encodedCompositeStaticTypeWithoutTypeArgumentsLength {
 		return nil, errors.NewUnexpectedError(
 			"invalid composite static type encoding: expected [%d]any, got [%d]any",
 			expectedLength,
@@ -1220,7 +1222,36 @@ func (d TypeDecoder) decodeCompositeStaticType() (StaticType, error) {
 		return nil, err
 	}
 
-	return NewCompositeStaticTypeComputeTypeID(d.memoryGauge, location, qualifiedIdentifier), nil
+	staticType := NewCompositeStaticTypeComputeTypeID(d.memoryGauge, location, qualifiedIdentifier)
+
+	if size == encodedCompositeStaticTypeWithoutTypeArgumentsLength {
+		return staticType, nil
+	}
+
+	// Decode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+	typeArgumentCount, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, errors.NewUnexpectedError(
+				"invalid composite static type type arguments encoding: %s",
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	typeArguments := make([]StaticType, typeArgumentCount)
+	for i := 0; i < int(typeArgumentCount); i++ {
+		typeArguments[i], err = d.DecodeStaticType()
+		if err != nil {
+			return nil, errors.NewUnexpectedError(
+				"invalid composite static type type argument encoding: %w",
+				err,
+			)
+		}
+	}
+
+	return staticType.WithTypeArguments(typeArguments), nil
 }
 
 func (d TypeDecoder) decodeInterfaceStaticType() (InterfaceStaticType, error) {
@@ -1561,7 +1592,9 @@ func (d TypeDecoder) decodeCompositeTypeInfo() (atree.TypeInfo, error) {
 		return nil, err
 	}
 
-	if length != encodedCompositeTypeInfoLength {
+	if length != encodedCompositeTypeInfoLength &&
+		length != encodedCompositeTypeInfoWithoutTypeArgumentsLength {
+
 		return nil, errors.NewUnexpectedError(
 			"invalid composite type info: expected %d elements, got %d",
 			encodedCompositeTypeInfoLength, length,
@@ -1590,12 +1623,33 @@ func (d TypeDecoder) decodeCompositeTypeInfo() (atree.TypeInfo, error) {
 		)
 	}
 
-	return NewCompositeTypeInfo(
+	typeInfo := NewCompositeTypeInfo(
 		d.memoryGauge,
 		location,
 		qualifiedIdentifier,
 		common.CompositeKind(kind),
-	), nil
+	)
+
+	if length == encodedCompositeTypeInfoWithoutTypeArgumentsLength {
+		return typeInfo, nil
+	}
+
+	typeArgumentCount, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	typeArguments := make([]StaticType, typeArgumentCount)
+	for i := 0; i < int(typeArgumentCount); i++ {
+		typeArguments[i], err = d.DecodeStaticType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	typeInfo.typeArguments = typeArguments
+
+	return typeInfo, nil
 }
 
 func DecodeTypeInfo(decoder *cbor.StreamDecoder, memoryGauge common.MemoryGauge) (atree.TypeInfo, error) {
@@ -1070,12 +1070,17 @@ func (t OptionalStaticType) Encode(e *cbor.StreamEncoder) error {
 const (
 	// encodedCompositeStaticTypeLocationFieldKey            uint64 = 0
 	// encodedCompositeStaticTypeQualifiedIdentifierFieldKey uint64 = 1
+	// encodedCompositeStaticTypeTypeArgumentsFieldKey       uint64 = 2
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCompositeStaticTypeLength MUST be updated when new element is added.
 	// It is used to verify encoded composite static type length during decoding.
-	encodedCompositeStaticTypeLength = 2
+	encodedCompositeStaticTypeLength = 3
+
+	// encodedCompositeStaticTypeWithoutTypeArgumentsLength is the length of
+	// the encoding of composite static types which have no type arguments
+	encodedCompositeStaticTypeWithoutTypeArgumentsLength = 2
 )
 
 // Encode encodes CompositeStaticType as
@@ -1085,20 +1090,34 @@ const (
 //				Content: cborArray{
 //					encodedCompositeStaticTypeLocationFieldKey:            Location(v.Location),
 //					encodedCompositeStaticTypeQualifiedIdentifierFieldKey: string(v.QualifiedIdentifier),
+//					encodedCompositeStaticTypeTypeArgumentsFieldKey:       []StaticType(v.TypeArguments()),
 //			},
 //	}
+//
+// The type arguments are only encoded for instantiations of generic composite types,
+// so the encoding of all other composite static types is unchanged.
 func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
+	typeArguments := t.TypeArguments()
+
+	length := encodedCompositeStaticTypeWithoutTypeArgumentsLength
+	if len(typeArguments) > 0 {
+		length = encodedCompositeStaticTypeLength
+	}
+
 	// Encode tag number and array head
 	err := e.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCompositeStaticType,
-		// array, 2 items follow
-		0x82,
 	})
 	if err != nil {
 		return err
 	}
 
+	err = e.EncodeArrayHead(uint64(length))
+	if err != nil {
+		return err
+	}
+
 	// Encode location at array index encodedCompositeStaticTypeLocationFieldKey
 	err = encodeLocation(e, t.Location)
 	if err != nil {
@@ -1106,7 +1125,29 @@ func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
 	}
 
 	// Encode qualified identifier at array index encodedCompositeStaticTypeQualifiedIdentifierFieldKey
-	return e.EncodeString(t.QualifiedIdentifier)
+	err = e.EncodeString(t.QualifiedIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if len(typeArguments) == 0 {
+		return nil
+	}
+
+	// Encode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+	err = e.EncodeArrayHead(uint64(len(typeArguments)))
+	if err != nil {
+		return err
+	}
+
+	for _, typeArgument := range typeArguments {
+		err = EncodeStaticType(e, typeArgument)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -1372,6 +1413,8 @@ type compositeTypeInfo struct {
 	location            common.Location
 	qualifiedIdentifier string
 	kind                common.CompositeKind
+	// typeArguments are the type arguments of a value of a generic composite type, if any
+	typeArguments []StaticType
 }
 
 func NewCompositeTypeInfo(
@@ -1391,19 +1434,34 @@ func NewCompositeTypeInfo(
 
 var _ atree.TypeInfo = compositeTypeInfo{}
 
-const encodedCompositeTypeInfoLength = 3
+const (
+	encodedCompositeTypeInfoLength = 4
+
+	// encodedCompositeTypeInfoWithoutTypeArgumentsLength is the length of
+	// the encoded type info of a value of a non-generic composite type,
+	// which does not include the type arguments
+	encodedCompositeTypeInfoWithoutTypeArgumentsLength = 3
+)
 
 func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
+	length := encodedCompositeTypeInfoWithoutTypeArgumentsLength
+	if len(c.typeArguments) > 0 {
+		length = encodedCompositeTypeInfoLength
+	}
+
 	err := e.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCompositeValue,
-		// array, 3 items follow
-		0x83,
 	})
 	if err != nil {
 		return err
 	}
 
+	err = e.EncodeArrayHead(uint64(length))
+	if err != nil {
+		return err
+	}
+
 	err = encodeLocation(e, c.location)
 	if err != nil {
 		return err
@@ -1419,15 +1477,43 @@ func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
 		return err
 	}
 
+	if len(c.typeArguments) == 0 {
+		return nil
+	}
+
+	err = e.EncodeArrayHead(uint64(len(c.typeArguments)))
+	if err != nil {
+		return err
+	}
+
+	for _, typeArgument := range c.typeArguments {
+		err = EncodeStaticType(e, typeArgument)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (c compositeTypeInfo) Equal(o atree.TypeInfo) bool {
 	other, ok := o.(compositeTypeInfo)
-	return ok &&
-		c.location == other.location &&
-		c.qualifiedIdentifier == other.qualifiedIdentifier &&
-		c.kind == other.kind
+	if !ok ||
+		c.location != other.location ||
+		c.qualifiedIdentifier != other.qualifiedIdentifier ||
+		c.kind != other.kind ||
+		len(c.typeArguments) != len(other.typeArguments) {
+
+		return false
+	}
+
+	for i, typeArgument := range c.typeArguments {
+		if !typeArgument.Equal(other.typeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // EmptyTypeInfo
@@ -3847,6 +3847,45 @@ func TestEncodeDecodeStaticType(t *testing.T) {
 
 		require.Equal(t, ty, actualType)
 	})
+
+	t.Run("composite, struct, type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := NewCompositeStaticTypeComputeTypeID(nil, nil, "Box").
+			WithTypeArguments([]StaticType{
+				PrimitiveStaticTypeBool,
+			})
+
+		encoded := cbor.RawMessage{
+			// tag
+			0xd8, CBORTagCompositeStaticType,
+			// array, 3 items follow
+			0x83,
+			// location: nil
+			0xf6,
+			// UTF-8 string, length 3
+			0x63,
+			// Box
+			0x42, 0x6f, 0x78,
+			// array, 1 items follow
+			0x81,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// positive integer 6
+			0x6,
+		}
+
+		actualEncoded, err := StaticTypeToBytes(ty)
+		require.NoError(t, err)
+
+		AssertEqualWithDiff(t, encoded, actualEncoded)
+
+		actualType, err := staticTypeFromBytes(encoded)
+		require.NoError(t, err)
+
+		require.Equal(t, ty, actualType)
+	})
 }
 
 func TestCBORTagValue(t *testing.T) {
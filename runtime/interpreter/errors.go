@@ -244,7 +244,9 @@ func (e DereferenceError) Error() string {
 
 // OverflowError
 
-type OverflowError struct{}
+type OverflowError struct {
+	LocationRange
+}
 
 var _ errors.UserError = OverflowError{}
 
@@ -820,6 +822,60 @@ func (StorageMutatedDuringIterationError) Error() string {
 	return "storage iteration continued after modifying storage"
 }
 
+// StorageMutatedDuringEvaluationError
+type StorageMutatedDuringEvaluationError struct{}
+
+var _ errors.UserError = StorageMutatedDuringEvaluationError{}
+
+func (StorageMutatedDuringEvaluationError) IsUserError() {}
+
+func (StorageMutatedDuringEvaluationError) Error() string {
+	return "cannot modify storage while evaluating an expression in the debugger"
+}
+
+// ValueMutatedDuringEvaluationError
+type ValueMutatedDuringEvaluationError struct {
+	LocationRange
+}
+
+var _ errors.UserError = ValueMutatedDuringEvaluationError{}
+
+func (ValueMutatedDuringEvaluationError) IsUserError() {}
+
+func (ValueMutatedDuringEvaluationError) Error() string {
+	return "cannot modify an existing value while evaluating an expression in the debugger"
+}
+
+// VariableAssignedDuringEvaluationError
+type VariableAssignedDuringEvaluationError struct {
+	Name string
+	LocationRange
+}
+
+var _ errors.UserError = VariableAssignedDuringEvaluationError{}
+
+func (VariableAssignedDuringEvaluationError) IsUserError() {}
+
+func (e VariableAssignedDuringEvaluationError) Error() string {
+	return fmt.Sprintf(
+		"cannot assign to existing variable `%s` while evaluating an expression in the debugger",
+		e.Name,
+	)
+}
+
+// EventEmittedDuringEvaluationError
+type EventEmittedDuringEvaluationError struct {
+	LocationRange
+}
+
+var _ errors.UserError = EventEmittedDuringEvaluationError{}
+
+func (EventEmittedDuringEvaluationError) IsUserError() {}
+
+func (EventEmittedDuringEvaluationError) Error() string {
+	return "cannot emit events while evaluating an expression in the debugger"
+}
+
 // InvalidHexByteError
 type InvalidHexByteError struct {
 	Byte byte
@@ -846,3 +902,22 @@ func (InvalidHexLengthError) IsUserError() {}
 func (InvalidHexLengthError) Error() string {
 	return "hex string has non-even length"
 }
+
+// DuplicateAttachmentError
+type DuplicateAttachmentError struct {
+	AttachmentType common.TypeID
+	Value          *CompositeValue
+	LocationRange
+}
+
+var _ errors.UserError = DuplicateAttachmentError{}
+
+func (DuplicateAttachmentError) IsUserError() {}
+
+func (e DuplicateAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"cannot attach `%s` to `%s`: an attachment of this type already exists",
+		e.AttachmentType,
+		e.Value.TypeID(),
+	)
+}
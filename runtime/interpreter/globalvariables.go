@@ -44,3 +44,11 @@ func (g *GlobalVariables) Set(name string, variable *Variable) {
 	}
 	g.variables[name] = variable
 }
+
+// ForEach calls the given function for each global variable.
+// The iteration order is undefined.
+func (g *GlobalVariables) ForEach(f func(name string, variable *Variable)) {
+	for name, variable := range g.variables { //nolint:maprangecheck
+		f(name, variable)
+	}
+}
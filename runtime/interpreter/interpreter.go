@@ -87,6 +87,27 @@ type OnFunctionInvocationFunc func(inter *Interpreter)
 // OnInvokedFunctionReturnFunc is a function that is triggered when an invoked function returned.
 type OnInvokedFunctionReturnFunc func(inter *Interpreter)
 
+// OnBranchFunc is a function that is triggered when a branch of a conditional element is taken,
+// i.e. of an if-statement, a switch-statement, a conditional expression, or a nil-coalescing expression.
+//
+// The branch is the index of the taken branch:
+// For if-statements and conditional expressions, 0 is the then-branch and 1 is the else-branch.
+// For switch-statements, it is the index of the executed case,
+// or the number of cases if no case was executed.
+// For nil-coalescing expressions, 0 is the left-hand side and 1 is the right-hand side.
+type OnBranchFunc func(
+	inter *Interpreter,
+	element ast.Element,
+	branch int,
+)
+
+// OnInterpretedFunctionInvocationFunc is a function that is triggered
+// when the body of an interpreted function is about to be executed.
+type OnInterpretedFunctionInvocationFunc func(
+	inter *Interpreter,
+	function *InterpretedFunctionValue,
+)
+
 // OnRecordTraceFunc is a function that records a trace.
 type OnRecordTraceFunc func(
 	inter *Interpreter,
@@ -468,6 +489,18 @@ func (interpreter *Interpreter) InvokeTransaction(index int, arguments ...Value)
 	return err
 }
 
+// errorHasPosition returns true if the given error has position information.
+// Errors which embed a LocationRange may have been created without one,
+// e.g. an OverflowError produced by an arithmetic operation.
+func errorHasPosition(err error) bool {
+	if locatedErr, ok := err.(interface{ locationRange() LocationRange }); ok {
+		return locatedErr.locationRange().HasPosition != nil
+	}
+
+	_, ok := err.(ast.HasPosition)
+	return ok
+}
+
 func (interpreter *Interpreter) RecoverErrors(onError func(error)) {
 	if r := recover(); r != nil {
 		var err error
@@ -490,8 +523,7 @@ func (interpreter *Interpreter) RecoverErrors(onError func(error)) {
 
 			// wrap the error with position information if needed
 
-			_, ok := err.(ast.HasPosition)
-			if !ok && interpreter.statement != nil {
+			if !errorHasPosition(err) && interpreter.statement != nil {
 				r := ast.NewUnmeteredRangeFromPositioned(interpreter.statement)
 
 				err = PositionedError{
@@ -627,6 +659,9 @@ func (interpreter *Interpreter) VisitFunctionDeclaration(declaration *ast.Functi
 
 	functionType := interpreter.Program.Elaboration.FunctionDeclarationFunctionTypes[declaration]
 
+	// NOTE: the function type might contain generic types of the enclosing function(s)
+	functionType = interpreter.resolveGenericType(functionType).(*sema.FunctionType)
+
 	// NOTE: find *or* declare, as the function might have not been pre-declared (e.g. in the REPL)
 	variable := interpreter.findOrDeclareVariable(identifier)
 
@@ -728,6 +763,7 @@ func (interpreter *Interpreter) visitFunctionBody(
 	if returnType != sema.VoidType {
 		var resultValue Value
 		if returnType.IsResourceType() {
+			returnType = interpreter.resolveGenericType(returnType)
 			resultValue = NewEphemeralReferenceValue(interpreter, false, returnValue, returnType)
 		} else {
 			resultValue = returnValue
@@ -784,6 +820,7 @@ func (interpreter *Interpreter) declareVariable(identifier string, value Value)
 	// NOTE: semantic analysis already checked possible invalid redeclaration
 	variable := NewVariableWithValue(interpreter, value)
 	interpreter.setVariable(identifier, variable)
+	interpreter.recordEvaluationVariable(variable)
 
 	// TODO: add proper location info
 	interpreter.startResourceTracking(value, variable, identifier, nil)
@@ -985,6 +1022,26 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 
 	functions := interpreter.compositeFunctions(declaration, lexicalScope)
 
+	// The functions of a generic composite type need the type arguments
+	// of the composite value they are invoked on
+
+	typeParameters := compositeType.TypeParameters()
+
+	if len(typeParameters) > 0 {
+		typeArgumentsWrapper := interpreter.compositeTypeArgumentsFunctionWrapper(typeParameters)
+
+		initializerFunction = typeArgumentsWrapper(initializerFunction)
+		destructorFunction = typeArgumentsWrapper(destructorFunction)
+
+		// Iterating over the map in a non-deterministic way is OK,
+		// we only apply the function wrapper to each function,
+		// the order does not matter.
+
+		for name, function := range functions { //nolint:maprangecheck
+			functions[name] = typeArgumentsWrapper(function)
+		}
+	}
+
 	wrapFunctions := func(code WrapperCode) {
 
 		// Wrap initializer
@@ -1126,12 +1183,21 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 					)
 				}
 
-				value := NewCompositeValue(
+				var typeArguments []StaticType
+				if len(typeParameters) > 0 {
+					typeArguments = interpreter.compositeStaticTypeArguments(
+						typeParameters,
+						invocation.TypeParameterTypes,
+					)
+				}
+
+				value := newCompositeValue(
 					interpreter,
 					locationRange,
 					location,
 					qualifiedIdentifier,
 					declaration.CompositeKind,
+					typeArguments,
 					fields,
 					address,
 				)
@@ -1548,6 +1614,12 @@ func (interpreter *Interpreter) transferAndConvert(
 	locationRange LocationRange,
 ) Value {
 
+	// NOTE: the types might contain generic types,
+	// resolve them to the type arguments in the current scope
+
+	valueType = interpreter.resolveGenericType(valueType)
+	targetType = interpreter.resolveGenericType(targetType)
+
 	transferredValue := value.Transfer(
 		interpreter,
 		locationRange,
@@ -1556,7 +1628,7 @@ func (interpreter *Interpreter) transferAndConvert(
 		nil,
 	)
 
-	result := interpreter.ConvertAndBox(
+	result := interpreter.convertAndBox(
 		locationRange,
 		transferredValue,
 		valueType,
@@ -1586,6 +1658,18 @@ func (interpreter *Interpreter) ConvertAndBox(
 	locationRange LocationRange,
 	value Value,
 	valueType, targetType sema.Type,
+) Value {
+	valueType = interpreter.resolveGenericType(valueType)
+	targetType = interpreter.resolveGenericType(targetType)
+
+	return interpreter.convertAndBox(locationRange, value, valueType, targetType)
+}
+
+// convertAndBox is ConvertAndBox for types which are already resolved, see resolveGenericType
+func (interpreter *Interpreter) convertAndBox(
+	locationRange LocationRange,
+	value Value,
+	valueType, targetType sema.Type,
 ) Value {
 	value = interpreter.convert(value, valueType, targetType)
 	return interpreter.BoxOptional(locationRange, value, targetType)
@@ -1937,7 +2021,7 @@ func (interpreter *Interpreter) functionConditionsWrapper(
 				}
 
 				if invocation.Self != nil {
-					interpreter.declareVariable(sema.SelfIdentifier, *invocation.Self)
+					interpreter.declareSelf(*invocation.Self)
 				}
 
 				// NOTE: The `inner` function might be nil.
@@ -2142,6 +2226,10 @@ func (interpreter *Interpreter) WriteStored(
 	identifier string,
 	value Value,
 ) {
+	if interpreter.SharedState.inDebuggerEvaluation {
+		panic(StorageMutatedDuringEvaluationError{})
+	}
+
 	config := interpreter.SharedState.Config
 	accountStorage := config.Storage.GetStorageMap(storageAddress, domain, true)
 	accountStorage.WriteValue(interpreter, identifier, value)
@@ -3058,6 +3146,7 @@ var typeFunction = NewUnmeteredHostFunctionValue(
 		return NewTypeValue(invocation.Interpreter, staticType)
 	},
 	&sema.FunctionType{
+		Purity:               sema.FunctionPurityView,
 		ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.MetaType),
 	},
 )
@@ -3964,6 +4053,24 @@ func (interpreter *Interpreter) reportFunctionInvocation() {
 	}
 }
 
+func (interpreter *Interpreter) reportBranch(element ast.Element, branch int) {
+	onBranch := interpreter.SharedState.Config.OnBranch
+	if onBranch == nil {
+		return
+	}
+
+	onBranch(interpreter, element, branch)
+}
+
+func (interpreter *Interpreter) reportInterpretedFunctionInvocation(function *InterpretedFunctionValue) {
+	onInterpretedFunctionInvocation := interpreter.SharedState.Config.OnInterpretedFunctionInvocation
+	if onInterpretedFunctionInvocation == nil {
+		return
+	}
+
+	onInterpretedFunctionInvocation(interpreter, function)
+}
+
 func (interpreter *Interpreter) reportInvokedFunctionReturn() {
 	config := interpreter.SharedState.Config
 
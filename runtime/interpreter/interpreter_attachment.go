@@ -0,0 +1,230 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"strings"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// attachmentFieldNamePrefix is the prefix of the names of the fields
+// in which the attachments of a composite value are stored.
+//
+// Attachments are stored in the composite value they are attached to,
+// under the type ID of the attachment type
+const attachmentFieldNamePrefix = "$attachment:"
+
+// attachmentFieldName returns the name of the field
+// in which an attachment of the given type is stored
+func attachmentFieldName(typeID common.TypeID) string {
+	return attachmentFieldNamePrefix + string(typeID)
+}
+
+// isAttachmentFieldName returns true if the given field name
+// is the name of a field which holds an attachment, see attachmentFieldName
+func isAttachmentFieldName(name string) bool {
+	return strings.HasPrefix(name, attachmentFieldNamePrefix)
+}
+
+// GetAttachment returns the attachment of the given type,
+// or nil if the composite value has no such attachment
+func (v *CompositeValue) GetAttachment(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	typeID common.TypeID,
+) *CompositeValue {
+	value := v.GetField(interpreter, locationRange, attachmentFieldName(typeID))
+	if value == nil {
+		return nil
+	}
+
+	attachment, ok := value.(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	attachment.base = v
+
+	return attachment
+}
+
+// SetAttachment attaches the given attachment to the composite value.
+// A composite value can have at most one attachment of each type
+func (v *CompositeValue) SetAttachment(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	attachment *CompositeValue,
+) {
+	typeID := attachment.TypeID()
+
+	if v.GetAttachment(interpreter, locationRange, typeID) != nil {
+		panic(DuplicateAttachmentError{
+			AttachmentType: typeID,
+			Value:          v,
+			LocationRange:  locationRange,
+		})
+	}
+
+	v.SetMember(
+		interpreter,
+		locationRange,
+		attachmentFieldName(typeID),
+		attachment,
+	)
+}
+
+// RemoveAttachment removes the attachment of the given type from the composite value,
+// and returns it, or nil if the composite value has no such attachment
+func (v *CompositeValue) RemoveAttachment(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	typeID common.TypeID,
+) *CompositeValue {
+	value := v.RemoveMember(interpreter, locationRange, attachmentFieldName(typeID))
+	if value == nil {
+		return nil
+	}
+
+	attachment, ok := value.(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	attachment.base = v
+
+	return attachment
+}
+
+// attachments returns all attachments of the composite value
+func (v *CompositeValue) attachments(interpreter *Interpreter) []*CompositeValue {
+	var attachments []*CompositeValue
+
+	err := v.dictionary.Iterate(func(key atree.Value, value atree.Value) (resume bool, err error) {
+		if !isAttachmentFieldName(string(key.(StringAtreeValue))) {
+			return true, nil
+		}
+
+		attachment, ok := MustConvertStoredValue(interpreter, value).(*CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		attachment.base = v
+
+		attachments = append(attachments, attachment)
+
+		return true, nil
+	})
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	return attachments
+}
+
+// fieldAndAttachmentCount returns the number of fields
+// and the number of attachments of the composite value,
+// which are both stored in the dictionary of the composite value
+func (v *CompositeValue) fieldAndAttachmentCount() (fieldCount int, attachmentCount int) {
+	err := v.dictionary.IterateKeys(func(key atree.Value) (resume bool, err error) {
+		if isAttachmentFieldName(string(key.(StringAtreeValue))) {
+			attachmentCount++
+		} else {
+			fieldCount++
+		}
+		return true, nil
+	})
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	return
+}
+
+// attachmentBaseValue returns the composite value which the given value is or refers to.
+// Attachments can be accessed through composite values and references to them
+func (interpreter *Interpreter) attachmentBaseValue(value Value, locationRange LocationRange) *CompositeValue {
+	switch value := value.(type) {
+	case *CompositeValue:
+		return value
+
+	case *EphemeralReferenceValue:
+		referenced := value.ReferencedValue(interpreter, locationRange)
+		if referenced == nil {
+			panic(DereferenceError{
+				LocationRange: locationRange,
+			})
+		}
+
+		interpreter.checkReferencedResourceNotDestroyed(*referenced, locationRange)
+
+		return interpreter.attachmentBaseValue(*referenced, locationRange)
+
+	case *StorageReferenceValue:
+		referenced, err := value.dereference(interpreter, locationRange)
+		if err != nil {
+			panic(err)
+		}
+		if referenced == nil {
+			panic(DereferenceError{
+				LocationRange: locationRange,
+			})
+		}
+
+		return interpreter.attachmentBaseValue(*referenced, locationRange)
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+// declareSelf declares `self`, and if `self` is an attachment,
+// also declares `base`, a reference to the value it is attached to
+func (interpreter *Interpreter) declareSelf(self MemberAccessibleValue) {
+	interpreter.declareVariable(sema.SelfIdentifier, self)
+
+	attachment, ok := self.(*CompositeValue)
+	if !ok ||
+		attachment.Kind != common.CompositeKindAttachment ||
+		attachment.base == nil {
+
+		return
+	}
+
+	attachmentType, ok := interpreter.MustConvertStaticToSemaType(
+		attachment.StaticType(interpreter),
+	).(*sema.CompositeType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	base := NewEphemeralReferenceValue(
+		interpreter,
+		false,
+		attachment.base,
+		attachmentType.AttachmentBaseType,
+	)
+
+	interpreter.declareVariable(sema.BaseIdentifier, base)
+}
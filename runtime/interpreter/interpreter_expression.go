@@ -20,8 +20,11 @@ package interpreter
 
 import (
 	"math/big"
+	"strings"
 	"time"
 
+	"github.com/onflow/atree"
+
 	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -67,6 +70,14 @@ func (interpreter *Interpreter) identifierExpressionGetterSetter(identifierExpre
 			return value
 		},
 		set: func(value Value) {
+			interpreter.checkEvaluationAssignment(
+				variable,
+				identifier,
+				LocationRange{
+					Location:    interpreter.Location,
+					HasPosition: identifierExpression,
+				},
+			)
 			interpreter.startResourceTracking(value, variable, identifier, identifierExpression)
 			variable.SetValue(value)
 		},
@@ -197,7 +208,7 @@ func (interpreter *Interpreter) checkMemberAccess(
 	locationRange LocationRange,
 ) {
 	memberInfo := interpreter.Program.Elaboration.MemberExpressionMemberInfos[memberExpression]
-	expectedType := memberInfo.AccessedType
+	expectedType := interpreter.resolveGenericType(memberInfo.AccessedType)
 
 	switch expectedType := expectedType.(type) {
 	case *sema.TransactionType:
@@ -436,9 +447,12 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 
 		// only evaluate right-hand side if left-hand side is nil
 		if some, ok := leftValue.(*SomeValue); ok {
+			interpreter.reportBranch(expression, 0)
 			return some.InnerValue(interpreter, locationRange)
 		}
 
+		interpreter.reportBranch(expression, 1)
+
 		value := rightValue()
 
 		binaryExpressionTypes := interpreter.Program.Elaboration.BinaryExpressionTypes[expression]
@@ -672,12 +686,66 @@ func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpr
 	return NewUnmeteredStringValue(expression.Value)
 }
 
+func (interpreter *Interpreter) VisitStringTemplateExpression(expression *ast.StringTemplateExpression) Value {
+	values := interpreter.visitExpressionsNonCopying(expression.Expressions)
+
+	length := 0
+	for _, literal := range expression.Values {
+		length += len(literal)
+	}
+
+	parts := make([]string, len(values))
+	for i, value := range values {
+		part := interpreter.stringTemplateValuePart(value)
+		parts[i] = part
+		length += len(part)
+	}
+
+	memoryUsage := common.NewStringMemoryUsage(length)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			var builder strings.Builder
+			builder.Grow(length)
+
+			for i, literal := range expression.Values {
+				builder.WriteString(literal)
+				if i < len(parts) {
+					builder.WriteString(parts[i])
+				}
+			}
+
+			return builder.String()
+		},
+	)
+}
+
+// stringTemplateValuePart returns the string representation
+// of a value interpolated in a string template
+func (interpreter *Interpreter) stringTemplateValuePart(value Value) string {
+	switch value := value.(type) {
+	case *StringValue:
+		return value.Str
+
+	case CharacterValue:
+		return string(value)
+
+	default:
+		// NOTE: the checker only allows values which can be converted to a string,
+		// i.e. booleans, numbers, addresses, and paths,
+		// which are represented as-is
+		return value.MeteredString(interpreter, SeenReferences{})
+	}
+}
+
 func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpression) Value {
 	values := interpreter.visitExpressionsNonCopying(expression.Values)
 
 	arrayExpressionTypes := interpreter.Program.Elaboration.ArrayExpressionTypes[expression]
 	argumentTypes := arrayExpressionTypes.ArgumentTypes
-	arrayType := arrayExpressionTypes.ArrayType
+	arrayType := interpreter.resolveGenericType(arrayExpressionTypes.ArrayType).(sema.ArrayType)
 	elementType := arrayType.ElementType(false)
 
 	copies := make([]Value, len(values))
@@ -713,7 +781,7 @@ func (interpreter *Interpreter) VisitDictionaryExpression(expression *ast.Dictio
 
 	dictionaryExpressionTypes := interpreter.Program.Elaboration.DictionaryExpressionTypes[expression]
 	entryTypes := dictionaryExpressionTypes.EntryTypes
-	dictionaryType := dictionaryExpressionTypes.DictionaryType
+	dictionaryType := interpreter.resolveGenericType(dictionaryExpressionTypes.DictionaryType).(*sema.DictionaryType)
 
 	var keyValuePairs []Value
 
@@ -769,6 +837,13 @@ func (interpreter *Interpreter) VisitMemberExpression(expression *ast.MemberExpr
 }
 
 func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpression) Value {
+
+	// Accessing an attachment, e.g. `r[A]`
+
+	if attachmentType, ok := interpreter.Program.Elaboration.AttachmentAccessTypes[expression]; ok {
+		return interpreter.visitAttachmentAccess(expression, attachmentType)
+	}
+
 	typedResult, ok := interpreter.evalExpression(expression.TargetExpression).(ValueIndexableValue)
 	if !ok {
 		panic(errors.NewUnreachableError())
@@ -781,14 +856,47 @@ func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpres
 	return typedResult.GetKey(interpreter, locationRange, indexingValue)
 }
 
+// visitAttachmentAccess evaluates an access of an attachment, e.g. `r[A]`.
+// The result is an optional reference to the attachment
+func (interpreter *Interpreter) visitAttachmentAccess(
+	expression *ast.IndexExpression,
+	attachmentType *sema.CompositeType,
+) Value {
+	locationRange := LocationRange{
+		Location:    interpreter.Location,
+		HasPosition: expression,
+	}
+
+	target := interpreter.evalExpression(expression.TargetExpression)
+
+	base := interpreter.attachmentBaseValue(target, locationRange)
+
+	attachment := base.GetAttachment(interpreter, locationRange, attachmentType.ID())
+	if attachment == nil {
+		return Nil
+	}
+
+	return NewSomeValueNonCopying(
+		interpreter,
+		NewEphemeralReferenceValue(
+			interpreter,
+			false,
+			attachment,
+			attachmentType,
+		),
+	)
+}
+
 func (interpreter *Interpreter) VisitConditionalExpression(expression *ast.ConditionalExpression) Value {
 	value, ok := interpreter.evalExpression(expression.Test).(BoolValue)
 	if !ok {
 		panic(errors.NewUnreachableError())
 	}
 	if value {
+		interpreter.reportBranch(expression, 0)
 		return interpreter.evalExpression(expression.Then)
 	} else {
+		interpreter.reportBranch(expression, 1)
 		return interpreter.evalExpression(expression.Else)
 	}
 }
@@ -857,12 +965,23 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 
 	invocationExpressionTypes := elaboration.InvocationExpressionTypes[invocationExpression]
 
-	typeParameterTypes := invocationExpressionTypes.TypeArguments
-	argumentTypes := invocationExpressionTypes.ArgumentTypes
-	parameterTypes := invocationExpressionTypes.TypeParameterTypes
+	// NOTE: the types might contain generic types of the enclosing function(s),
+	// so resolve them to the type arguments in call-site scope
+
+	typeParameterTypes := interpreter.resolveTypeArguments(invocationExpressionTypes.TypeArguments)
+	argumentTypes := interpreter.resolveGenericTypes(invocationExpressionTypes.ArgumentTypes)
+	parameterTypes := interpreter.resolveGenericTypes(invocationExpressionTypes.TypeParameterTypes)
 
 	interpreter.reportFunctionInvocation()
 
+	debugger := config.Debugger
+	if debugger != nil {
+		debugger.onFunctionInvocation(interpreter, invocationExpression)
+		// Pop the invocation even if the function panics,
+		// so the debugger's call stack is correct for later programs
+		defer debugger.onInvokedFunctionReturn()
+	}
+
 	resultValue := interpreter.invokeFunctionValue(
 		function,
 		arguments,
@@ -921,6 +1040,9 @@ func (interpreter *Interpreter) VisitFunctionExpression(expression *ast.Function
 
 	functionType := interpreter.Program.Elaboration.FunctionExpressionFunctionType[expression]
 
+	// NOTE: the function type might contain generic types of the enclosing function(s)
+	functionType = interpreter.resolveGenericType(functionType).(*sema.FunctionType)
+
 	var preConditions ast.Conditions
 	if expression.FunctionBlock.PreConditions != nil {
 		preConditions = *expression.FunctionBlock.PreConditions
@@ -959,7 +1081,7 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 		HasPosition: expression.Expression,
 	}
 
-	expectedType := interpreter.Program.Elaboration.CastingTargetTypes[expression]
+	expectedType := interpreter.resolveGenericType(interpreter.Program.Elaboration.CastingTargetTypes[expression])
 
 	switch expression.Operation {
 	case ast.OperationFailableCast, ast.OperationForceCast:
@@ -1029,9 +1151,50 @@ func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyEx
 	return Void
 }
 
+func (interpreter *Interpreter) VisitAttachExpression(expression *ast.AttachExpression) Value {
+
+	// NOTE: evaluate the attachment before the base,
+	// as the arguments of the attachment constructor may use the base
+
+	attachment, ok := interpreter.evalExpression(expression.Attachment).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	locationRange := LocationRange{
+		Location:    interpreter.Location,
+		HasPosition: expression,
+	}
+
+	base, ok := interpreter.evalExpression(expression.Base).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Resources are moved into the attach expression.
+	// Structures are copied, so attaching does not modify the original value
+
+	if !base.IsResourceKinded(interpreter) {
+		base, ok = base.Transfer(
+			interpreter,
+			locationRange,
+			atree.Address{},
+			false,
+			nil,
+		).(*CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+	}
+
+	base.SetAttachment(interpreter, locationRange, attachment)
+
+	return base
+}
+
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) Value {
 
-	borrowType := interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression]
+	borrowType := interpreter.resolveGenericType(interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression])
 
 	result := interpreter.evalExpression(referenceExpression.Expression)
 
@@ -21,7 +21,9 @@ package interpreter
 import (
 	"github.com/onflow/atree"
 
+	"github.com/onflow/cadence/runtime/activations"
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -129,9 +131,15 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 
 	interpreter.SharedState.callStack.Push(invocation)
 
-	// Make `self` available, if any
+	// Make `self` available, if any,
+	// and `base`, if `self` is an attachment
 	if invocation.Self != nil {
-		interpreter.declareVariable(sema.SelfIdentifier, *invocation.Self)
+		interpreter.declareSelf(*invocation.Self)
+	}
+
+	// Make the type arguments available, if any
+	if len(function.Type.TypeParameters) > 0 {
+		interpreter.declareTypeArguments(invocation.TypeParameterTypes)
 	}
 
 	return interpreter.invokeInterpretedFunctionActivated(function, invocation.Arguments)
@@ -155,6 +163,8 @@ func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
 		interpreter.bindParameterArguments(function.ParameterList, arguments)
 	}
 
+	interpreter.reportInterpretedFunctionInvocation(function)
+
 	return interpreter.visitFunctionBody(
 		function.BeforeStatements,
 		function.PreConditions,
@@ -176,3 +186,236 @@ func (interpreter *Interpreter) bindParameterArguments(
 		interpreter.declareVariable(parameter.Identifier.Identifier, argument)
 	}
 }
+
+// typeArgumentVariableName returns the name of the variable
+// which holds the type argument for the given type parameter.
+// The name is not a valid identifier, so it cannot clash with user-defined variables
+func typeArgumentVariableName(typeParameter *sema.TypeParameter) string {
+	return "$" + typeParameter.Name
+}
+
+// declareTypeArguments declares the given type arguments in the current activation,
+// so that the generic types in the invoked function can be resolved,
+// see resolveGenericType
+func (interpreter *Interpreter) declareTypeArguments(typeArguments *sema.TypeParameterTypeOrderedMap) {
+	if typeArguments == nil {
+		return
+	}
+
+	typeArguments.Foreach(func(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+		staticType := ConvertSemaToStaticType(interpreter, typeArgument)
+		interpreter.declareVariable(
+			typeArgumentVariableName(typeParameter),
+			NewTypeValue(interpreter, staticType),
+		)
+	})
+}
+
+// resolveGenericType resolves the generic types which occur in the given type
+// to the type arguments declared in the current activation, if any.
+//
+// Generic types for which no type argument is declared are left as-is
+func (interpreter *Interpreter) resolveGenericType(ty sema.Type) sema.Type {
+
+	// Types can only refer to type parameters if the program declares any,
+	// so avoid determining the free type parameters of the type otherwise
+
+	if ty == nil ||
+		interpreter.Program == nil ||
+		!interpreter.Program.Elaboration.HasTypeParameters {
+
+		return ty
+	}
+
+	typeParameters := sema.FreeTypeParameters(ty)
+	if len(typeParameters) == 0 {
+		return ty
+	}
+
+	typeArguments := &sema.TypeParameterTypeOrderedMap{}
+
+	for _, typeParameter := range typeParameters {
+		var typeArgument sema.Type
+
+		variable := interpreter.FindVariable(typeArgumentVariableName(typeParameter))
+		if variable != nil {
+			typeValue, ok := variable.GetValue().(TypeValue)
+			if ok && typeValue.Type != nil {
+				typeArgument = interpreter.MustConvertStaticToSemaType(typeValue.Type)
+			}
+		}
+
+		if typeArgument == nil {
+			typeArgument = &sema.GenericType{
+				TypeParameter: typeParameter,
+			}
+		}
+
+		typeArguments.Set(typeParameter, typeArgument)
+	}
+
+	resolvedType := ty.Resolve(typeArguments)
+	if resolvedType == nil {
+		return ty
+	}
+
+	return resolvedType
+}
+
+// resolveGenericTypes resolves the generic types which occur in the given types,
+// see resolveGenericType.
+//
+// The given slice is only copied if any of the types needs to be resolved
+func (interpreter *Interpreter) resolveGenericTypes(types []sema.Type) []sema.Type {
+	result := types
+
+	for i, ty := range types {
+		resolvedType := interpreter.resolveGenericType(ty)
+		if resolvedType == ty {
+			continue
+		}
+
+		if &result[0] == &types[0] {
+			result = make([]sema.Type, len(types))
+			copy(result, types)
+		}
+
+		result[i] = resolvedType
+	}
+
+	return result
+}
+
+// resolveTypeArguments resolves the generic types which occur in the given type arguments,
+// see resolveGenericType.
+//
+// The given type arguments are only copied if any of the types needs to be resolved
+func (interpreter *Interpreter) resolveTypeArguments(
+	typeArguments *sema.TypeParameterTypeOrderedMap,
+) *sema.TypeParameterTypeOrderedMap {
+
+	if typeArguments == nil {
+		return nil
+	}
+
+	result := typeArguments
+
+	typeArguments.Foreach(func(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+		resolvedTypeArgument := interpreter.resolveGenericType(typeArgument)
+		if resolvedTypeArgument == typeArgument {
+			return
+		}
+
+		if result == typeArguments {
+			result = &sema.TypeParameterTypeOrderedMap{}
+			typeArguments.Foreach(func(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+				result.Set(typeParameter, typeArgument)
+			})
+		}
+
+		result.Set(typeParameter, resolvedTypeArgument)
+	})
+
+	return result
+}
+
+// compositeStaticTypeArguments returns the type arguments
+// of a value of the given generic composite type, by index.
+//
+// The type arguments are stored in the type info of the composite value,
+// and are part of the static type of the value, see CompositeValue.StaticType
+func (interpreter *Interpreter) compositeStaticTypeArguments(
+	typeParameters []*sema.TypeParameter,
+	typeArguments *sema.TypeParameterTypeOrderedMap,
+) []StaticType {
+
+	if typeArguments == nil {
+		return nil
+	}
+
+	staticTypeArguments := make([]StaticType, 0, len(typeParameters))
+
+	for _, typeParameter := range typeParameters {
+		typeArgument, ok := typeArguments.Get(typeParameter)
+		if !ok {
+			// The type arguments are stored by index,
+			// so they must be given for all type parameters
+			panic(errors.NewUnreachableError())
+		}
+
+		staticTypeArguments = append(
+			staticTypeArguments,
+			ConvertSemaToStaticType(interpreter, typeArgument),
+		)
+	}
+
+	return staticTypeArguments
+}
+
+// compositeTypeArguments returns the type arguments of the given composite value,
+// see compositeStaticTypeArguments
+func (interpreter *Interpreter) compositeTypeArguments(
+	value *CompositeValue,
+	typeParameters []*sema.TypeParameter,
+) *sema.TypeParameterTypeOrderedMap {
+
+	typeArguments := &sema.TypeParameterTypeOrderedMap{}
+
+	for index, typeArgument := range value.typeArguments {
+		if index >= len(typeParameters) {
+			break
+		}
+
+		typeArguments.Set(
+			typeParameters[index],
+			interpreter.MustConvertStaticToSemaType(typeArgument),
+		)
+	}
+
+	return typeArguments
+}
+
+// compositeTypeArgumentsFunctionWrapper returns a function wrapper for the functions
+// of a generic composite type, which declares the type arguments stored in the composite value
+// when the function is invoked, so that the generic types in the function can be resolved
+func (interpreter *Interpreter) compositeTypeArgumentsFunctionWrapper(
+	typeParameters []*sema.TypeParameter,
+) FunctionWrapper {
+	return func(inner FunctionValue) FunctionValue {
+		function, ok := inner.(*InterpretedFunctionValue)
+		if !ok {
+			return inner
+		}
+
+		return NewHostFunctionValue(
+			interpreter,
+			func(invocation Invocation) Value {
+				inter := invocation.Interpreter
+
+				self, ok := (*invocation.Self).(*CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				activation := activations.NewActivation(inter, function.Activation)
+
+				for index, typeArgument := range self.typeArguments {
+					if index >= len(typeParameters) {
+						break
+					}
+					activation.Set(
+						typeArgumentVariableName(typeParameters[index]),
+						NewVariableWithValue(inter, NewTypeValue(inter, typeArgument)),
+					)
+				}
+
+				// NOTE: invoke a copy of the function which has the type arguments in scope
+				functionWithTypeArguments := *function
+				functionWithTypeArguments.Activation = activation
+
+				return functionWithTypeArguments.invoke(invocation)
+			},
+			function.Type,
+		)
+	}
+}
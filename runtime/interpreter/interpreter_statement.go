@@ -109,15 +109,16 @@ func (interpreter *Interpreter) VisitContinueStatement(_ *ast.ContinueStatement)
 func (interpreter *Interpreter) VisitIfStatement(statement *ast.IfStatement) StatementResult {
 	switch test := statement.Test.(type) {
 	case ast.Expression:
-		return interpreter.visitIfStatementWithTestExpression(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithTestExpression(statement, test, statement.Then, statement.Else)
 	case *ast.VariableDeclaration:
-		return interpreter.visitIfStatementWithVariableDeclaration(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithVariableDeclaration(statement, test, statement.Then, statement.Else)
 	default:
 		panic(errors.NewUnreachableError())
 	}
 }
 
 func (interpreter *Interpreter) visitIfStatementWithTestExpression(
+	statement *ast.IfStatement,
 	test ast.Expression,
 	thenBlock, elseBlock *ast.Block,
 ) StatementResult {
@@ -128,8 +129,13 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 	}
 
 	if value {
+		interpreter.reportBranch(statement, 0)
 		return interpreter.visitBlock(thenBlock)
-	} else if elseBlock != nil {
+	}
+
+	interpreter.reportBranch(statement, 1)
+
+	if elseBlock != nil {
 		return interpreter.visitBlock(elseBlock)
 	}
 
@@ -137,6 +143,7 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 }
 
 func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
+	statement *ast.IfStatement,
 	declaration *ast.VariableDeclaration,
 	thenBlock, elseBlock *ast.Block,
 ) StatementResult {
@@ -201,8 +208,14 @@ func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
 			transferredUnwrappedValue,
 		)
 
+		interpreter.reportBranch(statement, 0)
+
 		return interpreter.visitBlock(thenBlock)
-	} else if elseBlock != nil {
+	}
+
+	interpreter.reportBranch(statement, 1)
+
+	if elseBlock != nil {
 		return interpreter.visitBlock(elseBlock)
 	}
 
@@ -216,9 +229,11 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		panic(errors.NewUnreachableError())
 	}
 
-	for _, switchCase := range switchStatement.Cases {
+	for caseIndex, switchCase := range switchStatement.Cases {
 
 		runStatements := func() StatementResult {
+			interpreter.reportBranch(switchStatement, caseIndex)
+
 			// NOTE: the new block ensures that a new scope is introduced
 
 			block := ast.NewBlock(
@@ -270,6 +285,8 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		// then try the next case
 	}
 
+	interpreter.reportBranch(switchStatement, len(switchStatement.Cases))
+
 	return nil
 }
 
@@ -384,6 +401,12 @@ func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement)
 		HasPosition: statement,
 	}
 
+	if interpreter.SharedState.inDebuggerEvaluation {
+		panic(EventEmittedDuringEvaluationError{
+			LocationRange: locationRange,
+		})
+	}
+
 	config := interpreter.SharedState.Config
 
 	onEventEmitted := config.OnEventEmitted
@@ -401,10 +424,41 @@ func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement)
 	return nil
 }
 
+func (interpreter *Interpreter) VisitRemoveStatement(statement *ast.RemoveStatement) StatementResult {
+
+	attachmentType := interpreter.Program.Elaboration.RemoveStatementTypes[statement]
+
+	locationRange := LocationRange{
+		Location:    interpreter.Location,
+		HasPosition: statement,
+	}
+
+	// The base may also be a reference to the base value
+
+	value := interpreter.evalExpression(statement.Value)
+	base := interpreter.attachmentBaseValue(value, locationRange)
+
+	attachment := base.RemoveAttachment(interpreter, locationRange, attachmentType.ID())
+
+	// Removing an attachment which does not exist has no effect.
+	// Attachments of resources are resources, and are destroyed when removed
+
+	if attachment != nil && base.IsResourceKinded(interpreter) {
+		attachment.Destroy(interpreter, locationRange)
+	}
+
+	return nil
+}
+
 func (interpreter *Interpreter) VisitPragmaDeclaration(_ *ast.PragmaDeclaration) StatementResult {
 	return nil
 }
 
+func (interpreter *Interpreter) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) StatementResult {
+	// Type aliases are resolved statically, there is nothing to interpret
+	return nil
+}
+
 // VisitVariableDeclaration first visits the declaration's value,
 // then declares the variable with the name bound to the value
 func (interpreter *Interpreter) VisitVariableDeclaration(declaration *ast.VariableDeclaration) StatementResult {
@@ -43,6 +43,24 @@ func (r LocationRange) ImportLocation() common.Location {
 	return r.Location
 }
 
+func (r LocationRange) locationRange() LocationRange {
+	return r
+}
+
+func (r LocationRange) StartPosition() ast.Position {
+	if r.HasPosition == nil {
+		return ast.EmptyPosition
+	}
+	return r.HasPosition.StartPosition()
+}
+
+func (r LocationRange) EndPosition(memoryGauge common.MemoryGauge) ast.Position {
+	if r.HasPosition == nil {
+		return ast.EmptyPosition
+	}
+	return r.HasPosition.EndPosition(memoryGauge)
+}
+
 var EmptyLocationRange = LocationRange{}
 
 func ReturnEmptyRange() ast.Range {
@@ -32,6 +32,15 @@ type SharedState struct {
 	typeCodes                     TypeCodes
 	inStorageIteration            bool
 	storageMutatedDuringIteration bool
+	// inDebuggerEvaluation is set while the debugger evaluates an expression,
+	// which must not modify storage, existing values, or emit events
+	inDebuggerEvaluation bool
+	// debuggerEvaluationValues are the storage IDs of the container values
+	// created while the debugger evaluates an expression, which may be mutated
+	debuggerEvaluationValues map[atree.StorageID]struct{}
+	// debuggerEvaluationVariables are the variables declared
+	// while the debugger evaluates an expression, which may be assigned
+	debuggerEvaluationVariables map[*Variable]struct{}
 	// TODO: ideally this would be a weak map, but Go has no weak references
 	referencedResourceKindedValues ReferencedResourceKindedValues
 	resourceVariables              map[ResourceKindedValue]*Variable
@@ -55,6 +55,10 @@ type CompositeStaticType struct {
 	Location            common.Location
 	QualifiedIdentifier string
 	TypeID              common.TypeID
+	// typeArguments are the type arguments of an instantiation of a generic composite type, if any.
+	// NOTE: the type arguments are referred to by pointer, so composite static types are comparable,
+	// and can e.g. be used in map keys
+	typeArguments *[]StaticType
 }
 
 var _ StaticType = CompositeStaticType{}
@@ -84,19 +88,53 @@ func NewCompositeStaticTypeComputeTypeID(
 	return NewCompositeStaticType(memoryGauge, location, qualifiedIdentifier, typeID)
 }
 
+// TypeArguments returns the type arguments of an instantiation of a generic composite type, if any
+func (t CompositeStaticType) TypeArguments() []StaticType {
+	if t.typeArguments == nil {
+		return nil
+	}
+	return *t.typeArguments
+}
+
+// WithTypeArguments returns the composite static type with the given type arguments
+func (t CompositeStaticType) WithTypeArguments(typeArguments []StaticType) CompositeStaticType {
+	if len(typeArguments) == 0 {
+		t.typeArguments = nil
+	} else {
+		t.typeArguments = &typeArguments
+	}
+	return t
+}
+
 func (CompositeStaticType) isStaticType() {}
 
 func (CompositeStaticType) elementSize() uint {
 	return UnknownElementSize
 }
 
-func (t CompositeStaticType) String() string {
+func (t CompositeStaticType) identifier() string {
 	if t.Location == nil {
 		return t.QualifiedIdentifier
 	}
 	return string(t.TypeID)
 }
 
+func (t CompositeStaticType) String() string {
+	identifier := t.identifier()
+
+	staticTypeArguments := t.TypeArguments()
+	if len(staticTypeArguments) == 0 {
+		return identifier
+	}
+
+	typeArguments := make([]string, len(staticTypeArguments))
+	for i, typeArgument := range staticTypeArguments {
+		typeArguments[i] = typeArgument.String()
+	}
+
+	return fmt.Sprintf("%s<%s>", identifier, strings.Join(typeArguments, ", "))
+}
+
 func (t CompositeStaticType) MeteredString(memoryGauge common.MemoryGauge) string {
 	var amount int
 	if t.Location == nil {
@@ -106,7 +144,23 @@ func (t CompositeStaticType) MeteredString(memoryGauge common.MemoryGauge) strin
 	}
 
 	common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(amount))
-	return t.String()
+
+	identifier := t.identifier()
+
+	staticTypeArguments := t.TypeArguments()
+	if len(staticTypeArguments) == 0 {
+		return identifier
+	}
+
+	typeArguments := make([]string, len(staticTypeArguments))
+	for i, typeArgument := range staticTypeArguments {
+		typeArguments[i] = typeArgument.MeteredString(memoryGauge)
+	}
+
+	// Account for the angle brackets and the separators
+	common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(len(typeArguments)*2))
+
+	return fmt.Sprintf("%s<%s>", identifier, strings.Join(typeArguments, ", "))
 }
 
 func (t CompositeStaticType) Equal(other StaticType) bool {
@@ -115,7 +169,24 @@ func (t CompositeStaticType) Equal(other StaticType) bool {
 		return false
 	}
 
-	return otherCompositeType.TypeID == t.TypeID
+	if otherCompositeType.TypeID != t.TypeID {
+		return false
+	}
+
+	typeArguments := t.TypeArguments()
+	otherTypeArguments := otherCompositeType.TypeArguments()
+
+	if len(typeArguments) != len(otherTypeArguments) {
+		return false
+	}
+
+	for i, typeArgument := range typeArguments {
+		if !typeArgument.Equal(otherTypeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // InterfaceStaticType
@@ -599,7 +670,20 @@ func (t CapabilityStaticType) Equal(other StaticType) bool {
 func ConvertSemaToStaticType(memoryGauge common.MemoryGauge, t sema.Type) StaticType {
 	switch t := t.(type) {
 	case *sema.CompositeType:
-		return NewCompositeStaticType(memoryGauge, t.Location, t.QualifiedIdentifier(), t.ID())
+		staticType := NewCompositeStaticType(memoryGauge, t.Location, t.QualifiedIdentifier(), t.ID())
+
+		// Instantiations of generic composite types keep their type arguments
+
+		if t.BaseType() != nil {
+			typeArguments := t.TypeArguments()
+			staticTypeArguments := make([]StaticType, len(typeArguments))
+			for i, typeArgument := range typeArguments {
+				staticTypeArguments[i] = ConvertSemaToStaticType(memoryGauge, typeArgument)
+			}
+			staticType = staticType.WithTypeArguments(staticTypeArguments)
+		}
+
+		return staticType
 
 	case *sema.InterfaceType:
 		return ConvertSemaInterfaceTypeToStaticInterfaceType(memoryGauge, t)
@@ -709,7 +793,27 @@ func ConvertStaticToSemaType(
 ) (_ sema.Type, err error) {
 	switch t := typ.(type) {
 	case CompositeStaticType:
-		return getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		compositeType, err := getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		staticTypeArguments := t.TypeArguments()
+		if err != nil || len(staticTypeArguments) == 0 {
+			return compositeType, err
+		}
+
+		typeArguments := make([]sema.Type, len(staticTypeArguments))
+		for i, typeArgument := range staticTypeArguments {
+			typeArguments[i], err = ConvertStaticToSemaType(memoryGauge, typeArgument, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(typeArguments) != len(compositeType.TypeParameters()) {
+			return nil, TypeLoadingError{
+				TypeID: t.TypeID,
+			}
+		}
+
+		return compositeType.Instantiate(typeArguments, nil), nil
 
 	case InterfaceStaticType:
 		return getInterface(t.Location, t.QualifiedIdentifier)
@@ -1474,6 +1474,7 @@ func NewArrayValueWithIterator(
 	}
 	// must assign to v here for tracing to work properly
 	v = newArrayValueFromConstructor(interpreter, arrayType, count, constructor)
+	interpreter.recordEvaluationValue(v.StorageID())
 	return v
 }
 
@@ -1774,6 +1775,8 @@ func (v *ArrayValue) Set(interpreter *Interpreter, locationRange LocationRange,
 		})
 	}
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, locationRange)
 
 	common.UseMemory(interpreter, common.AtreeArrayElementOverhead)
@@ -1836,6 +1839,8 @@ func (v *ArrayValue) MeteredString(memoryGauge common.MemoryGauge, seenReference
 
 func (v *ArrayValue) Append(interpreter *Interpreter, locationRange LocationRange, element Value) {
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(
 		v.array.Count(),
@@ -1893,6 +1898,8 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, locationRange LocationRang
 		})
 	}
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(
 		v.array.Count(),
@@ -1946,6 +1953,8 @@ func (v *ArrayValue) Remove(interpreter *Interpreter, locationRange LocationRang
 		})
 	}
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	storable, err := v.array.Remove(uint64(index))
 	if err != nil {
 		v.handleIndexOutOfBoundsError(err, index, locationRange)
@@ -2429,6 +2438,8 @@ func (v *ArrayValue) Transfer(
 			panic(errors.NewExternalError(err))
 		}
 
+		interpreter.recordEvaluationValue(array.StorageID())
+
 		if remove {
 			err = v.array.PopIterate(func(storable atree.Storable) {
 				interpreter.RemoveReferencedSlab(storable)
@@ -2723,6 +2734,7 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 				)
 			},
 			&sema.FunctionType{
+				Purity: sema.FunctionPurityView,
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
 					sema.ByteArrayType,
 				),
@@ -2743,6 +2755,7 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 				)
 			},
 			&sema.FunctionType{
+				Purity: sema.FunctionPurityView,
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
 					typ,
 				),
@@ -2763,6 +2776,7 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 				)
 			},
 			&sema.FunctionType{
+				Purity: sema.FunctionPurityView,
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
 					typ,
 				),
@@ -2783,6 +2797,7 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 				)
 			},
 			&sema.FunctionType{
+				Purity: sema.FunctionPurityView,
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
 					typ,
 				),
@@ -2803,6 +2818,7 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 				)
 			},
 			&sema.FunctionType{
+				Purity: sema.FunctionPurityView,
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
 					typ,
 				),
@@ -13831,6 +13847,11 @@ type CompositeValue struct {
 	isDestroyed         bool
 	typeID              common.TypeID
 	staticType          StaticType
+	// typeArguments are the type arguments of a value of a generic composite type, if any
+	typeArguments []StaticType
+	// base is the value an attachment is attached to.
+	// Only set for attachment values, when they are accessed through their base
+	base *CompositeValue
 }
 
 type ComputedField func(*Interpreter, LocationRange) Value
@@ -13861,6 +13882,30 @@ func NewCompositeValue(
 	fields []CompositeField,
 	address common.Address,
 ) *CompositeValue {
+	return newCompositeValue(
+		interpreter,
+		locationRange,
+		location,
+		qualifiedIdentifier,
+		kind,
+		nil,
+		fields,
+		address,
+	)
+}
+
+// newCompositeValue returns a new composite value.
+// The type arguments are only given for values of generic composite types
+func newCompositeValue(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	location common.Location,
+	qualifiedIdentifier string,
+	kind common.CompositeKind,
+	typeArguments []StaticType,
+	fields []CompositeField,
+	address common.Address,
+) *CompositeValue {
 
 	interpreter.ReportComputation(common.ComputationKindCreateCompositeValue, 1)
 
@@ -13892,16 +13937,19 @@ func NewCompositeValue(
 	}
 
 	constructor := func() *atree.OrderedMap {
+		typeInfo := NewCompositeTypeInfo(
+			interpreter,
+			location,
+			qualifiedIdentifier,
+			kind,
+		)
+		typeInfo.typeArguments = typeArguments
+
 		dictionary, err := atree.NewMap(
 			config.Storage,
 			atree.Address(address),
 			atree.NewDefaultDigesterBuilder(),
-			NewCompositeTypeInfo(
-				interpreter,
-				location,
-				qualifiedIdentifier,
-				kind,
-			),
+			typeInfo,
 		)
 		if err != nil {
 			panic(errors.NewExternalError(err))
@@ -13915,8 +13963,10 @@ func NewCompositeValue(
 		qualifiedIdentifier,
 		kind,
 	)
+	typeInfo.typeArguments = typeArguments
 
 	v = newCompositeValueFromConstructor(interpreter, uint64(len(fields)), typeInfo, constructor)
+	interpreter.recordEvaluationValue(v.StorageID())
 
 	for _, field := range fields {
 		v.SetMember(
@@ -13939,6 +13989,7 @@ func newCompositeValueFromOrderedMap(
 		Location:            typeInfo.location,
 		QualifiedIdentifier: typeInfo.qualifiedIdentifier,
 		Kind:                typeInfo.kind,
+		typeArguments:       typeInfo.typeArguments,
 	}
 }
 
@@ -13971,15 +14022,15 @@ func (v *CompositeValue) Accept(interpreter *Interpreter, visitor Visitor) {
 		return
 	}
 
-	v.ForEachField(interpreter, func(_ string, value Value) {
+	v.forEachFieldAndAttachment(interpreter, func(_ string, value Value) {
 		value.Accept(interpreter, visitor)
 	})
 }
 
-// Walk iterates over all field values of the composite value.
+// Walk iterates over all field values and attachments of the composite value.
 // It does NOT walk the computed fields and functions!
 func (v *CompositeValue) Walk(interpreter *Interpreter, walkChild func(Value)) {
-	v.ForEachField(interpreter, func(_ string, value Value) {
+	v.forEachFieldAndAttachment(interpreter, func(_ string, value Value) {
 		walkChild(value)
 	})
 }
@@ -13993,7 +14044,7 @@ func (v *CompositeValue) StaticType(interpreter *Interpreter) StaticType {
 			v.Location,
 			v.QualifiedIdentifier,
 			v.TypeID(), // TODO TypeID metering
-		)
+		).WithTypeArguments(v.typeArguments)
 	}
 	return v.staticType
 }
@@ -14045,6 +14096,12 @@ func (v *CompositeValue) Destroy(interpreter *Interpreter, locationRange Locatio
 		v.Destructor = interpreter.SharedState.typeCodes.CompositeCodes[v.TypeID()].DestructorFunction
 	}
 
+	// Destroy the attachments first, as their destructors may still access the base
+
+	for _, attachment := range v.attachments(interpreter) {
+		attachment.Destroy(interpreter, locationRange)
+	}
+
 	destructor := v.Destructor
 
 	if destructor != nil {
@@ -14235,6 +14292,8 @@ func (v *CompositeValue) RemoveMember(
 		v.checkInvalidatedResourceUse(locationRange)
 	}
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	if config.TracingEnabled {
 		startTime := time.Now()
 
@@ -14300,6 +14359,8 @@ func (v *CompositeValue) SetMember(
 		v.checkInvalidatedResourceUse(locationRange)
 	}
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	if config.TracingEnabled {
 		startTime := time.Now()
 
@@ -14368,9 +14429,16 @@ func (v *CompositeValue) MeteredString(memoryGauge common.MemoryGauge, seenRefer
 
 	var fields []CompositeField
 	_ = v.dictionary.Iterate(func(key atree.Value, value atree.Value) (resume bool, err error) {
+		name := string(key.(StringAtreeValue))
+
+		// Attachments are not shown
+		if isAttachmentFieldName(name) {
+			return true, nil
+		}
+
 		field := NewCompositeField(
 			memoryGauge,
-			string(key.(StringAtreeValue)),
+			name,
 			MustConvertStoredValue(memoryGauge, value),
 		)
 
@@ -14452,8 +14520,20 @@ func (v *CompositeValue) Equal(interpreter *Interpreter, locationRange LocationR
 	}
 
 	if !v.StaticType(interpreter).Equal(otherComposite.StaticType(interpreter)) ||
-		v.Kind != otherComposite.Kind ||
-		v.dictionary.Count() != otherComposite.dictionary.Count() {
+		v.Kind != otherComposite.Kind {
+
+		return false
+	}
+
+	// The dictionary of the composite value also contains its attachments.
+	// Composite values are only equal if they have equal fields,
+	// and if they have the same attachments, and the attachments are equal
+
+	fieldCount, attachmentCount := v.fieldAndAttachmentCount()
+	otherFieldCount, otherAttachmentCount := otherComposite.fieldAndAttachmentCount()
+
+	if fieldCount != otherFieldCount ||
+		attachmentCount != otherAttachmentCount {
 
 		return false
 	}
@@ -14472,11 +14552,13 @@ func (v *CompositeValue) Equal(interpreter *Interpreter, locationRange LocationR
 			return true
 		}
 
-		fieldName := string(key.(StringAtreeValue))
+		// The name is either the name of a field, or the name of an attachment,
+		// see attachmentFieldName
+		name := string(key.(StringAtreeValue))
 
 		// NOTE: Do NOT use an iterator, iteration order of fields may be different
 		// (if stored in different account, as storage ID is used as hash seed)
-		otherValue := otherComposite.GetField(interpreter, locationRange, fieldName)
+		otherValue := otherComposite.GetField(interpreter, locationRange, name)
 
 		equatableValue, ok := MustConvertStoredValue(interpreter, value).(EquatableValue)
 		if !ok || !equatableValue.Equal(interpreter, locationRange, otherValue) {
@@ -14566,11 +14648,26 @@ func (v *CompositeValue) ConformsToStaticType(
 		return false
 	}
 
-	fieldsLen := int(v.dictionary.Count())
+	fieldsLen, _ := v.fieldAndAttachmentCount()
 	if v.ComputedFields != nil {
 		fieldsLen += len(v.ComputedFields)
 	}
 
+	// The fields of a value of a generic composite type are checked
+	// against the field types of the generic composite type,
+	// with the type parameters substituted by the type arguments of the value
+
+	var typeArguments *sema.TypeParameterTypeOrderedMap
+
+	if genericType, ok := compositeType.BaseType().(*sema.CompositeType); ok {
+		compositeType = genericType
+	}
+
+	typeParameters := compositeType.TypeParameters()
+	if len(typeParameters) > 0 {
+		typeArguments = interpreter.compositeTypeArguments(v, typeParameters)
+	}
+
 	if fieldsLen != len(compositeType.Fields) {
 		return false
 	}
@@ -14597,7 +14694,15 @@ func (v *CompositeValue) ConformsToStaticType(
 
 		fieldStaticType := value.StaticType(interpreter)
 
-		if !interpreter.IsSubTypeOfSemaType(fieldStaticType, member.TypeAnnotation.Type) {
+		fieldType := member.TypeAnnotation.Type
+		if typeArguments != nil {
+			resolvedFieldType := fieldType.Resolve(typeArguments)
+			if resolvedFieldType != nil {
+				fieldType = resolvedFieldType
+			}
+		}
+
+		if !interpreter.IsSubTypeOfSemaType(fieldStaticType, fieldType) {
 			return false
 		}
 
@@ -14610,6 +14715,16 @@ func (v *CompositeValue) ConformsToStaticType(
 		}
 	}
 
+	for _, attachment := range v.attachments(interpreter) {
+		if !attachment.ConformsToStaticType(
+			interpreter,
+			locationRange,
+			results,
+		) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -14744,6 +14859,8 @@ func (v *CompositeValue) Transfer(
 			panic(errors.NewExternalError(err))
 		}
 
+		interpreter.recordEvaluationValue(dictionary.StorageID())
+
 		if remove {
 			err = v.dictionary.PopIterate(func(nameStorable atree.Storable, valueStorable atree.Storable) {
 				interpreter.RemoveReferencedSlab(nameStorable)
@@ -14799,6 +14916,7 @@ func (v *CompositeValue) Transfer(
 			v.QualifiedIdentifier,
 			v.Kind,
 		)
+		info.typeArguments = v.typeArguments
 		res = newCompositeValueFromOrderedMap(dictionary, info)
 		res.InjectedFields = v.InjectedFields
 		res.ComputedFields = v.ComputedFields
@@ -14891,6 +15009,7 @@ func (v *CompositeValue) Clone(interpreter *Interpreter) Value {
 		isDestroyed:         v.isDestroyed,
 		typeID:              v.typeID,
 		staticType:          v.staticType,
+		typeArguments:       v.typeArguments,
 	}
 }
 
@@ -14938,8 +15057,19 @@ func (v *CompositeValue) GetOwner() common.Address {
 }
 
 // ForEachField iterates over all field-name field-value pairs of the composite value.
-// It does NOT iterate over computed fields and functions!
+// It does NOT iterate over computed fields, functions, and attachments!
 func (v *CompositeValue) ForEachField(gauge common.MemoryGauge, f func(fieldName string, fieldValue Value)) {
+	v.forEachFieldAndAttachment(gauge, func(name string, value Value) {
+		if isAttachmentFieldName(name) {
+			return
+		}
+		f(name, value)
+	})
+}
+
+// forEachFieldAndAttachment iterates over all fields and attachments of the composite value.
+// The name of an attachment is the name of the field it is stored in, see attachmentFieldName
+func (v *CompositeValue) forEachFieldAndAttachment(gauge common.MemoryGauge, f func(name string, value Value)) {
 
 	err := v.dictionary.Iterate(func(key atree.Value, value atree.Value) (resume bool, err error) {
 		f(
@@ -15104,6 +15234,7 @@ func NewDictionaryValueWithAddress(
 
 	// values are added to the dictionary after creation, not here
 	v = newDictionaryValueFromConstructor(interpreter, dictionaryType, 0, constructor)
+	interpreter.recordEvaluationValue(v.StorageID())
 
 	// NOTE: lazily initialized when needed for performance reasons
 	var lazyIsResourceTyped *bool
@@ -15690,6 +15821,8 @@ func (v *DictionaryValue) Remove(
 	keyValue Value,
 ) OptionalValue {
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	valueComparator := newValueComparator(interpreter, locationRange)
 	hashInputProvider := newHashInputProvider(interpreter, locationRange)
 
@@ -15745,6 +15878,8 @@ func (v *DictionaryValue) Insert(
 	keyValue, value Value,
 ) OptionalValue {
 
+	interpreter.checkEvaluationMutation(v.StorageID(), locationRange)
+
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(v.dictionary.Count(), v.elementSize, false)
 	common.UseMemory(interpreter, common.AtreeMapElementOverhead)
@@ -16045,6 +16180,8 @@ func (v *DictionaryValue) Transfer(
 			panic(errors.NewExternalError(err))
 		}
 
+		interpreter.recordEvaluationValue(dictionary.StorageID())
+
 		if remove {
 			err = v.dictionary.PopIterate(func(keyStorable atree.Storable, valueStorable atree.Storable) {
 				interpreter.RemoveReferencedSlab(keyStorable)
@@ -822,7 +822,7 @@ pub contract GarmentNFT: NonFungibleToken {
        }
 
        // getIDs returns an array of the IDs that are in the Collection
-       pub fun getIDs(): [UInt64] {
+       pub view fun getIDs(): [UInt64] {
            return self.ownedNFTs.keys
        }
 
@@ -1284,7 +1284,7 @@ pub contract MaterialNFT: NonFungibleToken {
        }
 
        // getIDs returns an array of the IDs that are in the Collection
-       pub fun getIDs(): [UInt64] {
+       pub view fun getIDs(): [UInt64] {
            return self.ownedNFTs.keys
        }
 
@@ -1802,7 +1802,7 @@ pub contract ItemNFT: NonFungibleToken {
        }
 
        // getIDs returns an array of the IDs that are in the Collection
-       pub fun getIDs(): [UInt64] {
+       pub view fun getIDs(): [UInt64] {
            return self.ownedNFTs.keys
        }
 
@@ -4454,12 +4454,12 @@ pub contract ExampleNFT {
 
         // idExists checks to see if a NFT 
         // with the given ID exists in the collection
-        pub fun idExists(id: UInt64): Bool {
+        pub view fun idExists(id: UInt64): Bool {
             return self.ownedNFTs[id] != nil
         }
 
         // getIDs returns an array of the IDs that are in the collection
-        pub fun getIDs(): [UInt64] {
+        pub view fun getIDs(): [UInt64] {
             return self.ownedNFTs.keys
         }
 
@@ -4674,7 +4674,7 @@ pub contract ExampleMarketplace {
         }
 
         // getIDs returns an array of token IDs that are for sale
-        pub fun getIDs(): [UInt64] {
+        pub view fun getIDs(): [UInt64] {
             return self.prices.keys
         }
     }
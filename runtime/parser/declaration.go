@@ -81,7 +81,15 @@ func parseDeclaration(p *parser, docString string) (ast.Declaration, error) {
 				return parseVariableDeclaration(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, false, access, accessPos, docString)
+				return parseFunctionDeclaration(p, false, access, accessPos, ast.FunctionPurityUnspecified, nil, docString)
+
+			case keywordView:
+				purity, purityPos := parseViewFunctionModifier(p)
+				if purity == ast.FunctionPurityUnspecified {
+					// Not followed by the `fun` keyword, so it is an identifier
+					break
+				}
+				return parseFunctionDeclaration(p, false, access, accessPos, purity, purityPos, docString)
 
 			case keywordImport:
 				return parseImportDeclaration(p)
@@ -92,6 +100,16 @@ func parseDeclaration(p *parser, docString string) (ast.Declaration, error) {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordAttachment:
+				if !p.isNextSemanticTokenIdentifier() {
+					// Not followed by an identifier, so it is an identifier
+					break
+				}
+				return parseAttachmentDeclaration(p, access, accessPos, docString)
+
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
 					return nil, p.syntaxError("invalid access modifier for transaction")
@@ -699,7 +717,9 @@ func parseEventDeclaration(
 		ast.NewFunctionDeclaration(
 			p.memoryGauge,
 			ast.AccessNotSpecified,
+			ast.FunctionPurityUnspecified,
 			ast.NewEmptyIdentifier(p.memoryGauge, ast.EmptyPosition),
+			nil,
 			parameterList,
 			nil,
 			nil,
@@ -721,6 +741,8 @@ func parseEventDeclaration(
 		common.CompositeKindEvent,
 		identifier,
 		nil,
+		nil,
+		nil,
 		members,
 		docString,
 		ast.NewRange(
@@ -825,7 +847,7 @@ func parseFieldWithVariableKind(
 //
 //	conformances : ':' nominalType ( ',' nominalType )*
 //
-//	compositeDeclaration : compositeKind identifier conformances?
+//	compositeDeclaration : compositeKind identifier typeParameterList? conformances?
 //	                       '{' membersAndNestedDeclarations '}'
 //
 //	interfaceDeclaration : compositeKind 'interface' identifier conformances?
@@ -881,10 +903,19 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	}
 
+	var typeParameterList *ast.TypeParameterList
+	var err error
+
+	if !isInterface {
+		typeParameterList, err = parseTypeParameterList(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	p.skipSpaceAndComments()
 
 	var conformances []*ast.NominalType
-	var err error
 
 	if p.current.Is(lexer.TokenColon) {
 		// Skip the colon
@@ -950,6 +981,8 @@ func parseCompositeOrInterfaceDeclaration(
 			access,
 			compositeKind,
 			identifier,
+			typeParameterList,
+			nil,
 			conformances,
 			members,
 			docString,
@@ -958,6 +991,90 @@ func parseCompositeOrInterfaceDeclaration(
 	}
 }
 
+// parseAttachmentDeclaration parses an attachment declaration.
+//
+//	attachmentDeclaration : 'attachment' identifier 'for' nominalType
+//	                        '{' membersAndNestedDeclarations '}'
+func parseAttachmentDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) (ast.Declaration, error) {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `attachment` keyword
+	p.nextSemanticToken()
+
+	identifier, err := p.mustIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	if !p.isToken(p.current, lexer.TokenIdentifier, keywordFor) {
+		return nil, p.syntaxError(
+			"expected %q after attachment name, got %s",
+			keywordFor,
+			p.current.Type,
+		)
+	}
+
+	// Skip the `for` keyword
+	p.nextSemanticToken()
+
+	baseTypeToken, err := p.mustOne(lexer.TokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	baseType, err := parseNominalTypeRemainder(p, baseTypeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	_, err = p.mustOne(lexer.TokenBraceOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := parseMembersAndNestedDeclarations(p, lexer.TokenBraceClose)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	endToken, err := p.mustOne(lexer.TokenBraceClose)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewCompositeDeclaration(
+		p.memoryGauge,
+		access,
+		common.CompositeKindAttachment,
+		identifier,
+		nil,
+		baseType,
+		nil,
+		members,
+		docString,
+		ast.NewRange(
+			p.memoryGauge,
+			startPos,
+			endToken.EndPos,
+		),
+	), nil
+}
+
 // parseMembersAndNestedDeclarations parses composite or interface members,
 // and nested declarations.
 //
@@ -1006,6 +1123,7 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 //	                          | compositeDeclaration
 //	                          | eventDeclaration
 //	                          | enumCase
+//	                          | typeAliasDeclaration
 func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaration, error) {
 
 	const functionBlockIsOptional = true
@@ -1015,11 +1133,29 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 
 	var previousIdentifierToken *lexer.Token
 
+	purity := ast.FunctionPurityUnspecified
+	var purityPos *ast.Position
+
 	for {
 		p.skipSpaceAndComments()
 
 		switch p.current.Type {
 		case lexer.TokenIdentifier:
+			if previousIdentifierToken == nil {
+				functionPurity, functionPurityPos := parseViewFunctionModifier(p)
+				if functionPurity != ast.FunctionPurityUnspecified {
+					return parseFunctionDeclaration(
+						p,
+						functionBlockIsOptional,
+						access,
+						accessPos,
+						functionPurity,
+						functionPurityPos,
+						docString,
+					)
+				}
+			}
+
 			switch string(p.currentTokenSource()) {
 			case keywordLet, keywordVar:
 				return parseFieldWithVariableKind(p, access, accessPos, docString)
@@ -1028,7 +1164,15 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 				return parseEnumCase(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
+				return parseFunctionDeclaration(
+					p,
+					functionBlockIsOptional,
+					access,
+					accessPos,
+					ast.FunctionPurityUnspecified,
+					nil,
+					docString,
+				)
 
 			case keywordEvent:
 				return parseEventDeclaration(p, access, accessPos, docString)
@@ -1036,6 +1180,16 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordAttachment:
+				if !p.isNextSemanticTokenIdentifier() {
+					// Not followed by an identifier, so it is an identifier
+					break
+				}
+				return parseAttachmentDeclaration(p, access, accessPos, docString)
+
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
 					return nil, p.syntaxError("unexpected access modifier")
@@ -1052,7 +1206,17 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 
 			default:
 				if previousIdentifierToken != nil {
-					return nil, p.syntaxError("unexpected %s", p.current.Type)
+					// The previous identifier may be the `view` modifier of an initializer
+
+					if purity != ast.FunctionPurityUnspecified ||
+						!p.isToken(*previousIdentifierToken, lexer.TokenIdentifier, keywordView) {
+
+						return nil, p.syntaxError("unexpected %s", p.current.Type)
+					}
+
+					purity = ast.FunctionPurityView
+					pos := previousIdentifierToken.StartPos
+					purityPos = &pos
 				}
 
 				t := p.current
@@ -1063,7 +1227,7 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 			}
 
 		case lexer.TokenColon:
-			if previousIdentifierToken == nil {
+			if previousIdentifierToken == nil || purity != ast.FunctionPurityUnspecified {
 				return nil, p.syntaxError("unexpected %s", p.current.Type)
 			}
 
@@ -1076,7 +1240,22 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) (ast.Declaratio
 			}
 
 			identifier := p.tokenToIdentifier(*previousIdentifierToken)
-			return parseSpecialFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, identifier)
+
+			if purity != ast.FunctionPurityUnspecified &&
+				identifier.Identifier != keywordInit {
+
+				return nil, p.syntaxError("invalid view modifier for %s", identifier.Identifier)
+			}
+
+			return parseSpecialFunctionDeclaration(
+				p,
+				functionBlockIsOptional,
+				access,
+				accessPos,
+				purity,
+				purityPos,
+				identifier,
+			)
 		}
 
 		return nil, nil
@@ -1128,12 +1307,16 @@ func parseSpecialFunctionDeclaration(
 	functionBlockIsOptional bool,
 	access ast.Access,
 	accessPos *ast.Position,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
 	identifier ast.Identifier,
 ) (*ast.SpecialFunctionDeclaration, error) {
 
 	startPos := identifier.Pos
 	if accessPos != nil {
 		startPos = *accessPos
+	} else if purityPos != nil {
+		startPos = *purityPos
 	}
 
 	// TODO: switch to parseFunctionParameterListAndRest once old parser is deprecated:
@@ -1175,7 +1358,9 @@ func parseSpecialFunctionDeclaration(
 		ast.NewFunctionDeclaration(
 			p.memoryGauge,
 			access,
+			purity,
 			identifier,
+			nil,
 			parameterList,
 			nil,
 			functionBlock,
@@ -1221,3 +1406,57 @@ func parseEnumCase(
 		startPos,
 	), nil
 }
+
+// parseTypeAliasDeclaration parses a type alias declaration.
+//
+//	typeAliasDeclaration : 'typealias' identifier '=' type
+func parseTypeAliasDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) (*ast.TypeAliasDeclaration, error) {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `typealias` keyword
+	p.nextSemanticToken()
+	if !p.current.Is(lexer.TokenIdentifier) {
+		return nil, p.syntaxError(
+			"expected identifier after start of type alias declaration, got %s",
+			p.current.Type,
+		)
+	}
+
+	identifier := p.tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.nextSemanticToken()
+
+	_, err := p.mustOne(lexer.TokenEqual)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	aliasedType, err := parseType(p, lowestBindingPower)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewTypeAliasDeclaration(
+		p.memoryGauge,
+		access,
+		identifier,
+		aliasedType,
+		docString,
+		ast.NewRange(
+			p.memoryGauge,
+			startPos,
+			aliasedType.EndPosition(p.memoryGauge),
+		),
+	), nil
+}
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
@@ -521,6 +522,101 @@ func TestParseFunctionDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("view, without return type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations("view fun foo () { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Purity: ast.FunctionPurityView,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 16, Offset: 16},
+								EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("view, without return type, pub", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations("pub view fun foo () { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Access: ast.AccessPublic,
+					Purity: ast.FunctionPurityView,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+							EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 18, Offset: 18},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+								EndPos:   ast.Position{Line: 1, Column: 22, Offset: 22},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
 	t.Run("without return type, pub", func(t *testing.T) {
 
 		t.Parallel()
@@ -956,6 +1052,96 @@ func TestParseFunctionDeclaration(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations("fun f<T: Integer, U>() {}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "f",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 6, Offset: 6},
+								},
+								TypeBound: &ast.TypeAnnotation{
+									Type: &ast.NominalType{
+										Identifier: ast.Identifier{
+											Identifier: "Integer",
+											Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+										},
+									},
+									StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "U",
+									Pos:        ast.Position{Line: 1, Column: 18, Offset: 18},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+							EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+						},
+					},
+					ParameterList: &ast.ParameterList{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+							EndPos:   ast.Position{Line: 1, Column: 21, Offset: 21},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 21, Offset: 21},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 21, Offset: 21},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 23, Offset: 23},
+								EndPos:   ast.Position{Line: 1, Column: 24, Offset: 24},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("type parameters, missing end", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseDeclarations("fun f<T() {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected comma or end of type parameter list, got '('",
+					Pos:     ast.Position{Offset: 7, Line: 1, Column: 7},
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseAccess(t *testing.T) {
@@ -1743,6 +1929,47 @@ func TestParseCompositeDeclaration(t *testing.T) {
 
 	t.Parallel()
 
+	t.Run("struct, type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations(" pub struct S<T> { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					Access:        ast.AccessPublic,
+					CompositeKind: common.CompositeKindStructure,
+					Identifier: ast.Identifier{
+						Identifier: "S",
+						Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+							EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+					},
+				},
+			},
+			result,
+		)
+	})
+
 	t.Run("struct, no conformances", func(t *testing.T) {
 
 		t.Parallel()
@@ -1997,6 +2224,172 @@ func TestParseCompositeDeclaration(t *testing.T) {
 	})
 }
 
+func TestParseViewMembers(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view function and initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations(`
+          struct S {
+              let view: Int
+
+              view init() {
+                  self.view = 1
+              }
+
+              pub view fun foo(): Int {
+                  return self.view
+              }
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.CompositeDeclaration{}, result[0])
+		members := result[0].(*ast.CompositeDeclaration).Members
+
+		fields := members.Fields()
+		require.Len(t, fields, 1)
+		assert.Equal(t, "view", fields[0].Identifier.Identifier)
+
+		initializers := members.Initializers()
+		require.Len(t, initializers, 1)
+		assert.Equal(t,
+			ast.FunctionPurityView,
+			initializers[0].FunctionDeclaration.Purity,
+		)
+		assert.Equal(t,
+			ast.Position{Offset: 65, Line: 5, Column: 14},
+			initializers[0].StartPosition(),
+		)
+
+		functions := members.Functions()
+		require.Len(t, functions, 1)
+		assert.Equal(t, ast.FunctionPurityView, functions[0].Purity)
+		assert.Equal(t, ast.AccessPublic, functions[0].Access)
+	})
+
+	t.Run("invalid view destructor", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseDeclarations(`
+          resource R {
+              view destroy() {}
+          }
+        `)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "invalid view modifier for destroy",
+					Pos:     ast.Position{Offset: 50, Line: 3, Column: 26},
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations(" pub attachment A for R { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					Access:        ast.AccessPublic,
+					CompositeKind: common.CompositeKindAttachment,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+					BaseType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "R",
+							Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 26, Offset: 26},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations(`
+          attachment A for C.R {
+              let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+
+              fun foo(): Int {
+                  return base.y + self.x
+              }
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.CompositeDeclaration{}, result[0])
+		declaration := result[0].(*ast.CompositeDeclaration)
+
+		assert.Equal(t, common.CompositeKindAttachment, declaration.CompositeKind)
+		assert.Equal(t, "C.R", declaration.BaseType.String())
+		assert.Len(t, declaration.Members.Fields(), 1)
+		assert.Len(t, declaration.Members.Initializers(), 1)
+		assert.Len(t, declaration.Members.Functions(), 1)
+	})
+
+	t.Run("missing for", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseDeclarations("attachment A { }")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected \"for\" after attachment name, got '{'",
+					Pos:     ast.Position{Offset: 13, Line: 1, Column: 13},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("attachment as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseStatements("attachment = 1")
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.AssignmentStatement{}, result[0])
+	})
+}
+
 func TestParseInterfaceDeclaration(t *testing.T) {
 
 	t.Parallel()
@@ -5014,3 +5407,94 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		)
 	})
 }
+
+func TestParseTypeAliasDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("top-level, with access and doc string", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations("/// Test\npub typealias Count = Int")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "Count",
+						Pos:        ast.Position{Offset: 23, Line: 2, Column: 14},
+					},
+					AliasedType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "Int",
+							Pos:        ast.Position{Offset: 31, Line: 2, Column: 22},
+						},
+					},
+					DocString: " Test",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 9, Line: 2, Column: 0},
+						EndPos:   ast.Position{Offset: 33, Line: 2, Column: 24},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseDeclarations("contract C { typealias Counts = [Int] }")
+		require.Empty(t, errs)
+		require.Len(t, result, 1)
+
+		utils.AssertEqualWithDiff(t,
+			[]*ast.TypeAliasDeclaration{
+				{
+					Access: ast.AccessNotSpecified,
+					Identifier: ast.Identifier{
+						Identifier: "Counts",
+						Pos:        ast.Position{Offset: 23, Line: 1, Column: 23},
+					},
+					AliasedType: &ast.VariableSizedType{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "Int",
+								Pos:        ast.Position{Offset: 33, Line: 1, Column: 33},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 32, Line: 1, Column: 32},
+							EndPos:   ast.Position{Offset: 36, Line: 1, Column: 36},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 13, Line: 1, Column: 13},
+						EndPos:   ast.Position{Offset: 36, Line: 1, Column: 36},
+					},
+				},
+			},
+			result[0].DeclarationMembers().TypeAliases(),
+		)
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseDeclarations("typealias Count Int")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected token '='",
+					Pos:     ast.Position{Offset: 16, Line: 1, Column: 16},
+				},
+			},
+			errs,
+		)
+	})
+}
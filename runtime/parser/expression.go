@@ -501,6 +501,7 @@ func init() {
 	})
 
 	defineNestedExpression()
+	defineStringTemplateExpression()
 	defineInvocationExpression()
 	defineArrayExpression()
 	defineDictionaryExpression()
@@ -814,8 +815,53 @@ func defineIdentifierExpression() {
 					token.Range.StartPos,
 				), nil
 
+			case keywordAttach:
+				// `attach` is not a reserved keyword, so it only denotes
+				// an attach expression if it is followed by an identifier
+
+				cursor := p.tokens.Cursor()
+				current := p.current
+
+				p.skipSpaceAndComments()
+
+				if p.current.Is(lexer.TokenIdentifier) {
+					return parseAttachExpressionRemainder(p, token)
+				}
+
+				p.tokens.Revert(cursor)
+				p.current = current
+
+				return ast.NewIdentifierExpression(
+					p.memoryGauge,
+					p.tokenToIdentifier(token),
+				), nil
+
 			case keywordFun:
-				return parseFunctionExpression(p, token)
+				return parseFunctionExpression(p, token, ast.FunctionPurityUnspecified)
+
+			case keywordView:
+				// `view` is not a reserved keyword, so it only denotes
+				// a view function expression if it is followed by the `fun` keyword
+
+				cursor := p.tokens.Cursor()
+				current := p.current
+
+				p.skipSpaceAndComments()
+
+				if p.isToken(p.current, lexer.TokenIdentifier, keywordFun) {
+					// Skip the `fun` keyword
+					p.next()
+
+					return parseFunctionExpression(p, token, ast.FunctionPurityView)
+				}
+
+				p.tokens.Revert(cursor)
+				p.current = current
+
+				return ast.NewIdentifierExpression(
+					p.memoryGauge,
+					p.tokenToIdentifier(token),
+				), nil
 
 			default:
 				return ast.NewIdentifierExpression(
@@ -827,7 +873,11 @@ func defineIdentifierExpression() {
 	})
 }
 
-func parseFunctionExpression(p *parser, token lexer.Token) (*ast.FunctionExpression, error) {
+func parseFunctionExpression(
+	p *parser,
+	token lexer.Token,
+	purity ast.FunctionPurity,
+) (*ast.FunctionExpression, error) {
 
 	parameterList, returnTypeAnnotation, functionBlock, err :=
 		parseFunctionParameterListAndRest(p, false)
@@ -837,6 +887,7 @@ func parseFunctionExpression(p *parser, token lexer.Token) (*ast.FunctionExpress
 
 	return ast.NewFunctionExpression(
 		p.memoryGauge,
+		purity,
 		parameterList,
 		returnTypeAnnotation,
 		functionBlock,
@@ -928,6 +979,42 @@ func parseCreateExpressionRemainder(p *parser, token lexer.Token) (*ast.CreateEx
 	), nil
 }
 
+// parseAttachExpressionRemainder parses the remainder of an attach expression,
+// i.e. everything after the `attach` keyword.
+//
+//	attachExpression : 'attach' nominalType invocation 'to' expression
+func parseAttachExpressionRemainder(p *parser, token lexer.Token) (*ast.AttachExpression, error) {
+	attachment, err := parseNominalTypeInvocationRemainder(p)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	if !p.isToken(p.current, lexer.TokenIdentifier, keywordTo) {
+		return nil, p.syntaxError(
+			"expected %q after attachment, got %s",
+			keywordTo,
+			p.current.Type,
+		)
+	}
+
+	// Skip the `to` keyword
+	p.next()
+
+	base, err := parseExpression(p, lowestBindingPower)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewAttachExpression(
+		p.memoryGauge,
+		base,
+		attachment,
+		token.StartPos,
+	), nil
+}
+
 // Invocation Expression Grammar:
 //
 //	invocation : '(' ( argument ( ',' argument )* )? ')'
@@ -1084,6 +1171,86 @@ func defineNestedExpression() {
 	)
 }
 
+func defineStringTemplateExpression() {
+	setExprNullDenotation(
+		lexer.TokenStringTemplateStart,
+		func(p *parser, startToken lexer.Token) (ast.Expression, error) {
+			values := []string{
+				parseStringTemplatePart(p, startToken),
+			}
+			var expressions []ast.Expression
+
+			for {
+				expression, err := parseExpression(p, lowestBindingPower)
+				if err != nil {
+					return nil, err
+				}
+				expressions = append(expressions, expression)
+
+				p.skipSpaceAndComments()
+
+				token := p.current
+
+				switch token.Type {
+				case lexer.TokenStringTemplateMiddle:
+					p.next()
+					values = append(values, parseStringTemplatePart(p, token))
+
+				case lexer.TokenStringTemplateEnd:
+					p.next()
+					values = append(values, parseStringTemplatePart(p, token))
+
+					return ast.NewStringTemplateExpression(
+						p.memoryGauge,
+						values,
+						expressions,
+						ast.NewRange(
+							p.memoryGauge,
+							startToken.StartPos,
+							token.EndPos,
+						),
+					), nil
+
+				default:
+					return nil, p.syntaxError(
+						"expected ')' at end of string template interpolation, got %s",
+						token.Type,
+					)
+				}
+			}
+		},
+	)
+}
+
+// parseStringTemplatePart parses the literal part of a string template token.
+//
+// The source of the token starts with the opening quote of the string template,
+// or with the closing parenthesis of the preceding interpolation,
+// and ends with the start of the following interpolation (`\(`),
+// or with the closing quote of the string template.
+func parseStringTemplatePart(p *parser, token lexer.Token) string {
+	literal := p.tokenSource(token)
+
+	// skip the opening quote or the closing parenthesis
+	content := literal[1:]
+
+	switch token.Type {
+	case lexer.TokenStringTemplateStart, lexer.TokenStringTemplateMiddle:
+		// skip the start of the interpolation
+		content = content[:len(content)-2]
+
+	case lexer.TokenStringTemplateEnd:
+		length := len(content)
+		if length > 0 && content[length-1] == '"' {
+			content = content[:length-1]
+		} else {
+			p.reportSyntaxError("invalid end of string literal: missing '\"'")
+		}
+	}
+
+	return parseStringLiteralContent(p, content)
+}
+
 func defineArrayExpression() {
 	setExprNullDenotation(
 		lexer.TokenBracketOpen,
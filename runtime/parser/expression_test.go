@@ -2702,6 +2702,43 @@ func TestParseCreate(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("create R<@T>()")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.CreateExpression{
+				InvocationExpression: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "R",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					TypeArguments: []*ast.TypeAnnotation{
+						{
+							IsResource: true,
+							Type: &ast.NominalType{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+								},
+							},
+							StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 12, Offset: 12},
+					EndPos:            ast.Position{Line: 1, Column: 13, Offset: 13},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseNil(t *testing.T) {
@@ -2862,6 +2899,70 @@ func TestParseFunctionExpression(t *testing.T) {
 	})
 }
 
+func TestParseViewFunctionExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("view fun () { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.FunctionExpression{
+				Purity: ast.FunctionPurityView,
+				ParameterList: &ast.ParameterList{
+					Parameters: nil,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+				ReturnTypeAnnotation: &ast.TypeAnnotation{
+					IsResource: false,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "",
+							Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+				FunctionBlock: &ast.FunctionBlock{
+					Block: &ast.Block{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 12, Offset: 12},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("view as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("view")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "view",
+					Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseIntegerLiterals(t *testing.T) {
 
 	t.Parallel()
@@ -6295,3 +6396,266 @@ func (g *limitingMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
 
 	return nil
 }
+
+func TestParseAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("attach A(1) to <-r")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.AttachExpression{
+				Base: &ast.UnaryExpression{
+					Operation: ast.OperationMove,
+					Expression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "r",
+							Pos:        ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+				},
+				Attachment: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					Arguments: []*ast.Argument{
+						{
+							Expression: &ast.IntegerExpression{
+								PositiveLiteral: []byte("1"),
+								Value:           big.NewInt(1),
+								Base:            10,
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+									EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+								},
+							},
+							TrailingSeparatorPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+					EndPos:            ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing to", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseExpression("attach A() <-r")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected \"to\" after attachment, got '<-'",
+					Pos:     ast.Position{Offset: 11, Line: 1, Column: 11},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("attach as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("attach")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "attach",
+					Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
+func TestParseStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"Hello, \(name)!"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"Hello, ", "!"},
+				Expressions: []ast.Expression{
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "name",
+							Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 16, Offset: 16},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("escapes", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"\t\(a)\n"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"\t", "\n"},
+				Expressions: []ast.Expression{
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "a",
+							Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"\("\(a)")"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"", ""},
+				Expressions: []ast.Expression{
+					&ast.StringTemplateExpression{
+						Values: []string{"", ""},
+						Expressions: []ast.Expression{
+							&ast.IdentifierExpression{
+								Identifier: ast.Identifier{
+									Identifier: "a",
+									Pos:        ast.Position{Line: 1, Column: 6, Offset: 6},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"\(a) and \(f(b))"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"", " and ", ""},
+				Expressions: []ast.Expression{
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "a",
+							Pos:        ast.Position{Line: 1, Column: 3, Offset: 3},
+						},
+					},
+					&ast.InvocationExpression{
+						InvokedExpression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "f",
+								Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+							},
+						},
+						Arguments: []*ast.Argument{
+							{
+								Expression: &ast.IdentifierExpression{
+									Identifier: ast.Identifier{
+										Identifier: "b",
+										Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+									},
+								},
+								TrailingSeparatorPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+							},
+						},
+						ArgumentsStartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+						EndPos:            ast.Position{Line: 1, Column: 15, Offset: 15},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing end of interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseExpression(`"\(a"`)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected ')' at end of string template interpolation, got string",
+					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("missing end of string", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseExpression(`"\(a)`)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "invalid end of string literal: missing '\"'",
+					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
+				},
+			},
+			errs,
+		)
+	})
+}
@@ -175,17 +175,137 @@ func parseParameter(p *parser) (*ast.Parameter, error) {
 	), nil
 }
 
+// parseTypeParameterList parses an optional type parameter list.
+//
+//	typeParameterList : '<' ( typeParameter ( ',' typeParameter )* )? '>'
+func parseTypeParameterList(p *parser) (*ast.TypeParameterList, error) {
+	var typeParameters []*ast.TypeParameter
+
+	p.skipSpaceAndComments()
+
+	if !p.current.Is(lexer.TokenLess) {
+		return nil, nil
+	}
+
+	startPos := p.current.StartPos
+	// Skip the opening angle bracket
+	p.next()
+
+	var endPos ast.Position
+
+	expectTypeParameter := true
+
+	atEnd := false
+	for !atEnd {
+		p.skipSpaceAndComments()
+		switch p.current.Type {
+		case lexer.TokenIdentifier:
+			if !expectTypeParameter {
+				return nil, p.syntaxError(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				)
+			}
+			typeParameter, err := parseTypeParameter(p)
+			if err != nil {
+				return nil, err
+			}
+
+			typeParameters = append(typeParameters, typeParameter)
+			expectTypeParameter = false
+
+		case lexer.TokenComma:
+			if expectTypeParameter {
+				return nil, p.syntaxError(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				)
+			}
+			// Skip the comma
+			p.next()
+			expectTypeParameter = true
+
+		case lexer.TokenGreater:
+			endPos = p.current.EndPos
+			// Skip the closing angle bracket
+			p.next()
+			atEnd = true
+
+		case lexer.TokenEOF:
+			return nil, p.syntaxError(
+				"missing %s at end of type parameter list",
+				lexer.TokenGreater,
+			)
+
+		default:
+			if expectTypeParameter {
+				return nil, p.syntaxError(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				)
+			} else {
+				return nil, p.syntaxError(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				)
+			}
+		}
+	}
+
+	return ast.NewTypeParameterList(
+		p.memoryGauge,
+		typeParameters,
+		ast.NewRange(
+			p.memoryGauge,
+			startPos,
+			endPos,
+		),
+	), nil
+}
+
+// parseTypeParameter parses a type parameter with an optional type bound.
+//
+//	typeParameter : identifier ( ':' typeAnnotation )?
+func parseTypeParameter(p *parser) (*ast.TypeParameter, error) {
+	identifier := p.tokenToIdentifier(p.current)
+
+	// Skip the identifier
+	p.nextSemanticToken()
+
+	var typeBound *ast.TypeAnnotation
+	if p.current.Is(lexer.TokenColon) {
+		// Skip the colon
+		p.nextSemanticToken()
+
+		var err error
+		typeBound, err = parseTypeAnnotation(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ast.NewTypeParameter(
+		p.memoryGauge,
+		identifier,
+		typeBound,
+	), nil
+}
+
 func parseFunctionDeclaration(
 	p *parser,
 	functionBlockIsOptional bool,
 	access ast.Access,
 	accessPos *ast.Position,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
 	docString string,
 ) (*ast.FunctionDeclaration, error) {
 
 	startPos := p.current.StartPos
 	if accessPos != nil {
 		startPos = *accessPos
+	} else if purityPos != nil {
+		startPos = *purityPos
 	}
 
 	// Skip the `fun` keyword
@@ -202,6 +322,11 @@ func parseFunctionDeclaration(
 	// Skip the identifier
 	p.next()
 
+	typeParameterList, err := parseTypeParameterList(p)
+	if err != nil {
+		return nil, err
+	}
+
 	parameterList, returnTypeAnnotation, functionBlock, err :=
 		parseFunctionParameterListAndRest(p, functionBlockIsOptional)
 
@@ -212,7 +337,9 @@ func parseFunctionDeclaration(
 	return ast.NewFunctionDeclaration(
 		p.memoryGauge,
 		access,
+		purity,
 		identifier,
+		typeParameterList,
 		parameterList,
 		returnTypeAnnotation,
 		functionBlock,
@@ -276,3 +403,34 @@ func parseFunctionParameterListAndRest(
 
 	return
 }
+
+// parseViewFunctionModifier parses an optional `view` modifier of a function.
+//
+// `view` is not a reserved keyword, so the current token is only
+// treated as a modifier if it is followed by the `fun` keyword.
+// Otherwise, the current token is left untouched, as it might be an identifier.
+//
+//	viewFunctionModifier : ( 'view' )?
+func parseViewFunctionModifier(p *parser) (purity ast.FunctionPurity, purityPos *ast.Position) {
+	if !p.isToken(p.current, lexer.TokenIdentifier, keywordView) {
+		return ast.FunctionPurityUnspecified, nil
+	}
+
+	// Look ahead for the `fun` keyword
+
+	cursor := p.tokens.Cursor()
+	current := p.current
+
+	// Skip the `view` keyword
+	p.nextSemanticToken()
+
+	if !p.isToken(p.current, lexer.TokenIdentifier, keywordFun) {
+		// Revert back to the `view` identifier
+		p.tokens.Revert(cursor)
+		p.current = current
+		return ast.FunctionPurityUnspecified, nil
+	}
+
+	pos := current.StartPos
+	return ast.FunctionPurityView, &pos
+}
@@ -62,4 +62,10 @@ const (
 	keywordSwitch      = "switch"
 	keywordDefault     = "default"
 	keywordEnum        = "enum"
+	keywordTypeAlias   = "typealias"
+	keywordView        = "view"
+	keywordAttachment  = "attachment"
+	keywordAttach      = "attach"
+	keywordRemove      = "remove"
+	keywordTo          = "to"
 )
@@ -74,6 +74,9 @@ type lexer struct {
 	tokenCount int
 	// memoryGauge is used for metering memory usage
 	memoryGauge common.MemoryGauge
+	// stringTemplateParenDepths contains, for each string template interpolation
+	// currently being scanned, the number of unclosed parentheses in it
+	stringTemplateParenDepths []int
 }
 
 var _ TokenStream = &lexer{}
@@ -130,6 +133,7 @@ func (l *lexer) clear() {
 	l.cursor = 0
 	l.tokens = l.tokens[:0]
 	l.tokenCount = 0
+	l.stringTemplateParenDepths = l.stringTemplateParenDepths[:0]
 }
 
 func (l *lexer) Reclaim() {
@@ -386,25 +390,67 @@ func (l *lexer) acceptWhile(f func(rune) bool) {
 	}
 }
 
-func (l *lexer) scanString(quote rune) {
+// scanString scans the remainder of a string literal,
+// until the closing quote or the start of an interpolation (`\(`).
+// It returns true if the scan ended at the start of an interpolation.
+func (l *lexer) scanString(quote rune) (interpolation bool) {
 	r := l.next()
 	for r != quote {
 		switch r {
 		case '\n', EOF:
 			// NOTE: invalid end of string handled by parser
 			l.backupOne()
-			return
+			return false
 		case '\\':
 			r = l.next()
 			switch r {
 			case '\n', EOF:
 				// NOTE: invalid end of string handled by parser
 				l.backupOne()
-				return
+				return false
+			case '(':
+				return true
 			}
 		}
 		r = l.next()
 	}
+	return false
+}
+
+// startStringTemplateInterpolation records the start of
+// the interpolation of an expression in a string template
+func (l *lexer) startStringTemplateInterpolation() {
+	l.stringTemplateParenDepths = append(l.stringTemplateParenDepths, 0)
+}
+
+// endsStringTemplateInterpolation is called for each closing parenthesis.
+// It returns true if the parenthesis ends the interpolation of an expression
+// in a string template, instead of being part of the expression.
+func (l *lexer) endsStringTemplateInterpolation() bool {
+	count := len(l.stringTemplateParenDepths)
+	if count == 0 {
+		return false
+	}
+
+	lastIndex := count - 1
+	if l.stringTemplateParenDepths[lastIndex] > 0 {
+		l.stringTemplateParenDepths[lastIndex]--
+		return false
+	}
+
+	l.stringTemplateParenDepths = l.stringTemplateParenDepths[:lastIndex]
+	return true
+}
+
+// openParenInStringTemplateInterpolation is called for each opening parenthesis,
+// so a closing parenthesis in an interpolated expression does not end the interpolation
+func (l *lexer) openParenInStringTemplateInterpolation() {
+	count := len(l.stringTemplateParenDepths)
+	if count == 0 {
+		return
+	}
+
+	l.stringTemplateParenDepths[count-1]++
 }
 
 func (l *lexer) scanBinaryRemainder() {
@@ -49,8 +49,12 @@ func rootState(l *lexer) stateFn {
 		case '%':
 			l.emitType(TokenPercent)
 		case '(':
+			l.openParenInStringTemplateInterpolation()
 			l.emitType(TokenParenOpen)
 		case ')':
+			if l.endsStringTemplateInterpolation() {
+				return stringTemplateRemainderState
+			}
 			l.emitType(TokenParenClose)
 		case '{':
 			l.emitType(TokenBraceOpen)
@@ -293,11 +297,27 @@ func identifierState(l *lexer) stateFn {
 }
 
 func stringState(l *lexer) stateFn {
-	l.scanString('"')
+	if l.scanString('"') {
+		l.emitType(TokenStringTemplateStart)
+		l.startStringTemplateInterpolation()
+		return rootState
+	}
 	l.emitType(TokenString)
 	return rootState
 }
 
+// stringTemplateRemainderState returns a stateFn that scans the remainder
+// of a string template after the end of an interpolation
+func stringTemplateRemainderState(l *lexer) stateFn {
+	if l.scanString('"') {
+		l.emitType(TokenStringTemplateMiddle)
+		l.startStringTemplateInterpolation()
+		return rootState
+	}
+	l.emitType(TokenStringTemplateEnd)
+	return rootState
+}
+
 func lineCommentState(l *lexer) stateFn {
 	l.scanLineComment()
 	l.emitType(TokenLineComment)
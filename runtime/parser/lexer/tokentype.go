@@ -81,6 +81,15 @@ const (
 	TokenAsExclamationMark
 	TokenAsQuestionMark
 	TokenPragma
+	// TokenStringTemplateStart is the start of a string template,
+	// up to and including the start of the first interpolation, e.g. `"a \(`
+	TokenStringTemplateStart
+	// TokenStringTemplateMiddle is the part of a string template between two interpolations,
+	// including the end of the preceding and the start of the following interpolation, e.g. `) b \(`
+	TokenStringTemplateMiddle
+	// TokenStringTemplateEnd is the end of a string template,
+	// starting with the end of the last interpolation, e.g. `) c"`
+	TokenStringTemplateEnd
 	// NOTE: not an actual token, must be last item
 	TokenMax
 )
@@ -202,6 +211,12 @@ func (t TokenType) String() string {
 		return `'as?'`
 	case TokenPragma:
 		return `'#'`
+	case TokenStringTemplateStart:
+		return "string template start"
+	case TokenStringTemplateMiddle:
+		return "string template middle"
+	case TokenStringTemplateEnd:
+		return "string template end"
 	default:
 		panic(errors.NewUnreachableError())
 	}
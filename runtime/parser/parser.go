@@ -241,6 +241,26 @@ func (p *parser) nextSemanticToken() {
 	p.skipSpaceAndComments()
 }
 
+// isNextSemanticTokenIdentifier returns true if the semantic token
+// following the current token is an identifier.
+// It does not advance the parser.
+//
+// This is used to disambiguate soft keywords, e.g. `attach` and `remove`,
+// which are otherwise valid identifiers
+func (p *parser) isNextSemanticTokenIdentifier() bool {
+	cursor := p.tokens.Cursor()
+	current := p.current
+
+	defer func() {
+		p.tokens.Revert(cursor)
+		p.current = current
+	}()
+
+	p.nextSemanticToken()
+
+	return p.current.Is(lexer.TokenIdentifier)
+}
+
 func (p *parser) mustOne(tokenType lexer.TokenType) (lexer.Token, error) {
 	t := p.current
 	if !t.Is(tokenType) {
@@ -621,10 +641,28 @@ func ParseArgumentList(input []byte, memoryGauge common.MemoryGauge) (arguments
 	return
 }
 
+// Config configures the parsing of programs
+type Config struct {
+	// Comments enables the recording of all comments in the program, see ast.Program.Comments.
+	// Comments are dropped by default
+	Comments bool
+}
+
 func ParseProgram(code []byte, memoryGauge common.MemoryGauge) (program *ast.Program, err error) {
+	return ParseProgramWithConfig(code, memoryGauge, Config{})
+}
+
+func ParseProgramWithConfig(
+	code []byte,
+	memoryGauge common.MemoryGauge,
+	config Config,
+) (
+	program *ast.Program,
+	err error,
+) {
 	tokens := lexer.Lex(code, memoryGauge)
 	defer tokens.Reclaim()
-	return ParseProgramFromTokenStream(tokens, memoryGauge)
+	return parseProgramFromTokenStream(tokens, memoryGauge, config)
 }
 
 func ParseProgramFromTokenStream(
@@ -633,6 +671,17 @@ func ParseProgramFromTokenStream(
 ) (
 	program *ast.Program,
 	err error,
+) {
+	return parseProgramFromTokenStream(input, memoryGauge, Config{})
+}
+
+func parseProgramFromTokenStream(
+	input lexer.TokenStream,
+	memoryGauge common.MemoryGauge,
+	config Config,
+) (
+	program *ast.Program,
+	err error,
 ) {
 	var res any
 	var errs []error
@@ -659,11 +708,71 @@ func ParseProgramFromTokenStream(
 		panic(errors.NewUnreachableError())
 	}
 
-	program = ast.NewProgram(memoryGauge, declarations)
+	if config.Comments {
+		program = ast.NewProgramWithComments(
+			memoryGauge,
+			declarations,
+			parseComments(input, memoryGauge),
+		)
+	} else {
+		program = ast.NewProgram(memoryGauge, declarations)
+	}
 
 	return
 }
 
+// parseComments returns all comments in the given token stream.
+//
+// The parser may backtrack and replay tokens, so the comments are not recorded during parsing,
+// but the whole token stream is scanned again afterwards
+func parseComments(tokens lexer.TokenStream, memoryGauge common.MemoryGauge) []*ast.Comment {
+	var comments []*ast.Comment
+
+	input := tokens.Input()
+
+	newComment := func(commentRange ast.Range) *ast.Comment {
+		text := input[commentRange.StartPos.Offset : commentRange.EndPos.Offset+1]
+		common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(len(text)))
+		return ast.NewComment(memoryGauge, string(text), commentRange)
+	}
+
+	tokens.Revert(0)
+
+	// Block comments may be nested
+	depth := 0
+	var blockCommentStartPos ast.Position
+
+	for {
+		token := tokens.Next()
+
+		switch token.Type {
+		case lexer.TokenEOF:
+			return comments
+
+		case lexer.TokenLineComment:
+			comments = append(comments, newComment(token.Range))
+
+		case lexer.TokenBlockCommentStart:
+			if depth == 0 {
+				blockCommentStartPos = token.StartPos
+			}
+			depth++
+
+		case lexer.TokenBlockCommentEnd:
+			depth--
+			if depth == 0 {
+				comments = append(
+					comments,
+					newComment(ast.Range{
+						StartPos: blockCommentStartPos,
+						EndPos:   token.EndPos,
+					}),
+				)
+			}
+		}
+	}
+}
+
 func ParseProgramFromFile(
 	filename string,
 	memoryGauge common.MemoryGauge,
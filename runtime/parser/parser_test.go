@@ -1005,3 +1005,141 @@ func TestParseWhitespaceAtEnd(t *testing.T) {
 
 	assert.Empty(t, errs)
 }
+
+func TestParseProgramComments(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `// a
+let x = 1 /* b /* c */ */
+/// d
+fun f() {
+    let y = g < /* e */ h > (/** f */ 1) // g
+}
+`
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgram([]byte(code), nil)
+		require.NoError(t, err)
+
+		assert.Nil(t, program.Comments())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithConfig(
+			[]byte(code),
+			nil,
+			Config{Comments: true},
+		)
+		require.NoError(t, err)
+
+		utils.AssertEqualWithDiff(t,
+			[]*ast.Comment{
+				{
+					Text: "// a",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 3, Line: 1, Column: 3},
+					},
+				},
+				{
+					Text: "/* b /* c */ */",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 15, Line: 2, Column: 10},
+						EndPos:   ast.Position{Offset: 29, Line: 2, Column: 24},
+					},
+				},
+				{
+					Text: "/// d",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 31, Line: 3, Column: 0},
+						EndPos:   ast.Position{Offset: 35, Line: 3, Column: 4},
+					},
+				},
+				{
+					Text: "/* e */",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 63, Line: 5, Column: 16},
+						EndPos:   ast.Position{Offset: 69, Line: 5, Column: 22},
+					},
+				},
+				{
+					Text: "/** f */",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 76, Line: 5, Column: 29},
+						EndPos:   ast.Position{Offset: 83, Line: 5, Column: 36},
+					},
+				},
+				{
+					Text: "// g",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 88, Line: 5, Column: 41},
+						EndPos:   ast.Position{Offset: 91, Line: 5, Column: 44},
+					},
+				},
+			},
+			program.Comments(),
+		)
+	})
+
+	t.Run("metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := makeLimitingMemoryGauge()
+
+		_, err := ParseProgramWithConfig(
+			[]byte(code),
+			gauge,
+			Config{Comments: true},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(6), gauge.totals[common.MemoryKindComment])
+	})
+
+	t.Run("not metered when disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := makeLimitingMemoryGauge()
+
+		_, err := ParseProgram([]byte(code), gauge)
+		require.NoError(t, err)
+
+		assert.Zero(t, gauge.totals[common.MemoryKindComment])
+	})
+
+	t.Run("fatal error from lack of memory", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := makeLimitingMemoryGauge()
+		gauge.Limit(common.MemoryKindComment, 5)
+
+		var panicMsg any
+		(func() {
+			defer func() {
+				panicMsg = recover()
+			}()
+
+			_, _ = ParseProgramWithConfig(
+				[]byte(code),
+				gauge,
+				Config{Comments: true},
+			)
+		})()
+
+		require.IsType(t, errors.MemoryError{}, panicMsg)
+
+		fatalError, _ := panicMsg.(errors.MemoryError)
+		var expectedError limitingMemoryGaugeError
+		assert.ErrorAs(t, fatalError, &expectedError)
+	})
+}
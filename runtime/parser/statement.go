@@ -94,10 +94,30 @@ func parseStatement(p *parser) (ast.Statement, error) {
 			return parseForStatement(p)
 		case keywordEmit:
 			return parseEmitStatement(p)
+		case keywordRemove:
+			// The `remove` keyword is a soft keyword:
+			// it only introduces a remove statement if an identifier follows
+			if p.isNextSemanticTokenIdentifier() {
+				return parseRemoveStatement(p)
+			}
 		case keywordFun:
 			// The `fun` keyword is ambiguous: it either introduces a function expression
 			// or a function declaration, depending on if an identifier follows, or not.
-			return parseFunctionDeclarationOrFunctionExpressionStatement(p)
+			return parseFunctionDeclarationOrFunctionExpressionStatement(
+				p,
+				ast.FunctionPurityUnspecified,
+				nil,
+			)
+
+		case keywordView:
+			purity, purityPos := parseViewFunctionModifier(p)
+			if purity != ast.FunctionPurityUnspecified {
+				return parseFunctionDeclarationOrFunctionExpressionStatement(
+					p,
+					purity,
+					purityPos,
+				)
+			}
 		}
 	}
 
@@ -151,9 +171,16 @@ func parseStatement(p *parser) (ast.Statement, error) {
 	}
 }
 
-func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) (ast.Statement, error) {
+func parseFunctionDeclarationOrFunctionExpressionStatement(
+	p *parser,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
+) (ast.Statement, error) {
 
 	startPos := p.current.StartPos
+	if purityPos != nil {
+		startPos = *purityPos
+	}
 
 	// Skip the `fun` keyword
 	p.nextSemanticToken()
@@ -163,6 +190,11 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) (ast.State
 
 		p.next()
 
+		typeParameterList, err := parseTypeParameterList(p)
+		if err != nil {
+			return nil, err
+		}
+
 		parameterList, returnTypeAnnotation, functionBlock, err :=
 			parseFunctionParameterListAndRest(p, false)
 
@@ -173,7 +205,9 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) (ast.State
 		return ast.NewFunctionDeclaration(
 			p.memoryGauge,
 			ast.AccessNotSpecified,
+			purity,
 			identifier,
+			typeParameterList,
 			parameterList,
 			returnTypeAnnotation,
 			functionBlock,
@@ -191,6 +225,7 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) (ast.State
 			p.memoryGauge,
 			ast.NewFunctionExpression(
 				p.memoryGauge,
+				purity,
 				parameterList,
 				returnTypeAnnotation,
 				functionBlock,
@@ -601,6 +636,46 @@ func parseEmitStatement(p *parser) (*ast.EmitStatement, error) {
 	return ast.NewEmitStatement(p.memoryGauge, invocation, startPos), nil
 }
 
+// parseRemoveStatement parses a remove statement.
+//
+//	removeStatement : 'remove' nominalType 'from' expression
+func parseRemoveStatement(p *parser) (*ast.RemoveStatement, error) {
+	startPos := p.current.StartPos
+
+	// Skip the `remove` keyword
+	p.nextSemanticToken()
+
+	attachmentToken, err := p.mustOne(lexer.TokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := parseNominalTypeRemainder(p, attachmentToken)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaceAndComments()
+
+	if !p.isToken(p.current, lexer.TokenIdentifier, keywordFrom) {
+		return nil, p.syntaxError(
+			"expected %q after attachment type, got %s",
+			keywordFrom,
+			p.current.Type,
+		)
+	}
+
+	// Skip the `from` keyword
+	p.next()
+
+	value, err := parseExpression(p, lowestBindingPower)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewRemoveStatement(p.memoryGauge, attachment, value, startPos), nil
+}
+
 func parseSwitchStatement(p *parser) (*ast.SwitchStatement, error) {
 
 	startPos := p.current.StartPos
@@ -2477,3 +2477,128 @@ func TestParseStatementsWithWhitespace(t *testing.T) {
 		require.Len(t, statements, 1)
 	})
 }
+
+func TestParseRemoveStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseStatements("remove A from r")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.RemoveStatement{
+					Attachment: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					Value: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "r",
+							Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseStatements("remove C.A from r")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.RemoveStatement{
+					Attachment: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "C",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+						NestedIdentifiers: []ast.Identifier{
+							{
+								Identifier: "A",
+								Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+							},
+						},
+					},
+					Value: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "r",
+							Pos:        ast.Position{Line: 1, Column: 16, Offset: 16},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing from", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := testParseStatements("remove A r")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected \"from\" after attachment type, got identifier",
+					Pos:     ast.Position{Offset: 9, Line: 1, Column: 9},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("remove as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseStatements("remove(1)")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ExpressionStatement{
+					Expression: &ast.InvocationExpression{
+						InvokedExpression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "remove",
+								Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+							},
+						},
+						Arguments: []*ast.Argument{
+							{
+								Expression: &ast.IntegerExpression{
+									PositiveLiteral: []byte("1"),
+									Value:           big.NewInt(1),
+									Base:            10,
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+										EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+									},
+								},
+								TrailingSeparatorPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+							},
+						},
+						ArgumentsStartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:            ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+			},
+			result,
+		)
+	})
+}
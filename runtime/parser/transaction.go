@@ -86,7 +86,15 @@ func parseTransactionDeclaration(p *parser, docString string) (*ast.TransactionD
 			identifier := p.tokenToIdentifier(p.current)
 			// Skip the `prepare` keyword
 			p.next()
-			prepare, err = parseSpecialFunctionDeclaration(p, false, ast.AccessNotSpecified, nil, identifier)
+			prepare, err = parseSpecialFunctionDeclaration(
+				p,
+				false,
+				ast.AccessNotSpecified,
+				nil,
+				ast.FunctionPurityUnspecified,
+				nil,
+				identifier,
+			)
 			if err != nil {
 				return nil, err
 			}
@@ -256,9 +264,11 @@ func parseTransactionExecute(p *parser) (*ast.SpecialFunctionDeclaration, error)
 		ast.NewFunctionDeclaration(
 			p.memoryGauge,
 			ast.AccessNotSpecified,
+			ast.FunctionPurityUnspecified,
 			identifier,
 			nil,
 			nil,
+			nil,
 			ast.NewFunctionBlock(
 				p.memoryGauge,
 				block,
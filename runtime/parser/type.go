@@ -630,6 +630,16 @@ func defineFunctionType() {
 		lexer.TokenParenOpen,
 		func(p *parser, startToken lexer.Token) (ast.Type, error) {
 
+			purity := ast.FunctionPurityUnspecified
+
+			p.skipSpaceAndComments()
+			if p.isToken(p.current, lexer.TokenIdentifier, keywordView) {
+				purity = ast.FunctionPurityView
+
+				// Skip the `view` keyword
+				p.next()
+			}
+
 			parameterTypeAnnotations, err := parseParameterTypeAnnotations(p)
 			if err != nil {
 				return nil, err
@@ -655,6 +665,7 @@ func defineFunctionType() {
 
 			return ast.NewFunctionType(
 				p.memoryGauge,
+				purity,
 				parameterTypeAnnotations,
 				returnTypeAnnotation,
 				ast.NewRange(
@@ -864,7 +875,28 @@ func parseNominalTypeInvocationRemainder(p *parser) (*ast.InvocationExpression,
 		return nil, err
 	}
 
+	// Parse optional type arguments, e.g. `create C<T>()`
+
+	var typeArguments []*ast.TypeAnnotation
+
 	p.skipSpaceAndComments()
+	if p.current.Is(lexer.TokenLess) {
+		// Skip the `<` token
+		p.next()
+
+		typeArguments, err = parseCommaSeparatedTypeAnnotations(p, lexer.TokenGreater)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.mustOne(lexer.TokenGreater)
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpaceAndComments()
+	}
+
 	parenOpenToken, err := p.mustOne(lexer.TokenParenOpen)
 	if err != nil {
 		return nil, err
@@ -894,7 +926,7 @@ func parseNominalTypeInvocationRemainder(p *parser) (*ast.InvocationExpression,
 	return ast.NewInvocationExpression(
 		p.memoryGauge,
 		invokedExpression,
-		nil,
+		typeArguments,
 		arguments,
 		argumentsStartPos,
 		endPos,
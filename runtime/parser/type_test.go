@@ -1095,6 +1095,36 @@ func TestParseFunctionType(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("view, no parameters, Void return type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseType("(view ():Void)")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.FunctionType{
+				PurityAnnotation:         ast.FunctionPurityView,
+				ParameterTypeAnnotations: nil,
+				ReturnTypeAnnotation: &ast.TypeAnnotation{
+					IsResource: false,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "Void",
+							Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 13, Offset: 13},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseInstantiationType(t *testing.T) {
@@ -44,9 +44,14 @@ type REPL struct {
 	codes    map[Location][]byte
 }
 
+// NewREPL returns a new REPL.
+//
+// If a debugger is given, the statements of functions declared in the REPL can be debugged,
+// e.g. breakpoints can be added to lines of the REPL's location, common.REPLLocation.
 func NewREPL(
 	onError func(err error, location Location, codes map[Location][]byte),
 	onResult func(interpreter.Value),
+	debugger *interpreter.Debugger,
 ) (*REPL, error) {
 
 	checkers := map[Location]*sema.Checker{}
@@ -81,6 +86,7 @@ func NewREPL(
 			return uuid, nil
 		},
 		BaseActivation: baseActivation,
+		Debugger:       debugger,
 	}
 
 	inter, err := interpreter.NewInterpreter(
@@ -0,0 +1,102 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestREPLDebugger(t *testing.T) {
+
+	t.Parallel()
+
+	debugger := interpreter.NewDebugger()
+
+	var results []string
+	var reportedErrors []error
+
+	repl, err := NewREPL(
+		func(err error, _ Location, _ map[Location][]byte) {
+			reportedErrors = append(reportedErrors, err)
+		},
+		func(value interpreter.Value) {
+			results = append(results, value.String())
+		},
+		debugger,
+	)
+	require.NoError(t, err)
+
+	_, err = repl.Accept([]byte(`
+      fun double(_ x: Int): Int {
+          let y = x * 2
+          return y
+      }
+    `))
+	require.NoError(t, err)
+
+	// Add a breakpoint to the function declared in the REPL
+
+	debugger.AddBreakpoint(common.REPLLocation{}, 3)
+
+	// Invoke the function in a later input.
+	// It will pause/block at the breakpoint,
+	// so run it in a goroutine, and report the result back to the test goroutine
+
+	errs := make(chan error, 1)
+
+	go func() {
+		_, err := repl.Accept([]byte("double(21)\n"))
+		errs <- err
+	}()
+
+	// Wait for the input to run into the breakpoint
+
+	stop := <-debugger.Stops()
+
+	require.Equal(t, common.REPLLocation{}, stop.Interpreter.Location)
+	require.Equal(t, 3, stop.Statement.StartPosition().Line)
+	require.Len(t, stop.CallStack, 2)
+	require.Equal(t, "double", stop.CallStack[0].FunctionName)
+	// The invocation is on the line following the lines of the first input
+	require.Equal(t, 6, stop.CallStack[1].Range.StartPos.Line)
+
+	// Step over the variable declaration
+
+	stop = debugger.StepOver()
+
+	require.Equal(t, 4, stop.Statement.StartPosition().Line)
+
+	variable := debugger.CurrentActivation(stop.Interpreter).Find("y")
+	require.NotNil(t, variable)
+	require.Equal(t, "42", variable.GetValue().String())
+
+	debugger.Continue()
+
+	// Wait for the input to finish execution
+
+	require.NoError(t, <-errs)
+	require.Empty(t, reportedErrors)
+
+	require.Equal(t, []string{"42"}, results)
+}
@@ -166,6 +166,7 @@ var validTopLevelDeclarationsInTransaction = []common.DeclarationKind{
 	common.DeclarationKindImport,
 	common.DeclarationKindFunction,
 	common.DeclarationKindTransaction,
+	common.DeclarationKindTypeAlias,
 }
 
 var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
@@ -2620,6 +2620,7 @@ func TestRuntimeScriptReturnSpecial(t *testing.T) {
                 `,
 				expected: cadence.Function{
 					FunctionType: (&cadence.FunctionType{
+						Purity: cadence.FunctionPurityView,
 						Parameters: []cadence.Parameter{
 							{
 								Label:      sema.ArgumentLabelNotRequired,
@@ -7546,3 +7547,44 @@ func TestImportingTestStdlib(t *testing.T) {
 	require.ErrorAs(t, errs[0], &notDeclaredErr)
 	assert.Equal(t, "Test", notDeclaredErr.Name)
 }
+
+func TestRuntimeConditionPurityCheck(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+	}
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              pub fun increment(_ counter: &[Int]): Bool {
+                  counter.append(1)
+                  return true
+              }
+
+              pub fun test(_ counter: &[Int]) {
+                  pre { increment(counter) }
+              }
+
+              pub fun main(): Int {
+                  let counter: [Int] = []
+                  test(&counter as &[Int])
+                  return counter.length
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	RequireError(t, err)
+
+	errs := checker.RequireCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.PurityError{}, errs[0])
+}
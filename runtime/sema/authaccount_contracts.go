@@ -168,6 +168,7 @@ Returns nil if no contract/contract interface with the given name exists in the
 `
 
 var AuthAccountContractsTypeGetFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Identifier: "name",
@@ -0,0 +1,114 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitAttachExpression(expression *ast.AttachExpression) Type {
+
+	// Attaching modifies the base value
+
+	checker.observeImpureOperation(expression)
+
+	// NOTE: check the attachment before the base,
+	// as the arguments of the attachment constructor are evaluated first,
+	// and they may still use the base, e.g. `attach A(r.id) to <-r`
+
+	// NOTE: check the invocation directly instead of visiting it,
+	// as attachments can only be constructed in an attach expression
+
+	attachment := expression.Attachment
+
+	attachmentType := checker.checkInvocationExpression(attachment)
+
+	baseType := checker.VisitExpression(expression.Base, nil)
+
+	checker.checkResourceMoveOperation(expression.Base, baseType)
+
+	if attachmentType.IsInvalidType() || baseType.IsInvalidType() {
+		return baseType
+	}
+
+	compositeType, ok := attachmentType.(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&NotAnAttachmentError{
+				Type:  attachmentType,
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, attachment),
+			},
+		)
+		return baseType
+	}
+
+	checker.checkAttachmentBase(compositeType, baseType, expression.Base)
+
+	// The result of attaching is the base value
+
+	return baseType
+}
+
+// checkAttachmentBase checks that the given attachment type
+// can be attached to a value of the given base type
+func (checker *Checker) checkAttachmentBase(
+	attachmentType *CompositeType,
+	baseType Type,
+	baseExpression ast.Expression,
+) {
+	if baseType.IsInvalidType() ||
+		attachmentType.AttachmentBaseType.IsInvalidType() {
+
+		return
+	}
+
+	if isAttachableType(baseType) &&
+		IsSubType(baseType, attachmentType.AttachmentBaseType) {
+
+		return
+	}
+
+	checker.report(
+		&InvalidAttachmentBaseError{
+			AttachmentType: attachmentType,
+			ActualType:     baseType,
+			Range:          ast.NewRangeFromPositioned(checker.memoryGauge, baseExpression),
+		},
+	)
+}
+
+// isAttachableType returns true if values of the given type can have attachments,
+// i.e. if the values are structures or resources
+func isAttachableType(ty Type) bool {
+	switch ty := ty.(type) {
+	case *CompositeType:
+		switch ty.Kind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			return true
+		}
+
+	case *RestrictedType:
+		return isAttachableType(ty.Type)
+	}
+
+	return false
+}
@@ -73,7 +73,27 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 	}
 
+	// Type requirements cannot be generic,
+	// as conformances cannot be checked for them
+
+	if kind == ContainerKindInterface && len(compositeType.typeParameters) > 0 {
+		checker.report(
+			&InvalidTypeParametersError{
+				DeclarationKind:   declaration.DeclarationKind(),
+				IsTypeRequirement: true,
+				Range:             declaration.TypeParameterList.Range,
+			},
+		)
+	}
+
+	checker.declareTypeParameters(
+		declaration.TypeParameterList,
+		compositeType.typeParameters,
+		true,
+	)
+
 	checker.declareCompositeNestedTypes(declaration, kind, true)
+	checker.declareTypeAliases(declaration.Members.TypeAliases(), compositeType)
 
 	var initializationInfo *InitializationInfo
 
@@ -136,11 +156,17 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 		return compositeType.FieldPosition(name, declaration)
 	}
 
-	checker.checkResourceFieldNesting(
-		compositeType.Members,
-		compositeType.Kind,
-		fieldPositionGetter,
-	)
+	// Resource attachments may have resource fields, like resources
+
+	if compositeType.Kind != common.CompositeKindAttachment ||
+		!compositeType.IsResourceType() {
+
+		checker.checkResourceFieldNesting(
+			compositeType.Members,
+			compositeType.Kind,
+			fieldPositionGetter,
+		)
+	}
 
 	// Check conformances
 	// NOTE: perform after completing composite type (e.g. setting constructor parameter types)
@@ -204,6 +230,10 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 	for _, nestedComposite := range declaration.Members.Composites() {
 		ast.AcceptDeclaration[struct{}](nestedComposite, checker)
 	}
+
+	for _, nestedTypeAlias := range declaration.Members.TypeAliases() {
+		ast.AcceptDeclaration[struct{}](nestedTypeAlias, checker)
+	}
 }
 
 // declareCompositeNestedTypes declares the types nested in a composite,
@@ -352,17 +382,23 @@ func (checker *Checker) declareNestedDeclarations(
 				common.CompositeKindStructure,
 				common.CompositeKindEvent,
 				common.CompositeKindEnum:
-				break
+				return
 
-			default:
-				checker.report(
-					&InvalidNestedDeclarationError{
-						NestedDeclarationKind:    nestedDeclarationKind,
-						ContainerDeclarationKind: containerDeclarationKind,
-						Range:                    ast.NewRangeFromPositioned(checker.memoryGauge, identifier),
-					},
-				)
+			case common.CompositeKindAttachment:
+				// Attachments may be nested in contracts,
+				// but are not supported as type requirements
+				if containerDeclarationKind == common.DeclarationKindContract {
+					return
+				}
 			}
+
+			checker.report(
+				&InvalidNestedDeclarationError{
+					NestedDeclarationKind:    nestedDeclarationKind,
+					ContainerDeclarationKind: containerDeclarationKind,
+					Range:                    ast.NewRangeFromPositioned(checker.memoryGauge, identifier),
+				},
+			)
 		}
 
 		for _, nestedDeclaration := range nestedInterfaceDeclarations {
@@ -455,6 +491,26 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 			checker.explicitInterfaceConformances(declaration, compositeType)
 	}
 
+	// Determine type parameters, if any.
+	// NOTE: before declaring the members, as they may refer to the type parameters
+
+	if !declaration.TypeParameterList.IsEmpty() {
+		switch declaration.CompositeKind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			compositeType.typeParameters = checker.typeParameters(declaration.TypeParameterList)
+
+		default:
+			checker.report(
+				&InvalidTypeParametersError{
+					DeclarationKind: declaration.DeclarationKind(),
+					Range:           declaration.TypeParameterList.Range,
+				},
+			)
+		}
+	}
+
 	// Register in elaboration
 
 	checker.Elaboration.CompositeDeclarationTypes[declaration] = compositeType
@@ -520,7 +576,21 @@ func (checker *Checker) declareCompositeMembersAndValue(
 		checker.enterValueScope()
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 
+		checker.declareTypeParameters(
+			declaration.TypeParameterList,
+			compositeType.typeParameters,
+			false,
+		)
+
 		checker.declareCompositeNestedTypes(declaration, kind, false)
+		checker.declareTypeAliases(declaration.Members.TypeAliases(), compositeType)
+
+		// NOTE: determine the base type of an attachment before declaring the members,
+		// as it determines if the attachment is a resource
+
+		if compositeType.Kind == common.CompositeKindAttachment {
+			compositeType.AttachmentBaseType = checker.attachmentBaseType(declaration)
+		}
 
 		// NOTE: determine initializer parameter types while nested types are in scope,
 		// and after declaring nested types as the initializer may use nested type in parameters
@@ -866,6 +936,7 @@ func (checker *Checker) declareEnumConstructor(
 
 func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	return &FunctionType{
+		Purity:        FunctionPurityView,
 		IsConstructor: true,
 		Parameters: []*Parameter{
 			{
@@ -882,6 +953,63 @@ func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	}
 }
 
+// attachmentBaseType resolves the base type of the given attachment declaration.
+//
+// Attachments can be declared for structures and resources,
+// structure and resource interfaces (restricted `AnyStruct` or `AnyResource` types),
+// and for all structures or all resources (`AnyStruct` or `AnyResource`).
+func (checker *Checker) attachmentBaseType(declaration *ast.CompositeDeclaration) Type {
+	if declaration.BaseType == nil {
+		return InvalidType
+	}
+
+	baseType := checker.convertNominalType(declaration.BaseType)
+
+	switch baseType := baseType.(type) {
+	case *CompositeType:
+		switch baseType.Kind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			return baseType
+		}
+
+	case *InterfaceType:
+		switch baseType.CompositeKind {
+		case common.CompositeKindStructure:
+			return NewRestrictedType(
+				checker.memoryGauge,
+				AnyStructType,
+				[]*InterfaceType{baseType},
+			)
+
+		case common.CompositeKindResource:
+			return NewRestrictedType(
+				checker.memoryGauge,
+				AnyResourceType,
+				[]*InterfaceType{baseType},
+			)
+		}
+
+	default:
+		switch baseType {
+		case AnyStructType, AnyResourceType:
+			return baseType
+		}
+	}
+
+	if !baseType.IsInvalidType() {
+		checker.report(
+			&InvalidAttachmentBaseTypeError{
+				Type:  baseType,
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, declaration.BaseType),
+			},
+		)
+	}
+
+	return InvalidType
+}
+
 // checkMemberStorability check that all fields have a type that is storable.
 func (checker *Checker) checkMemberStorability(members *StringMemberOrderedMap) {
 
@@ -1248,6 +1376,54 @@ func (checker *Checker) memberSatisfied(compositeMember, interfaceMember *Member
 				return false
 			}
 
+			// Functions must have the same type parameters,
+			// i.e. the same names and type bounds.
+			//
+			// The interface function's type parameters are substituted
+			// with the composite function's type parameters,
+			// so the parameter types and return types can be compared
+
+			interfaceTypeParameters := interfaceMemberFunctionType.TypeParameters
+			compositeTypeParameters := compositeMemberFunctionType.TypeParameters
+
+			if len(compositeTypeParameters) != len(interfaceTypeParameters) {
+				return false
+			}
+
+			if len(interfaceTypeParameters) > 0 {
+				typeArguments := &TypeParameterTypeOrderedMap{}
+
+				for i, interfaceTypeParameter := range interfaceTypeParameters {
+					compositeTypeParameter := compositeTypeParameters[i]
+					if !compositeTypeParameter.Equal(interfaceTypeParameter) {
+						return false
+					}
+
+					typeArguments.Set(
+						interfaceTypeParameter,
+						&GenericType{
+							TypeParameter: compositeTypeParameter,
+						},
+					)
+				}
+
+				resolvedInterfaceMemberType := interfaceMemberFunctionType.Resolve(typeArguments)
+				if resolvedInterfaceMemberType == nil {
+					return false
+				}
+
+				interfaceMemberFunctionType = resolvedInterfaceMemberType.(*FunctionType)
+			}
+
+			// A view function satisfies an impure function,
+			// but an impure function does not satisfy a view function
+
+			if interfaceMemberFunctionType.Purity == FunctionPurityView &&
+				compositeMemberFunctionType.Purity != FunctionPurityView {
+
+				return false
+			}
+
 			// Functions are invariant in their parameter types
 
 			for i, subParameter := range compositeMemberFunctionType.Parameters {
@@ -1434,8 +1610,13 @@ func CompositeConstructorType(
 	argumentLabels []string,
 ) {
 
+	// The constructor of a generic composite type is generic,
+	// the type arguments of the instantiation are inferred from the arguments,
+	// or are given explicitly
+
 	constructorFunctionType = &FunctionType{
 		IsConstructor:        true,
+		TypeParameters:       compositeType.typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(compositeType),
 	}
 
@@ -1452,15 +1633,31 @@ func CompositeConstructorType(
 
 		constructorFunctionType.Parameters = compositeType.ConstructorParameters
 
+		// The constructor is a view function if the initializer is
+
+		constructorFunctionType.Purity =
+			NewFunctionPurity(firstInitializer.FunctionDeclaration.Purity)
+
 		// NOTE: Don't use `constructorFunctionType`, as it has a return type.
 		//   The initializer itself has a `Void` return type.
 
 		elaboration.ConstructorFunctionTypes[firstInitializer] =
 			&FunctionType{
 				IsConstructor:        true,
+				Purity:               constructorFunctionType.Purity,
 				Parameters:           constructorFunctionType.Parameters,
 				ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
 			}
+	} else {
+		// Without an initializer, the constructor has no side effects
+		constructorFunctionType.Purity = FunctionPurityView
+	}
+
+	// Event constructors only initialize the fields of the event,
+	// so they may be invoked in a view context
+
+	if compositeType.Kind == common.CompositeKindEvent {
+		constructorFunctionType.Purity = FunctionPurityView
 	}
 
 	return constructorFunctionType, argumentLabels
@@ -1601,7 +1798,12 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		identifier := function.Identifier.Identifier
 
-		functionType := checker.functionType(function.ParameterList, function.ReturnTypeAnnotation)
+		functionType := checker.functionType(
+			function.Purity,
+			function.TypeParameterList,
+			function.ParameterList,
+			function.ReturnTypeAnnotation,
+		)
 
 		argumentLabels := function.ParameterList.EffectiveArgumentLabels()
 
@@ -1867,7 +2069,15 @@ func (checker *Checker) checkSpecialFunction(
 
 	checker.declareSelfValue(containerType, containerDocString)
 
+	// NOTE: `base` is not available in the initializer of an attachment,
+	// as the attachment is only attached to its base after it was constructed
+
+	if specialFunction.Kind != common.DeclarationKindInitializer {
+		checker.declareAttachmentBaseValue(containerType)
+	}
+
 	functionType := &FunctionType{
+		Purity:               NewFunctionPurity(specialFunction.FunctionDeclaration.Purity),
 		Parameters:           parameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
 	}
@@ -1917,6 +2127,7 @@ func (checker *Checker) checkCompositeFunctions(
 			defer checker.leaveValueScope(function.EndPosition, true)
 
 			checker.declareSelfValue(selfType, selfDocString)
+			checker.declareAttachmentBaseValue(selfType)
 
 			checker.visitFunctionDeclaration(
 				function,
@@ -1961,6 +2172,40 @@ func (checker *Checker) declareSelfValue(selfType Type, selfDocString string) {
 	}
 }
 
+// declareAttachmentBaseValue declares the `base` value,
+// a reference to the value the attachment is attached to,
+// if the given container type is an attachment
+func (checker *Checker) declareAttachmentBaseValue(containerType Type) {
+
+	compositeType, ok := containerType.(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		return
+	}
+
+	// NOTE: declare `base` one depth lower ("inside" function),
+	// so it can't be re-declared by the function's parameters
+
+	depth := checker.valueActivations.Depth() + 1
+
+	base := &Variable{
+		Identifier:      BaseIdentifier,
+		Access:          ast.AccessPublic,
+		DeclarationKind: common.DeclarationKindBase,
+		Type: NewReferenceType(
+			checker.memoryGauge,
+			compositeType.AttachmentBaseType,
+			false,
+		),
+		IsConstant:      true,
+		ActivationDepth: depth,
+		Pos:             nil,
+	}
+	checker.valueActivations.Set(BaseIdentifier, base)
+	if checker.PositionInfo != nil {
+		checker.recordVariableDeclarationOccurrence(BaseIdentifier, base)
+	}
+}
+
 // checkNestedIdentifiers checks that nested identifiers, i.e. fields, functions,
 // and nested interfaces and composites, are unique and aren't named `init` or `destroy`
 func (checker *Checker) checkNestedIdentifiers(members *ast.Members) {
@@ -2196,7 +2441,7 @@ func (checker *Checker) checkDestructor(
 // that all resource fields are invalidated (moved or destroyed)
 func (checker *Checker) checkCompositeResourceInvalidated(containerType Type) {
 	compositeType, isComposite := containerType.(*CompositeType)
-	if !isComposite || compositeType.Kind != common.CompositeKindResource {
+	if !isComposite || !compositeType.IsResourceType() {
 		return
 	}
 
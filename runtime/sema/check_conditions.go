@@ -33,11 +33,16 @@ func (checker *Checker) visitConditions(conditions []*ast.Condition) {
 	}()
 
 	// check all conditions: check the expression
-	// and ensure the result is boolean
-
-	for _, condition := range conditions {
-		checker.checkCondition(condition)
-	}
+	// and ensure the result is boolean.
+	//
+	// conditions must not have side effects,
+	// so they are checked in a view context
+
+	checker.inNewPurityScope(true, func() {
+		for _, condition := range conditions {
+			checker.checkCondition(condition)
+		}
+	})
 }
 
 func (checker *Checker) checkCondition(condition *ast.Condition) Type {
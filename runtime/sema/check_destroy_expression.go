@@ -25,6 +25,8 @@ import (
 func (checker *Checker) VisitDestroyExpression(expression *ast.DestroyExpression) (resultType Type) {
 	resultType = VoidType
 
+	checker.observeImpureOperation(expression)
+
 	valueType := checker.VisitExpression(expression.Expression, nil)
 
 	checker.recordResourceInvalidation(
@@ -112,6 +112,9 @@ func IsValidDictionaryKeyType(keyType Type) bool {
 		return true
 	case *CompositeType:
 		return keyType.Kind == common.CompositeKindEnum
+	case *GenericType:
+		typeBound := keyType.TypeParameter.TypeBound
+		return typeBound != nil && IsValidDictionaryKeyType(typeBound)
 	default:
 		switch keyType {
 		case NeverType, BoolType, CharacterType, StringType, MetaType:
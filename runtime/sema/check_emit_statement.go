@@ -24,6 +24,8 @@ import (
 )
 
 func (checker *Checker) VisitEmitStatement(statement *ast.EmitStatement) (_ struct{}) {
+	checker.observeImpureOperation(statement)
+
 	invocation := statement.InvocationExpression
 
 	ty := checker.checkInvocationExpression(invocation)
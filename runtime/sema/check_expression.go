@@ -165,8 +165,21 @@ func (checker *Checker) VisitNilExpression(_ *ast.NilExpression) Type {
 	return NilType
 }
 
+// unwrapLiteralTargetType returns the type a literal may be inferred as,
+// given the type the literal is expected to have.
+//
+// Literals are never inferred to have a generic type,
+// as the actual type argument is unknown statically.
+func unwrapLiteralTargetType(targetType Type) Type {
+	targetType = UnwrapOptionalType(targetType)
+	if _, ok := targetType.(*GenericType); ok {
+		return nil
+	}
+	return targetType
+}
+
 func (checker *Checker) VisitIntegerExpression(expression *ast.IntegerExpression) Type {
-	expectedType := UnwrapOptionalType(checker.expectedType)
+	expectedType := unwrapLiteralTargetType(checker.expectedType)
 
 	var actualType Type
 	isAddress := false
@@ -198,7 +211,7 @@ func (checker *Checker) VisitFixedPointExpression(expression *ast.FixedPointExpr
 	// If the contextually expected type is a subtype of FixedPoint, then take that.
 	// Otherwise, infer the type from the expression itself.
 
-	expectedType := UnwrapOptionalType(checker.expectedType)
+	expectedType := unwrapLiteralTargetType(checker.expectedType)
 
 	var actualType Type
 
@@ -218,7 +231,7 @@ func (checker *Checker) VisitFixedPointExpression(expression *ast.FixedPointExpr
 }
 
 func (checker *Checker) VisitStringExpression(expression *ast.StringExpression) Type {
-	expectedType := UnwrapOptionalType(checker.expectedType)
+	expectedType := unwrapLiteralTargetType(checker.expectedType)
 
 	var actualType Type = StringType
 
@@ -256,6 +269,14 @@ func (checker *Checker) visitIndexExpression(
 		return InvalidType
 	}
 
+	// Composites, and references to composites,
+	// can be indexed with an attachment type, e.g. `r[A]`
+
+	attachmentType := checker.attachmentAccessType(targetType, indexExpression.IndexingExpression)
+	if attachmentType != nil {
+		return checker.checkAttachmentAccess(indexExpression, targetType, attachmentType, isAssignment)
+	}
+
 	// Check if the type instance is actually indexable. For most types (e.g. arrays and dictionaries)
 	// this is known statically (in the sense of this host language (Go), not the implemented language),
 	// i.e. a Go type switch would be sufficient.
@@ -298,3 +319,68 @@ func (checker *Checker) visitIndexExpression(
 
 	return elementType
 }
+
+// attachmentAccessType returns the attachment type
+// if the given indexing expression refers to an attachment type
+// and values of the given target type can have attachments.
+// Otherwise, it returns nil.
+func (checker *Checker) attachmentAccessType(targetType Type, indexingExpression ast.Expression) *CompositeType {
+
+	if referenceType, ok := targetType.(*ReferenceType); ok {
+		targetType = referenceType.Type
+	}
+
+	if !isAttachableType(targetType) {
+		return nil
+	}
+
+	nominalType, ok := ast.ExpressionAsType(indexingExpression).(*ast.NominalType)
+	if !ok {
+		return nil
+	}
+
+	// Only consider the indexing expression a type if it refers to a declared type,
+	// so that other indexing expressions are still reported as invalid indexing
+
+	if checker.typeActivations.Find(nominalType.Identifier.Identifier) == nil {
+		return nil
+	}
+
+	compositeType, ok := checker.convertNominalType(nominalType).(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		return nil
+	}
+
+	return compositeType
+}
+
+// checkAttachmentAccess checks an access of an attachment, e.g. `r[A]`,
+// and returns the type of the access, an optional reference to the attachment
+func (checker *Checker) checkAttachmentAccess(
+	indexExpression *ast.IndexExpression,
+	targetType Type,
+	attachmentType *CompositeType,
+	isAssignment bool,
+) Type {
+
+	if isAssignment {
+		checker.report(
+			&InvalidAttachmentAssignmentError{
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, indexExpression),
+			},
+		)
+	}
+
+	baseType := targetType
+	if referenceType, ok := targetType.(*ReferenceType); ok {
+		baseType = referenceType.Type
+	}
+
+	checker.checkAttachmentBase(attachmentType, baseType, indexExpression.TargetExpression)
+
+	checker.Elaboration.AttachmentAccessTypes[indexExpression] = attachmentType
+
+	return &OptionalType{
+		Type: NewReferenceType(checker.memoryGauge, attachmentType, false),
+	}
+}
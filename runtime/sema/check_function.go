@@ -70,7 +70,12 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	functionType := checker.Elaboration.FunctionDeclarationFunctionTypes[declaration]
 	if functionType == nil {
-		functionType = checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+		functionType = checker.functionType(
+			declaration.Purity,
+			declaration.TypeParameterList,
+			declaration.ParameterList,
+			declaration.ReturnTypeAnnotation,
+		)
 
 		if options.declareFunction {
 			checker.declareFunctionDeclaration(declaration, functionType)
@@ -79,6 +84,19 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 
+	// Declare the type parameters, if any, so they are also visible in the function's body
+
+	if len(functionType.TypeParameters) > 0 {
+		checker.typeActivations.Enter()
+		defer checker.typeActivations.Leave(declaration.EndPosition)
+
+		checker.declareTypeParameters(
+			declaration.TypeParameterList,
+			functionType.TypeParameters,
+			true,
+		)
+	}
+
 	checker.checkFunction(
 		declaration.ParameterList,
 		declaration.ReturnTypeAnnotation,
@@ -96,7 +114,7 @@ func (checker *Checker) declareFunctionDeclaration(
 ) {
 	argumentLabels := declaration.ParameterList.EffectiveArgumentLabels()
 
-	_, err := checker.valueActivations.declare(variableDeclaration{
+	variable, err := checker.valueActivations.declare(variableDeclaration{
 		identifier:               declaration.Identifier.Identifier,
 		ty:                       functionType,
 		docString:                declaration.DocString,
@@ -110,7 +128,11 @@ func (checker *Checker) declareFunctionDeclaration(
 	checker.report(err)
 
 	if checker.PositionInfo != nil {
-		checker.recordFunctionDeclarationOrigin(declaration, functionType)
+		origin := checker.recordFunctionDeclarationOrigin(declaration, functionType)
+		if variable != nil {
+			// References to the function should refer to the origin of the declaration
+			checker.PositionInfo.VariableOrigins[variable] = origin
+		}
 	}
 }
 
@@ -144,38 +166,46 @@ func (checker *Checker) checkFunction(
 			//   variable declarations will have proper function activation
 			//   associated to it, and declare parameters in this new scope
 
-			var endPosGetter EndPositionGetter
-			if functionBlock != nil {
-				endPosGetter = functionBlock.EndPosition
-			}
-
-			checker.enterValueScope()
-			defer func() {
-				checkResourceLoss := checkResourceLoss &&
-					!functionActivation.ReturnInfo.DefinitelyHalted
-				checker.leaveValueScope(endPosGetter, checkResourceLoss)
-			}()
-
-			checker.declareParameters(parameterList, functionType.Parameters)
-
-			functionActivation.InitializationInfo = initializationInfo
-
-			if functionBlock != nil {
-				checker.visitFunctionBlock(
-					functionBlock,
-					functionType.ReturnTypeAnnotation,
-					checkResourceLoss,
-				)
-
-				if mustExit {
-					returnType := functionType.ReturnTypeAnnotation.Type
-					checker.checkFunctionExits(functionBlock, returnType)
-				}
-			}
-
-			if initializationInfo != nil {
-				checker.checkFieldMembersInitialized(initializationInfo)
-			}
+			// NOTE: begin the purity scope before the value scope of the function,
+			//   so that the parameters are local to the function body
+
+			checker.inNewPurityScope(
+				functionType.Purity == FunctionPurityView,
+				func() {
+					var endPosGetter EndPositionGetter
+					if functionBlock != nil {
+						endPosGetter = functionBlock.EndPosition
+					}
+
+					checker.enterValueScope()
+					defer func() {
+						checkResourceLoss := checkResourceLoss &&
+							!functionActivation.ReturnInfo.DefinitelyHalted
+						checker.leaveValueScope(endPosGetter, checkResourceLoss)
+					}()
+
+					checker.declareParameters(parameterList, functionType.Parameters)
+
+					functionActivation.InitializationInfo = initializationInfo
+
+					if functionBlock != nil {
+						checker.visitFunctionBlock(
+							functionBlock,
+							functionType.ReturnTypeAnnotation,
+							checkResourceLoss,
+						)
+
+						if mustExit {
+							returnType := functionType.ReturnTypeAnnotation.Type
+							checker.checkFunctionExits(functionBlock, returnType)
+						}
+					}
+
+					if initializationInfo != nil {
+						checker.checkFieldMembersInitialized(initializationInfo)
+					}
+				},
+			)
 		},
 	)
 
@@ -294,6 +324,9 @@ func (checker *Checker) declareParameters(
 		checker.valueActivations.Set(identifier.Identifier, variable)
 		if checker.PositionInfo != nil {
 			checker.recordVariableDeclarationOccurrence(identifier.Identifier, variable)
+			if existingVariable != nil {
+				checker.PositionInfo.recordVariableShadowing(variable, existingVariable)
+			}
 		}
 	}
 }
@@ -312,7 +345,12 @@ func (checker *Checker) visitWithPostConditions(postConditions *ast.Conditions,
 
 		checker.Elaboration.PostConditionsRewrite[postConditions] = rewriteResult
 
-		checker.visitStatements(rewriteResult.BeforeStatements)
+		// The extracted `before` expressions are part of the post-conditions,
+		// so they are checked in a view context, just like conditions
+
+		checker.inNewPurityScope(true, func() {
+			checker.visitStatements(rewriteResult.BeforeStatements)
+		})
 	}
 
 	body()
@@ -395,7 +433,12 @@ func (checker *Checker) declareBefore() {
 func (checker *Checker) VisitFunctionExpression(expression *ast.FunctionExpression) Type {
 
 	// TODO: infer
-	functionType := checker.functionType(expression.ParameterList, expression.ReturnTypeAnnotation)
+	functionType := checker.functionType(
+		expression.Purity,
+		nil,
+		expression.ParameterList,
+		expression.ReturnTypeAnnotation,
+	)
 
 	checker.Elaboration.FunctionExpressionFunctionType[expression] = functionType
 
@@ -64,6 +64,7 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 	// Declare nested types
 
 	checker.declareInterfaceNestedTypes(declaration)
+	checker.declareTypeAliases(declaration.Members.TypeAliases(), interfaceType)
 
 	checker.checkInitializers(
 		declaration.Members.Initializers(),
@@ -123,6 +124,10 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 		checker.visitCompositeDeclaration(nestedComposite, kind)
 	}
 
+	for _, nestedTypeAlias := range declaration.Members.TypeAliases() {
+		ast.AcceptDeclaration[struct{}](nestedTypeAlias, checker)
+	}
+
 	return
 }
 
@@ -317,6 +322,7 @@ func (checker *Checker) declareInterfaceMembers(declaration *ast.InterfaceDeclar
 	// Declare nested types
 
 	checker.declareInterfaceNestedTypes(declaration)
+	checker.declareTypeAliases(declaration.Members.TypeAliases(), interfaceType)
 
 	// Declare members
 
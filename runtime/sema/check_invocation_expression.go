@@ -39,6 +39,19 @@ func (checker *Checker) VisitInvocationExpression(invocationExpression *ast.Invo
 		return InvalidType
 	}
 
+	// Attachments cannot be constructed without an attach expression
+
+	if compositeType, ok := ty.(*CompositeType); ok &&
+		compositeType.Kind == common.CompositeKindAttachment {
+
+		checker.report(
+			&InvalidAttachmentUsageError{
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, invocationExpression),
+			},
+		)
+		return InvalidType
+	}
+
 	return ty
 }
 
@@ -108,6 +121,8 @@ func (checker *Checker) checkInvocationExpression(invocationExpression *ast.Invo
 		return InvalidType
 	}
 
+	checker.checkInvocationPurity(invocationExpression, functionType)
+
 	// The invoked expression has a function type,
 	// check the invocation including all arguments.
 	//
@@ -454,7 +469,11 @@ func (checker *Checker) checkInvocation(
 		ast.NewRangeFromPositioned(checker.memoryGauge, invocationExpression),
 	)
 
-	returnType = functionType.ReturnTypeAnnotation.Type.Resolve(typeArguments)
+	returnType = resolveInvocationType(
+		functionType.ReturnTypeAnnotation.Type,
+		functionType.TypeParameters,
+		typeArguments,
+	)
 	if returnType == nil {
 		// TODO: report error? does `checkTypeParameterInference` below already do that?
 		returnType = InvalidType
@@ -538,7 +557,11 @@ func (checker *Checker) checkInvocationRequiredArgument(
 		argumentRange := ast.NewRangeFromPositioned(checker.memoryGauge, argument.Expression)
 
 		if parameterType.Unify(argumentType, typeParameters, checker.report, argumentRange) {
-			parameterType = parameterType.Resolve(typeParameters)
+			parameterType = resolveInvocationType(
+				parameterType,
+				functionType.TypeParameters,
+				typeParameters,
+			)
 			if parameterType == nil {
 				parameterType = InvalidType
 			}
@@ -675,3 +698,50 @@ func (checker *Checker) checkInvocationArgumentMove(argument ast.Expression, arg
 
 	return argumentType
 }
+
+// resolveInvocationType resolves the given type of the invoked function
+// using the type arguments of the invocation.
+//
+// Generic types which are not type parameters of the invoked function,
+// e.g. the type parameters of an enclosing generic function, are left as-is.
+func resolveInvocationType(
+	ty Type,
+	typeParameters []*TypeParameter,
+	typeArguments *TypeParameterTypeOrderedMap,
+) Type {
+
+	resolvedTypeArguments := typeArguments
+
+	for _, freeTypeParameter := range FreeTypeParameters(ty) {
+		if typeArguments.Contains(freeTypeParameter) {
+			continue
+		}
+
+		isOwnTypeParameter := false
+		for _, typeParameter := range typeParameters {
+			if typeParameter == freeTypeParameter {
+				isOwnTypeParameter = true
+				break
+			}
+		}
+		if isOwnTypeParameter {
+			continue
+		}
+
+		if resolvedTypeArguments == typeArguments {
+			resolvedTypeArguments = &TypeParameterTypeOrderedMap{}
+			typeArguments.Foreach(func(typeParameter *TypeParameter, ty Type) {
+				resolvedTypeArguments.Set(typeParameter, ty)
+			})
+		}
+
+		resolvedTypeArguments.Set(
+			freeTypeParameter,
+			&GenericType{
+				TypeParameter: freeTypeParameter,
+			},
+		)
+	}
+
+	return ty.Resolve(resolvedTypeArguments)
+}
@@ -0,0 +1,180 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// PurityCheckScope is a scope in which impure operations are checked,
+// e.g. the body of a function, or the conditions of a function
+type PurityCheckScope struct {
+	// EnforcePurity specifies if impure operations are reported,
+	// e.g. in view functions and conditions
+	EnforcePurity bool
+	// ActivationDepth is the depth of value scopes in which the scope was entered.
+	// Variables declared at a greater depth are local to the scope,
+	// and may be modified in a view context
+	ActivationDepth int
+}
+
+func (checker *Checker) currentPurityScope() PurityCheckScope {
+	lastIndex := len(checker.purityCheckScopes) - 1
+	if lastIndex < 0 {
+		return PurityCheckScope{}
+	}
+	return checker.purityCheckScopes[lastIndex]
+}
+
+func (checker *Checker) inNewPurityScope(enforcePurity bool, f func()) {
+	checker.purityCheckScopes = append(
+		checker.purityCheckScopes,
+		PurityCheckScope{
+			EnforcePurity:   enforcePurity,
+			ActivationDepth: checker.valueActivations.Depth(),
+		},
+	)
+
+	defer func() {
+		lastIndex := len(checker.purityCheckScopes) - 1
+		checker.purityCheckScopes = checker.purityCheckScopes[:lastIndex]
+	}()
+
+	f()
+}
+
+// observeImpureOperation reports the given impure operation,
+// if it is performed in a view context
+func (checker *Checker) observeImpureOperation(operation ast.HasPosition) {
+	if !checker.currentPurityScope().EnforcePurity {
+		return
+	}
+
+	checker.report(
+		&PurityError{
+			Range: ast.NewRangeFromPositioned(checker.memoryGauge, operation),
+		},
+	)
+}
+
+// checkAssignmentTargetPurity checks that the given assignment target
+// may be modified in the current purity scope.
+//
+// In a view context, only variables declared in the scope may be modified,
+// and their members and elements, unless they are accessed through a reference
+func (checker *Checker) checkAssignmentTargetPurity(target ast.Expression) {
+	scope := checker.currentPurityScope()
+	if !scope.EnforcePurity {
+		return
+	}
+
+	if checker.isLocalAssignmentTarget(target, scope.ActivationDepth) {
+		return
+	}
+
+	checker.observeImpureOperation(target)
+}
+
+// checkInvocationPurity checks that the given invocation
+// may be performed in the current purity scope.
+//
+// In a view context, only view functions may be invoked,
+// and built-in functions which mutate a container, e.g. `append` of arrays,
+// if the container may be modified, i.e. it is declared in the scope
+func (checker *Checker) checkInvocationPurity(
+	invocationExpression *ast.InvocationExpression,
+	functionType *FunctionType,
+) {
+	if functionType.Purity == FunctionPurityView {
+		return
+	}
+
+	scope := checker.currentPurityScope()
+	if !scope.EnforcePurity {
+		return
+	}
+
+	if memberExpression, ok := invocationExpression.InvokedExpression.(*ast.MemberExpression); ok {
+		memberInfo := checker.Elaboration.MemberExpressionMemberInfos[memberExpression]
+
+		if isContainerMutationFunction(memberInfo.AccessedType, memberExpression.Identifier.Identifier) &&
+			checker.isLocalAssignmentTarget(memberExpression.Expression, scope.ActivationDepth) {
+
+			return
+		}
+	}
+
+	checker.observeImpureOperation(invocationExpression)
+}
+
+// isContainerMutationFunction returns true if the given member of the given type
+// is a built-in function which only mutates the container itself
+func isContainerMutationFunction(accessedType Type, identifier string) bool {
+	switch accessedType.(type) {
+	case ArrayType:
+		switch identifier {
+		case "append", "appendAll", "insert", "remove", "removeFirst", "removeLast":
+			return true
+		}
+
+	case *DictionaryType:
+		switch identifier {
+		case "insert", "remove":
+			return true
+		}
+	}
+
+	return false
+}
+
+func (checker *Checker) isLocalAssignmentTarget(target ast.Expression, activationDepth int) bool {
+	switch target := target.(type) {
+	case *ast.IdentifierExpression:
+		identifier := target.Identifier.Identifier
+
+		// NOTE: `self` is declared at the same depth as the parameters,
+		//   but it is only local to the function if the function is an initializer,
+		//   i.e. the value is being initialized
+
+		if identifier == SelfIdentifier {
+			return checker.functionActivations.Current().InitializationInfo != nil
+		}
+
+		variable := checker.valueActivations.Find(identifier)
+		return variable != nil &&
+			variable.ActivationDepth > activationDepth
+
+	case *ast.MemberExpression:
+		memberInfo := checker.Elaboration.MemberExpressionMemberInfos[target]
+		if _, ok := memberInfo.AccessedType.(*ReferenceType); ok {
+			return false
+		}
+		return checker.isLocalAssignmentTarget(target.Expression, activationDepth)
+
+	case *ast.IndexExpression:
+		indexExpressionTypes := checker.Elaboration.IndexExpressionTypes[target]
+		if _, ok := indexExpressionTypes.IndexedType.(*ReferenceType); ok {
+			return false
+		}
+		return checker.isLocalAssignmentTarget(target.TargetExpression, activationDepth)
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitRemoveStatement(statement *ast.RemoveStatement) (_ struct{}) {
+
+	// Removing an attachment modifies the base value
+
+	checker.observeImpureOperation(statement)
+
+	attachmentType := checker.convertNominalType(statement.Attachment)
+
+	valueType := checker.VisitExpression(statement.Value, nil)
+
+	if attachmentType.IsInvalidType() {
+		return
+	}
+
+	compositeType, ok := attachmentType.(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&NotAnAttachmentError{
+				Type:  attachmentType,
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, statement.Attachment),
+			},
+		)
+		return
+	}
+
+	// Attachments may also be removed through a reference to the base value
+
+	baseType := valueType
+	if referenceType, ok := valueType.(*ReferenceType); ok {
+		baseType = referenceType.Type
+	}
+
+	checker.checkAttachmentBase(compositeType, baseType, statement.Value)
+
+	checker.Elaboration.RemoveStatementTypes[statement] = compositeType
+
+	return
+}
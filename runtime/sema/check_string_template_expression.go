@@ -0,0 +1,62 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+func (checker *Checker) VisitStringTemplateExpression(expression *ast.StringTemplateExpression) Type {
+
+	for _, valueExpression := range expression.Expressions {
+		valueType := checker.VisitExpression(valueExpression, nil)
+
+		if valueType.IsInvalidType() ||
+			isStringTemplateValueType(valueType) {
+
+			continue
+		}
+
+		checker.report(
+			&TypeMismatchWithDescriptionError{
+				ExpectedTypeDescription: "a type convertible to a string",
+				ActualType:              valueType,
+				Range:                   ast.NewRangeFromPositioned(checker.memoryGauge, valueExpression),
+			},
+		)
+	}
+
+	return StringType
+}
+
+// isStringTemplateValueType returns true if values of the given type
+// can be interpolated in a string template, i.e. can be converted to a string
+func isStringTemplateValueType(ty Type) bool {
+	switch ty {
+	case StringType, CharacterType, BoolType:
+		return true
+	}
+
+	if _, ok := ty.(*AddressType); ok {
+		return true
+	}
+
+	return IsSubType(ty, NumberType) ||
+		IsSubType(ty, PathType)
+}
@@ -37,6 +37,14 @@ func (checker *Checker) VisitSwapStatement(swap *ast.SwapStatement) (_ struct{})
 	lhsValid := checker.checkSwapStatementExpression(swap.Left, leftType, common.OperandSideLeft)
 	rhsValid := checker.checkSwapStatementExpression(swap.Right, rightType, common.OperandSideRight)
 
+	if lhsValid {
+		checker.checkAssignmentTargetPurity(swap.Left)
+	}
+
+	if rhsValid {
+		checker.checkAssignmentTargetPurity(swap.Right)
+	}
+
 	// The types of both sides must be subtypes of each other,
 	// so that assignment can be performed in both directions.
 	// i.e: The two types have to be equal.
@@ -0,0 +1,406 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+func (checker *Checker) VisitTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) (_ struct{}) {
+	// NOTE: the aliased type is already resolved when declaring the type alias,
+	// see `declareTypeAliases`
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	return
+}
+
+type typeAliasState uint8
+
+const (
+	typeAliasStateUnresolved typeAliasState = iota
+	typeAliasStateResolving
+	typeAliasStateResolved
+)
+
+// declareTypeAliases declares the given type aliases in the current type activation.
+// If the type aliases are nested in a composite or interface, i.e. `containerType` is not nil,
+// they are also declared in the container type, so they can be referred to using a qualified name.
+//
+// The aliased types are resolved when the type aliases are declared for the first time,
+// and are recorded in the elaboration. The aliases are resolved in the order of their dependencies,
+// so a type alias may refer to a type alias which is declared after it in the same scope.
+// Type aliases which refer to themselves, directly or indirectly, are reported.
+func (checker *Checker) declareTypeAliases(
+	declarations []*ast.TypeAliasDeclaration,
+	containerType Type,
+) {
+	if len(declarations) == 0 {
+		return
+	}
+
+	isNested := containerType != nil
+
+	declarationsByName := make(map[string]*ast.TypeAliasDeclaration, len(declarations))
+	for _, declaration := range declarations {
+		name := declaration.Identifier.Identifier
+		if _, ok := declarationsByName[name]; !ok {
+			declarationsByName[name] = declaration
+		}
+	}
+
+	states := make(map[*ast.TypeAliasDeclaration]typeAliasState, len(declarations))
+
+	var resolve func(declaration *ast.TypeAliasDeclaration)
+	resolve = func(declaration *ast.TypeAliasDeclaration) {
+		switch states[declaration] {
+		case typeAliasStateResolved:
+			return
+
+		case typeAliasStateResolving:
+			checker.report(
+				&CyclicTypeAliasError{
+					Name:  declaration.Identifier.Identifier,
+					Range: ast.NewRangeFromPositioned(checker.memoryGauge, declaration.Identifier),
+				},
+			)
+
+			states[declaration] = typeAliasStateResolved
+			checker.declareTypeAlias(declaration, InvalidType, containerType)
+			return
+		}
+
+		states[declaration] = typeAliasStateResolving
+
+		forEachNominalTypeIdentifier(declaration.AliasedType, func(identifier ast.Identifier) {
+			dependency, ok := declarationsByName[identifier.Identifier]
+			if !ok {
+				return
+			}
+			resolve(dependency)
+		})
+
+		// The type alias might have been declared already, if it is cyclic
+
+		if states[declaration] == typeAliasStateResolved {
+			return
+		}
+
+		states[declaration] = typeAliasStateResolved
+
+		aliasedType := checker.ConvertType(declaration.AliasedType)
+		checker.declareTypeAlias(declaration, aliasedType, containerType)
+	}
+
+	for _, declaration := range declarations {
+
+		// If the aliased type was already resolved, only re-declare the type alias.
+		//
+		// NOTE: We allow the shadowing of types here, and ignore errors,
+		// because the type alias was already previously declared, and errors were reported.
+
+		if aliasedType, ok := checker.Elaboration.TypeAliasDeclarationTypes[declaration]; ok {
+			_, _ = checker.typeActivations.declareType(typeDeclaration{
+				identifier:               declaration.Identifier,
+				ty:                       aliasedType,
+				declarationKind:          declaration.DeclarationKind(),
+				access:                   declaration.Access,
+				docString:                declaration.DocString,
+				allowOuterScopeShadowing: true,
+			})
+			continue
+		}
+
+		// Redeclarations of nested declarations are already reported
+		// when checking the nested identifiers, see `checkNestedIdentifiers`
+
+		if isNested {
+			name := declaration.Identifier.Identifier
+
+			if declarationsByName[name] != declaration {
+				continue
+			}
+
+			if _, ok := containerType.(ContainerType).GetNestedTypes().Get(name); ok {
+				continue
+			}
+		}
+
+		resolve(declaration)
+	}
+}
+
+// declareNestedTypeAliases declares the type aliases nested in the given composite or interface declaration,
+// and recursively in its nested declarations.
+//
+// The nested type aliases are declared in the container types before the members of any declaration are declared,
+// so they can be referred to using a qualified name, e.g. `S.N`, independent of the order of the declarations.
+// Type aliases may also refer to type aliases nested in other declarations, see `typeAliasDependencyOrder`.
+//
+// NOTE: This function assumes that the container types and their nested types were previously declared
+// using `declareCompositeType` and `declareInterfaceType`.
+func (checker *Checker) declareNestedTypeAliases(declaration ast.Declaration) {
+
+	var containerType ContainerType
+	var nestedDeclarations map[string]ast.Declaration
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		containerType = checker.Elaboration.CompositeDeclarationTypes[declaration]
+		nestedDeclarations = checker.Elaboration.CompositeNestedDeclarations[declaration]
+
+	case *ast.InterfaceDeclaration:
+		containerType = checker.Elaboration.InterfaceDeclarationTypes[declaration]
+		nestedDeclarations = checker.Elaboration.InterfaceNestedDeclarations[declaration]
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	members := containerDeclarationMembers(declaration)
+
+	// Activate new scope for nested declarations
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(declaration.EndPosition)
+
+	// Declare nested types, so the type aliases can refer to them.
+	//
+	// NOTE: We ignore errors here, because the nested types are declared again
+	// when their members are declared, and errors are reported then.
+
+	containerType.GetNestedTypes().Foreach(func(name string, nestedType Type) {
+		nestedDeclaration := nestedDeclarations[name]
+
+		identifier := nestedDeclaration.DeclarationIdentifier()
+		if identifier == nil {
+			// It should be impossible to have a nested declaration
+			// that does not have an identifier
+
+			panic(errors.NewUnreachableError())
+		}
+
+		_, _ = checker.typeActivations.declareType(typeDeclaration{
+			identifier:               *identifier,
+			ty:                       nestedType,
+			declarationKind:          nestedDeclaration.DeclarationKind(),
+			access:                   nestedDeclaration.DeclarationAccess(),
+			docString:                nestedDeclaration.DeclarationDocString(),
+			allowOuterScopeShadowing: true,
+		})
+	})
+
+	checker.declareTypeAliases(members.TypeAliases(), containerType)
+
+	for _, nestedDeclaration := range typeAliasDependencyOrder(containerDeclarations(members)) {
+		checker.declareNestedTypeAliases(nestedDeclaration)
+	}
+}
+
+// containerDeclarations returns the interface and composite declarations of the given members
+func containerDeclarations(members *ast.Members) []ast.Declaration {
+	interfaces := members.Interfaces()
+	composites := members.Composites()
+
+	declarations := make([]ast.Declaration, 0, len(interfaces)+len(composites))
+	for _, declaration := range interfaces {
+		declarations = append(declarations, declaration)
+	}
+	for _, declaration := range composites {
+		declarations = append(declarations, declaration)
+	}
+
+	return declarations
+}
+
+// containerDeclarationMembers returns the members of the given composite or interface declaration
+func containerDeclarationMembers(declaration ast.Declaration) *ast.Members {
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		return declaration.Members
+	case *ast.InterfaceDeclaration:
+		return declaration.Members
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+// typeAliasDependencyOrder returns the given composite and interface declarations, ordered so that
+// a declaration comes after the declarations that the type aliases nested in it refer to,
+// e.g. `struct S { typealias N = Int }` comes before `struct T { typealias M = S.N }`.
+//
+// Declarations which depend on each other are kept in their original order,
+// references to type aliases which are not declared yet are reported when the aliased types are resolved.
+func typeAliasDependencyOrder(declarations []ast.Declaration) []ast.Declaration {
+	if len(declarations) < 2 {
+		return declarations
+	}
+
+	declarationsByName := make(map[string]ast.Declaration, len(declarations))
+	for _, declaration := range declarations {
+		name := declaration.DeclarationIdentifier().Identifier
+		if _, ok := declarationsByName[name]; !ok {
+			declarationsByName[name] = declaration
+		}
+	}
+
+	ordered := make([]ast.Declaration, 0, len(declarations))
+	visited := make(map[ast.Declaration]bool, len(declarations))
+
+	var visit func(declaration ast.Declaration)
+	visit = func(declaration ast.Declaration) {
+		if visited[declaration] {
+			return
+		}
+		visited[declaration] = true
+
+		forEachNestedTypeAlias(declaration, func(typeAlias *ast.TypeAliasDeclaration) {
+			forEachNominalTypeIdentifier(typeAlias.AliasedType, func(identifier ast.Identifier) {
+				dependency, ok := declarationsByName[identifier.Identifier]
+				if !ok {
+					return
+				}
+				visit(dependency)
+			})
+		})
+
+		ordered = append(ordered, declaration)
+	}
+
+	for _, declaration := range declarations {
+		visit(declaration)
+	}
+
+	return ordered
+}
+
+// forEachNestedTypeAlias calls the given function for each type alias
+// nested in the given composite or interface declaration, directly or indirectly
+func forEachNestedTypeAlias(declaration ast.Declaration, f func(typeAlias *ast.TypeAliasDeclaration)) {
+	members := containerDeclarationMembers(declaration)
+
+	for _, typeAlias := range members.TypeAliases() {
+		f(typeAlias)
+	}
+
+	for _, nestedDeclaration := range containerDeclarations(members) {
+		forEachNestedTypeAlias(nestedDeclaration, f)
+	}
+}
+
+func (checker *Checker) declareTypeAlias(
+	declaration *ast.TypeAliasDeclaration,
+	aliasedType Type,
+	containerType Type,
+) {
+	checker.Elaboration.TypeAliasDeclarationTypes[declaration] = aliasedType
+
+	identifier := declaration.Identifier
+
+	variable, err := checker.typeActivations.declareType(typeDeclaration{
+		identifier:               identifier,
+		ty:                       aliasedType,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: containerType != nil,
+	})
+	checker.report(err)
+
+	if checker.PositionInfo != nil && variable != nil {
+		checker.recordVariableDeclarationOccurrence(
+			identifier.Identifier,
+			variable,
+		)
+	}
+
+	if containerType == nil {
+		return
+	}
+
+	var typeAliases **StringTypeOrderedMap
+
+	switch containerType := containerType.(type) {
+	case *CompositeType:
+		typeAliases = &containerType.TypeAliases
+	case *InterfaceType:
+		typeAliases = &containerType.TypeAliases
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	if *typeAliases == nil {
+		*typeAliases = &StringTypeOrderedMap{}
+	}
+
+	(*typeAliases).Set(identifier.Identifier, aliasedType)
+}
+
+// forEachNominalTypeIdentifier calls the given function for the identifier
+// of each nominal type in the given type, e.g. `A` for `A.B`
+func forEachNominalTypeIdentifier(t ast.Type, f func(identifier ast.Identifier)) {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		f(t.Identifier)
+
+	case *ast.OptionalType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.VariableSizedType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.ConstantSizedType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.DictionaryType:
+		forEachNominalTypeIdentifier(t.KeyType, f)
+		forEachNominalTypeIdentifier(t.ValueType, f)
+
+	case *ast.FunctionType:
+		for _, parameterTypeAnnotation := range t.ParameterTypeAnnotations {
+			forEachNominalTypeIdentifier(parameterTypeAnnotation.Type, f)
+		}
+		if t.ReturnTypeAnnotation != nil {
+			forEachNominalTypeIdentifier(t.ReturnTypeAnnotation.Type, f)
+		}
+
+	case *ast.ReferenceType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.RestrictedType:
+		if t.Type != nil {
+			forEachNominalTypeIdentifier(t.Type, f)
+		}
+		for _, restriction := range t.Restrictions {
+			forEachNominalTypeIdentifier(restriction, f)
+		}
+
+	case *ast.InstantiationType:
+		forEachNominalTypeIdentifier(t.Type, f)
+		for _, typeArgument := range t.TypeArguments {
+			forEachNominalTypeIdentifier(typeArgument.Type, f)
+		}
+	}
+}
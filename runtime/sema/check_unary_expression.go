@@ -62,6 +62,10 @@ func (checker *Checker) VisitUnaryExpression(expression *ast.UnaryExpression) Ty
 		return checkExpectedType(valueType, SignedNumberType)
 
 	case ast.OperationMove:
+		// Resources may not be moved in a view context
+
+		checker.observeImpureOperation(expression)
+
 		if !valueType.IsInvalidType() &&
 			!valueType.IsResourceType() {
 
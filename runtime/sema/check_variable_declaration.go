@@ -180,6 +180,11 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 
 	identifier := declaration.Identifier.Identifier
 
+	var shadowedVariable *Variable
+	if checker.PositionInfo != nil {
+		shadowedVariable = checker.valueActivations.Find(identifier)
+	}
+
 	variable, err := checker.valueActivations.declare(variableDeclaration{
 		identifier:               identifier,
 		ty:                       declarationType,
@@ -196,6 +201,9 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 	if checker.PositionInfo != nil && variable != nil {
 		checker.recordVariableDeclarationOccurrence(identifier, variable)
 		checker.recordVariableDeclarationRange(declaration, identifier, declarationType)
+		if shadowedVariable != nil {
+			checker.PositionInfo.recordVariableShadowing(variable, shadowedVariable)
+		}
 	}
 }
 
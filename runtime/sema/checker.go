@@ -34,6 +34,7 @@ import (
 
 const ArgumentLabelNotRequired = "_"
 const SelfIdentifier = "self"
+const BaseIdentifier = "base"
 const BeforeIdentifier = "before"
 const ResultIdentifier = "result"
 
@@ -51,6 +52,7 @@ var beforeType = func() *FunctionType {
 	)
 
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -100,6 +102,7 @@ type Checker struct {
 	typeActivations                    *VariableActivations
 	containerTypes                     map[Type]bool
 	functionActivations                *FunctionActivations
+	purityCheckScopes                  []PurityCheckScope
 	inCondition                        bool
 	isChecked                          bool
 	inCreate                           bool
@@ -303,6 +306,26 @@ func (checker *Checker) CheckProgram(program *ast.Program) {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare type aliases
+
+	checker.declareTypeAliases(program.TypeAliasDeclarations(), nil)
+
+	containerDeclarations := make(
+		[]ast.Declaration,
+		0,
+		len(program.InterfaceDeclarations())+len(program.CompositeDeclarations()),
+	)
+	for _, declaration := range program.InterfaceDeclarations() {
+		containerDeclarations = append(containerDeclarations, declaration)
+	}
+	for _, declaration := range program.CompositeDeclarations() {
+		containerDeclarations = append(containerDeclarations, declaration)
+	}
+
+	for _, declaration := range typeAliasDependencyOrder(containerDeclarations) {
+		checker.declareNestedTypeAliases(declaration)
+	}
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -389,7 +412,12 @@ func (checker *Checker) checkTopLevelDeclarationValidity(declarations []ast.Decl
 }
 
 func (checker *Checker) declareGlobalFunctionDeclaration(declaration *ast.FunctionDeclaration) {
-	functionType := checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	functionType := checker.functionType(
+		declaration.Purity,
+		declaration.TypeParameterList,
+		declaration.ParameterList,
+		declaration.ReturnTypeAnnotation,
+	)
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 	checker.declareFunctionDeclaration(declaration, functionType)
 }
@@ -424,7 +452,7 @@ func (checker *Checker) checkTransfer(transfer *ast.Transfer, valueType Type) {
 func (checker *Checker) checkTypeCompatibility(expression ast.Expression, valueType Type, targetType Type) bool {
 	switch typedExpression := expression.(type) {
 	case *ast.IntegerExpression:
-		unwrappedTargetType := UnwrapOptionalType(targetType)
+		unwrappedTargetType := unwrapLiteralTargetType(targetType)
 
 		if IsSameTypeKind(unwrappedTargetType, IntegerType) {
 			CheckIntegerLiteral(checker.memoryGauge, typedExpression, unwrappedTargetType, checker.report)
@@ -438,7 +466,7 @@ func (checker *Checker) checkTypeCompatibility(expression ast.Expression, valueT
 		}
 
 	case *ast.FixedPointExpression:
-		unwrappedTargetType := UnwrapOptionalType(targetType)
+		unwrappedTargetType := unwrapLiteralTargetType(targetType)
 
 		if IsSameTypeKind(unwrappedTargetType, FixedPointType) {
 			valueTypeOK := CheckFixedPointLiteral(checker.memoryGauge, typedExpression, valueType, checker.report)
@@ -484,7 +512,7 @@ func (checker *Checker) checkTypeCompatibility(expression ast.Expression, valueT
 		}
 
 	case *ast.StringExpression:
-		unwrappedTargetType := UnwrapOptionalType(targetType)
+		unwrappedTargetType := unwrapLiteralTargetType(targetType)
 
 		if IsSameTypeKind(unwrappedTargetType, CharacterType) {
 			checker.checkCharacterLiteral(typedExpression)
@@ -1051,6 +1079,7 @@ func (checker *Checker) convertFunctionType(t *ast.FunctionType) Type {
 	returnTypeAnnotation := checker.ConvertTypeAnnotation(t.ReturnTypeAnnotation)
 
 	return &FunctionType{
+		Purity:               NewFunctionPurity(t.PurityAnnotation),
 		Parameters:           parameters,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 	}
@@ -1146,7 +1175,7 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 
 	for _, identifier := range t.NestedIdentifiers {
 		if containerType, ok := ty.(ContainerType); ok && containerType.IsContainerType() {
-			ty, _ = containerType.GetNestedTypes().Get(identifier.Identifier)
+			ty = getNestedType(containerType, identifier.Identifier)
 		} else {
 			if !ty.IsInvalidType() {
 				checker.report(
@@ -1185,6 +1214,31 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 	return ty
 }
 
+// getNestedType returns the type with the given name nested in the given container type,
+// or the type aliased by the type alias with the given name declared in it, if any
+func getNestedType(containerType ContainerType, name string) Type {
+	nestedType, ok := containerType.GetNestedTypes().Get(name)
+	if ok {
+		return nestedType
+	}
+
+	var typeAliases *StringTypeOrderedMap
+
+	switch containerType := containerType.(type) {
+	case *CompositeType:
+		typeAliases = containerType.TypeAliases
+	case *InterfaceType:
+		typeAliases = containerType.TypeAliases
+	}
+
+	if typeAliases == nil {
+		return nil
+	}
+
+	aliasedType, _ := typeAliases.Get(name)
+	return aliasedType
+}
+
 // ConvertTypeAnnotation converts an AST type annotation representation
 // to a sema type annotation
 //
@@ -1198,20 +1252,100 @@ func (checker *Checker) ConvertTypeAnnotation(typeAnnotation *ast.TypeAnnotation
 }
 
 func (checker *Checker) functionType(
+	purity ast.FunctionPurity,
+	typeParameterList *ast.TypeParameterList,
 	parameterList *ast.ParameterList,
 	returnTypeAnnotation *ast.TypeAnnotation,
 ) *FunctionType {
+	typeParameters := checker.typeParameters(typeParameterList)
+
+	// The type parameters are only visible in the function's signature and body,
+	// see also `checker.declareTypeParameters` in `visitFunctionDeclaration`
+
+	if len(typeParameters) > 0 {
+		checker.typeActivations.Enter()
+		defer checker.typeActivations.Leave(parameterList.EndPosition)
+
+		checker.declareTypeParameters(typeParameterList, typeParameters, false)
+	}
+
 	convertedParameters := checker.parameters(parameterList)
 
 	convertedReturnTypeAnnotation :=
 		checker.ConvertTypeAnnotation(returnTypeAnnotation)
 
 	return &FunctionType{
+		Purity:               NewFunctionPurity(purity),
+		TypeParameters:       typeParameters,
 		Parameters:           convertedParameters,
 		ReturnTypeAnnotation: convertedReturnTypeAnnotation,
 	}
 }
 
+// typeParameters converts the type parameters of the given type parameter list.
+// Type parameters without an explicit type bound are bound by `AnyStruct`.
+func (checker *Checker) typeParameters(typeParameterList *ast.TypeParameterList) []*TypeParameter {
+	if typeParameterList.IsEmpty() {
+		return nil
+	}
+
+	checker.Elaboration.HasTypeParameters = true
+
+	typeParameters := make([]*TypeParameter, len(typeParameterList.TypeParameters))
+
+	for i, typeParameter := range typeParameterList.TypeParameters {
+
+		var typeBound Type = AnyStructType
+
+		if typeParameter.TypeBound != nil {
+			typeBoundAnnotation := checker.ConvertTypeAnnotation(typeParameter.TypeBound)
+			checker.checkTypeAnnotation(typeBoundAnnotation, typeParameter.TypeBound)
+			typeBound = typeBoundAnnotation.Type
+		}
+
+		typeParameters[i] = &TypeParameter{
+			Name:      typeParameter.Identifier.Identifier,
+			TypeBound: typeBound,
+		}
+	}
+
+	return typeParameters
+}
+
+// declareTypeParameters declares the given type parameters as generic types in the current type scope.
+//
+// If outer scope shadowing is allowed, the type parameters were already declared before,
+// e.g. when the function's type was determined, so errors are not reported again.
+func (checker *Checker) declareTypeParameters(
+	typeParameterList *ast.TypeParameterList,
+	typeParameters []*TypeParameter,
+	allowOuterScopeShadowing bool,
+) {
+	for i, typeParameter := range typeParameters {
+		identifier := typeParameterList.TypeParameters[i].Identifier
+
+		variable, err := checker.typeActivations.declareType(typeDeclaration{
+			identifier: identifier,
+			ty: &GenericType{
+				TypeParameter: typeParameter,
+			},
+			declarationKind:          common.DeclarationKindTypeParameter,
+			access:                   ast.AccessNotSpecified,
+			allowOuterScopeShadowing: allowOuterScopeShadowing,
+		})
+		if !allowOuterScopeShadowing {
+			checker.report(err)
+		}
+
+		if checker.PositionInfo != nil && variable != nil {
+			checker.recordVariableDeclarationOccurrence(
+				identifier.Identifier,
+				variable,
+			)
+		}
+	}
+}
+
 func (checker *Checker) parameters(parameterList *ast.ParameterList) []*Parameter {
 
 	parameters := make([]*Parameter, len(parameterList.Parameters))
@@ -2097,8 +2231,11 @@ func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type
 		typeArgumentAnnotations[i] = typeArgument
 	}
 
+	// NOTE: composite types are parameterized types,
+	// but only generic composite types have type parameters
+
 	parameterizedType, ok := ty.(ParameterizedType)
-	if !ok {
+	if !ok || len(parameterizedType.TypeParameters()) == 0 {
 
 		// The type is not parameterized,
 		// report an error for all type arguments
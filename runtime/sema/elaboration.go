@@ -100,6 +100,7 @@ type Elaboration struct {
 	CompositeTypeDeclarations        map[*CompositeType]*ast.CompositeDeclaration
 	InterfaceDeclarationTypes        map[*ast.InterfaceDeclaration]*InterfaceType
 	InterfaceTypeDeclarations        map[*InterfaceType]*ast.InterfaceDeclaration
+	TypeAliasDeclarationTypes        map[*ast.TypeAliasDeclaration]Type
 	ConstructorFunctionTypes         map[*ast.SpecialFunctionDeclaration]*FunctionType
 	FunctionExpressionFunctionType   map[*ast.FunctionExpression]*FunctionType
 	InvocationExpressionTypes        map[*ast.InvocationExpression]InvocationExpressionTypes
@@ -140,6 +141,13 @@ type Elaboration struct {
 		Range ast.Range
 	}
 	RuntimeCastTypes map[*ast.CastingExpression]RuntimeCastTypes
+	// AttachmentAccessTypes are the attachment types of index expressions
+	// which access an attachment, e.g. `r[A]`
+	AttachmentAccessTypes map[*ast.IndexExpression]*CompositeType
+	RemoveStatementTypes  map[*ast.RemoveStatement]*CompositeType
+	// HasTypeParameters is true if the program declares generic functions or composite types,
+	// i.e. if types in the program may refer to type parameters
+	HasTypeParameters bool
 }
 
 func NewElaboration(gauge common.MemoryGauge, extendedElaboration bool) *Elaboration {
@@ -153,6 +161,7 @@ func NewElaboration(gauge common.MemoryGauge, extendedElaboration bool) *Elabora
 		CompositeTypeDeclarations:           map[*CompositeType]*ast.CompositeDeclaration{},
 		InterfaceDeclarationTypes:           map[*ast.InterfaceDeclaration]*InterfaceType{},
 		InterfaceTypeDeclarations:           map[*InterfaceType]*ast.InterfaceDeclaration{},
+		TypeAliasDeclarationTypes:           map[*ast.TypeAliasDeclaration]Type{},
 		ConstructorFunctionTypes:            map[*ast.SpecialFunctionDeclaration]*FunctionType{},
 		FunctionExpressionFunctionType:      map[*ast.FunctionExpression]*FunctionType{},
 		InvocationExpressionTypes:           map[*ast.InvocationExpression]InvocationExpressionTypes{},
@@ -182,6 +191,8 @@ func NewElaboration(gauge common.MemoryGauge, extendedElaboration bool) *Elabora
 		GlobalTypes:                         &StringVariableOrderedMap{},
 		ReferenceExpressionBorrowTypes:      map[*ast.ReferenceExpression]Type{},
 		IndexExpressionTypes:                map[*ast.IndexExpression]IndexExpressionTypes{},
+		AttachmentAccessTypes:               map[*ast.IndexExpression]*CompositeType{},
+		RemoveStatementTypes:                map[*ast.RemoveStatement]*CompositeType{},
 	}
 	if extendedElaboration {
 		elaboration.ForceExpressionTypes = map[*ast.ForceExpression]Type{}
@@ -247,6 +247,7 @@ type TypeMismatchError struct {
 var _ SemanticError = &TypeMismatchError{}
 var _ errors.UserError = &TypeMismatchError{}
 var _ errors.SecondaryError = &TypeMismatchError{}
+var _ errors.HasSuggestedFixes[ast.TextEdit] = &TypeMismatchError{}
 
 func (*TypeMismatchError) isSemanticError() {}
 
@@ -264,6 +265,61 @@ func (e *TypeMismatchError) SecondaryError() string {
 	)
 }
 
+// SuggestedFixes suggests to force-unwrap the expression,
+// if the expression is optional, and the expected type is the inner type
+func (e *TypeMismatchError) SuggestedFixes(_ string) []errors.SuggestedFix[ast.TextEdit] {
+	if e.Expression == nil || e.ExpectedType == nil {
+		return nil
+	}
+
+	optionalType, ok := e.ActualType.(*OptionalType)
+	if !ok || !IsSubType(optionalType.Type, e.ExpectedType) {
+		return nil
+	}
+
+	startPos := e.Expression.StartPosition()
+	endPos := e.Expression.EndPosition(nil)
+
+	insertionPos := endPos.Shifted(nil, 1)
+
+	var textEdits []ast.TextEdit
+
+	switch e.Expression.(type) {
+	case *ast.IdentifierExpression,
+		*ast.MemberExpression,
+		*ast.IndexExpression,
+		*ast.InvocationExpression,
+		*ast.ForceExpression:
+
+		textEdits = []ast.TextEdit{
+			{
+				Insertion: "!",
+				Range:     ast.NewUnmeteredRange(insertionPos, insertionPos),
+			},
+		}
+
+	default:
+		// Parenthesize the expression, as the force-unwrap has a higher precedence
+		textEdits = []ast.TextEdit{
+			{
+				Insertion: "(",
+				Range:     ast.NewUnmeteredRange(startPos, startPos),
+			},
+			{
+				Insertion: ")!",
+				Range:     ast.NewUnmeteredRange(insertionPos, insertionPos),
+			},
+		}
+	}
+
+	return []errors.SuggestedFix[ast.TextEdit]{
+		{
+			Message:   "insert force-unwrap",
+			TextEdits: textEdits,
+		},
+	}
+}
+
 // TypeMismatchWithDescriptionError
 
 type TypeMismatchWithDescriptionError struct {
@@ -651,6 +707,7 @@ type MissingAccessModifierError struct {
 
 var _ errors.UserError = &MissingAccessModifierError{}
 var _ SemanticError = &MissingAccessModifierError{}
+var _ errors.HasSuggestedFixes[ast.TextEdit] = &MissingAccessModifierError{}
 
 func (*MissingAccessModifierError) isSemanticError() {}
 
@@ -669,6 +726,21 @@ func (e *MissingAccessModifierError) Error() string {
 	)
 }
 
+// SuggestedFixes suggests to insert the access modifier which allows access from everywhere
+func (e *MissingAccessModifierError) SuggestedFixes(_ string) []errors.SuggestedFix[ast.TextEdit] {
+	return []errors.SuggestedFix[ast.TextEdit]{
+		{
+			Message: "insert access modifier",
+			TextEdits: []ast.TextEdit{
+				{
+					Insertion: "access(all) ",
+					Range:     ast.NewUnmeteredRange(e.Pos, e.Pos),
+				},
+			},
+		},
+	}
+}
+
 func (e *MissingAccessModifierError) StartPosition() ast.Position {
 	return e.Pos
 }
@@ -1799,6 +1871,7 @@ type IncorrectTransferOperationError struct {
 var _ SemanticError = &IncorrectTransferOperationError{}
 var _ errors.UserError = &IncorrectTransferOperationError{}
 var _ errors.SecondaryError = &IncorrectTransferOperationError{}
+var _ errors.HasSuggestedFixes[ast.TextEdit] = &IncorrectTransferOperationError{}
 
 func (*IncorrectTransferOperationError) isSemanticError() {}
 
@@ -1815,6 +1888,24 @@ func (e *IncorrectTransferOperationError) SecondaryError() string {
 	)
 }
 
+// SuggestedFixes suggests to replace the transfer operation with the expected one
+func (e *IncorrectTransferOperationError) SuggestedFixes(_ string) []errors.SuggestedFix[ast.TextEdit] {
+	return []errors.SuggestedFix[ast.TextEdit]{
+		{
+			Message: fmt.Sprintf(
+				"replace with `%s`",
+				e.ExpectedOperation.Operator(),
+			),
+			TextEdits: []ast.TextEdit{
+				{
+					Replacement: e.ExpectedOperation.Operator(),
+					Range:       e.Range,
+				},
+			},
+		},
+	}
+}
+
 // InvalidConstructionError
 
 type InvalidConstructionError struct {
@@ -3422,6 +3513,36 @@ func (e *TypeParameterTypeInferenceError) Error() string {
 	)
 }
 
+// InvalidTypeParametersError
+
+type InvalidTypeParametersError struct {
+	DeclarationKind   common.DeclarationKind
+	IsTypeRequirement bool
+	ast.Range
+}
+
+var _ SemanticError = &InvalidTypeParametersError{}
+var _ errors.UserError = &InvalidTypeParametersError{}
+var _ errors.SecondaryError = &InvalidTypeParametersError{}
+
+func (*InvalidTypeParametersError) isSemanticError() {}
+
+func (*InvalidTypeParametersError) IsUserError() {}
+
+func (e *InvalidTypeParametersError) Error() string {
+	if e.IsTypeRequirement {
+		return "type requirements cannot have type parameters"
+	}
+	return fmt.Sprintf(
+		"%s declarations cannot have type parameters",
+		e.DeclarationKind.Name(),
+	)
+}
+
+func (*InvalidTypeParametersError) SecondaryError() string {
+	return "only structures and resources can be generic"
+}
+
 // InvalidConstantSizedTypeBaseError
 
 type InvalidConstantSizedTypeBaseError struct {
@@ -3707,3 +3828,162 @@ func (e *ExternalMutationError) SecondaryError() string {
 		e.ContainerType.QualifiedString(),
 	)
 }
+
+// CyclicTypeAliasError
+
+type CyclicTypeAliasError struct {
+	Name string
+	ast.Range
+}
+
+var _ SemanticError = &CyclicTypeAliasError{}
+var _ errors.UserError = &CyclicTypeAliasError{}
+
+func (*CyclicTypeAliasError) isSemanticError() {}
+
+func (*CyclicTypeAliasError) IsUserError() {}
+
+func (e *CyclicTypeAliasError) Error() string {
+	return fmt.Sprintf(
+		"type alias `%s` refers to itself",
+		e.Name,
+	)
+}
+
+func (e *CyclicTypeAliasError) SecondaryError() string {
+	return "type aliases may not be cyclic, directly or indirectly"
+}
+
+// PurityError
+
+type PurityError struct {
+	ast.Range
+}
+
+var _ SemanticError = &PurityError{}
+var _ errors.UserError = &PurityError{}
+
+func (*PurityError) isSemanticError() {}
+
+func (*PurityError) IsUserError() {}
+
+func (e *PurityError) Error() string {
+	return "impure operation performed in view context"
+}
+
+func (e *PurityError) SecondaryError() string {
+	return "view functions and conditions may only call view functions, " +
+		"and may not emit events, move or destroy resources, " +
+		"or modify state declared outside of them"
+}
+
+// InvalidAttachmentBaseTypeError
+
+type InvalidAttachmentBaseTypeError struct {
+	Type Type
+	ast.Range
+}
+
+var _ SemanticError = &InvalidAttachmentBaseTypeError{}
+var _ errors.UserError = &InvalidAttachmentBaseTypeError{}
+var _ errors.SecondaryError = &InvalidAttachmentBaseTypeError{}
+
+func (*InvalidAttachmentBaseTypeError) isSemanticError() {}
+
+func (*InvalidAttachmentBaseTypeError) IsUserError() {}
+
+func (e *InvalidAttachmentBaseTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot declare attachment for type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *InvalidAttachmentBaseTypeError) SecondaryError() string {
+	return "attachments can only be declared for structures, resources, " +
+		"structure interfaces, resource interfaces, `AnyStruct`, and `AnyResource`"
+}
+
+// InvalidAttachmentUsageError
+
+type InvalidAttachmentUsageError struct {
+	ast.Range
+}
+
+var _ SemanticError = &InvalidAttachmentUsageError{}
+var _ errors.UserError = &InvalidAttachmentUsageError{}
+
+func (*InvalidAttachmentUsageError) isSemanticError() {}
+
+func (*InvalidAttachmentUsageError) IsUserError() {}
+
+func (e *InvalidAttachmentUsageError) Error() string {
+	return "attachments can only be constructed in an `attach` expression"
+}
+
+// NotAnAttachmentError
+
+type NotAnAttachmentError struct {
+	Type Type
+	ast.Range
+}
+
+var _ SemanticError = &NotAnAttachmentError{}
+var _ errors.UserError = &NotAnAttachmentError{}
+
+func (*NotAnAttachmentError) isSemanticError() {}
+
+func (*NotAnAttachmentError) IsUserError() {}
+
+func (e *NotAnAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"expected attachment type, got `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+// InvalidAttachmentBaseError
+
+type InvalidAttachmentBaseError struct {
+	AttachmentType *CompositeType
+	ActualType     Type
+	ast.Range
+}
+
+var _ SemanticError = &InvalidAttachmentBaseError{}
+var _ errors.UserError = &InvalidAttachmentBaseError{}
+
+func (*InvalidAttachmentBaseError) isSemanticError() {}
+
+func (*InvalidAttachmentBaseError) IsUserError() {}
+
+func (e *InvalidAttachmentBaseError) Error() string {
+	return fmt.Sprintf(
+		"attachment `%s` is declared for `%s`, got `%s`",
+		e.AttachmentType.QualifiedString(),
+		e.AttachmentType.AttachmentBaseType.QualifiedString(),
+		e.ActualType.QualifiedString(),
+	)
+}
+
+// InvalidAttachmentAssignmentError
+
+type InvalidAttachmentAssignmentError struct {
+	ast.Range
+}
+
+var _ SemanticError = &InvalidAttachmentAssignmentError{}
+var _ errors.UserError = &InvalidAttachmentAssignmentError{}
+var _ errors.SecondaryError = &InvalidAttachmentAssignmentError{}
+
+func (*InvalidAttachmentAssignmentError) isSemanticError() {}
+
+func (*InvalidAttachmentAssignmentError) IsUserError() {}
+
+func (e *InvalidAttachmentAssignmentError) Error() string {
+	return "cannot assign to attachment"
+}
+
+func (e *InvalidAttachmentAssignmentError) SecondaryError() string {
+	return "use an `attach` expression or a `remove` statement instead"
+}
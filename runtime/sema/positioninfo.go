@@ -32,6 +32,9 @@ type PositionInfo struct {
 	MemberAccesses      *MemberAccesses
 	Ranges              *Ranges
 	FunctionInvocations *FunctionInvocations
+	// ShadowedVariables maps variables to the variables of enclosing scopes
+	// which have the same name, and are shadowed by them
+	ShadowedVariables map[*Variable]*Variable
 }
 
 func NewPositionInfo() *PositionInfo {
@@ -42,6 +45,7 @@ func NewPositionInfo() *PositionInfo {
 		MemberAccesses:      NewMemberAccesses(),
 		Ranges:              NewRanges(),
 		FunctionInvocations: NewFunctionInvocations(),
+		ShadowedVariables:   map[*Variable]*Variable{},
 	}
 }
 
@@ -135,6 +139,10 @@ func (i *PositionInfo) recordVariableDeclarationOccurrence(
 	i.recordVariableReferenceOccurrence(memoryGauge, startPos, endPos, variable)
 }
 
+func (i *PositionInfo) recordVariableShadowing(variable *Variable, shadowedVariable *Variable) {
+	i.ShadowedVariables[variable] = shadowedVariable
+}
+
 func (i *PositionInfo) recordMemberOrigins(ty Type, origins map[string]*Origin) {
 	i.MemberOrigins[ty] = origins
 }
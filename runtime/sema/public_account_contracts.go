@@ -69,6 +69,7 @@ Returns nil if no contract/contract interface with the given name exists in the
 `
 
 var publicAccountContractsTypeGetFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Identifier: "name",
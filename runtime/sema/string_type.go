@@ -141,6 +141,7 @@ func init() {
 }
 
 var StringTypeConcatFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -158,6 +159,7 @@ Returns a new string which contains the given string concatenated to the end of
 `
 
 var StringTypeSliceFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Identifier:     "from",
@@ -192,6 +194,7 @@ var ByteArrayArrayType = &VariableSizedType{
 }
 
 var StringTypeDecodeHexFunctionType = &FunctionType{
+	Purity:               FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(ByteArrayType),
 }
 
@@ -211,6 +214,7 @@ The byte array of the UTF-8 encoding
 `
 
 var StringTypeToLowerFunctionType = &FunctionType{
+	Purity:               FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 }
 
@@ -233,6 +237,7 @@ var StringFunctionType = func() *FunctionType {
 	}
 
 	functionType := &FunctionType{
+		Purity:               FunctionPurityView,
 		ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 	}
 
@@ -281,6 +286,7 @@ var StringFunctionType = func() *FunctionType {
 }()
 
 var StringTypeEncodeHexFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -296,6 +302,7 @@ var StringTypeEncodeHexFunctionType = &FunctionType{
 }
 
 var StringTypeFromUtf8FunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -311,6 +318,7 @@ var StringTypeFromUtf8FunctionType = &FunctionType{
 }
 
 var StringTypeFromCharactersFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -309,6 +309,7 @@ func NewTypeAnnotation(ty Type) *TypeAnnotation {
 const IsInstanceFunctionName = "isInstance"
 
 var IsInstanceFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -332,6 +333,7 @@ Returns true if the object conforms to the given type at runtime
 const GetTypeFunctionName = "getType"
 
 var GetTypeFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		MetaType,
 	),
@@ -346,6 +348,7 @@ Returns the type of the value
 const ToStringFunctionName = "toString"
 
 var ToStringFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		StringType,
 	),
@@ -383,6 +386,7 @@ func FromStringFunctionDocstring(ty Type) string {
 
 func FromStringFunctionType(ty Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -401,6 +405,7 @@ func FromStringFunctionType(ty Type) *FunctionType {
 const ToBigEndianBytesFunctionName = "toBigEndianBytes"
 
 var toBigEndianBytesFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -714,16 +719,18 @@ func (t *GenericType) Equal(other Type) bool {
 	return t.TypeParameter == otherType.TypeParameter
 }
 
-func (*GenericType) IsResourceType() bool {
-	return false
+func (t *GenericType) IsResourceType() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsResourceType()
 }
 
 func (*GenericType) IsInvalidType() bool {
 	return false
 }
 
-func (*GenericType) IsStorable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsStorable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsStorable(results)
 }
 
 func (*GenericType) IsExternallyReturnable(_ map[*Member]bool) bool {
@@ -734,8 +741,9 @@ func (t *GenericType) IsImportable(_ map[*Member]bool) bool {
 	return false
 }
 
-func (*GenericType) IsEquatable() bool {
-	return false
+func (t *GenericType) IsEquatable() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsEquatable()
 }
 
 func (*GenericType) TypeAnnotationState() TypeAnnotationState {
@@ -796,9 +804,156 @@ func (t *GenericType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 }
 
 func (t *GenericType) GetMembers() map[string]MemberResolver {
+	// The members of the type bound are available,
+	// as any type argument must be a subtype of it
+	typeBound := t.TypeParameter.TypeBound
+	if typeBound != nil {
+		return typeBound.GetMembers()
+	}
 	return withBuiltinMembers(t, nil)
 }
 
+// FreeTypeParameters returns the type parameters of all generic types
+// which occur in the given type, and which are not declared
+// by a generic function type which occurs in the given type.
+func FreeTypeParameters(ty Type) []*TypeParameter {
+	var result []*TypeParameter
+	collectFreeTypeParameters(ty, nil, &result)
+	return result
+}
+
+func collectFreeTypeParameters(ty Type, bound []*TypeParameter, result *[]*TypeParameter) {
+	switch ty := ty.(type) {
+	case *GenericType:
+		typeParameter := ty.TypeParameter
+		for _, boundTypeParameter := range bound {
+			if boundTypeParameter == typeParameter {
+				return
+			}
+		}
+		for _, existingTypeParameter := range *result {
+			if existingTypeParameter == typeParameter {
+				return
+			}
+		}
+		*result = append(*result, typeParameter)
+
+	case *OptionalType:
+		collectFreeTypeParameters(ty.Type, bound, result)
+
+	case *VariableSizedType:
+		collectFreeTypeParameters(ty.Type, bound, result)
+
+	case *ConstantSizedType:
+		collectFreeTypeParameters(ty.Type, bound, result)
+
+	case *DictionaryType:
+		collectFreeTypeParameters(ty.KeyType, bound, result)
+		collectFreeTypeParameters(ty.ValueType, bound, result)
+
+	case *ReferenceType:
+		collectFreeTypeParameters(ty.Type, bound, result)
+
+	case *CapabilityType:
+		if ty.BorrowType != nil {
+			collectFreeTypeParameters(ty.BorrowType, bound, result)
+		}
+
+	case *CompositeType:
+		for _, typeArgument := range ty.effectiveTypeArguments() {
+			collectFreeTypeParameters(typeArgument, bound, result)
+		}
+
+	case *FunctionType:
+		if len(ty.TypeParameters) > 0 {
+			bound = append(bound[:len(bound):len(bound)], ty.TypeParameters...)
+		}
+		for _, parameter := range ty.Parameters {
+			collectFreeTypeParameters(parameter.TypeAnnotation.Type, bound, result)
+		}
+		if ty.ReturnTypeAnnotation != nil {
+			collectFreeTypeParameters(ty.ReturnTypeAnnotation.Type, bound, result)
+		}
+	}
+}
+
+// EraseTypeArguments returns the given type with all instantiations of generic composite types
+// replaced by their generic composite type, and all generic types replaced by their type bound.
+//
+// Exported types do not include the type arguments of generic composite types,
+// so types must be erased before they are exported
+func EraseTypeArguments(ty Type) Type {
+	switch ty := ty.(type) {
+	case *GenericType:
+		typeBound := ty.TypeParameter.TypeBound
+		if typeBound == nil {
+			return AnyType
+		}
+		return EraseTypeArguments(typeBound)
+
+	case *CompositeType:
+		if ty.genericType != nil {
+			return ty.genericType
+		}
+
+	case *OptionalType:
+		innerType := EraseTypeArguments(ty.Type)
+		if innerType != ty.Type {
+			return &OptionalType{
+				Type: innerType,
+			}
+		}
+
+	case *VariableSizedType:
+		elementType := EraseTypeArguments(ty.Type)
+		if elementType != ty.Type {
+			return &VariableSizedType{
+				Type: elementType,
+			}
+		}
+
+	case *ConstantSizedType:
+		elementType := EraseTypeArguments(ty.Type)
+		if elementType != ty.Type {
+			return &ConstantSizedType{
+				Type: elementType,
+				Size: ty.Size,
+			}
+		}
+
+	case *DictionaryType:
+		keyType := EraseTypeArguments(ty.KeyType)
+		valueType := EraseTypeArguments(ty.ValueType)
+		if keyType != ty.KeyType || valueType != ty.ValueType {
+			return &DictionaryType{
+				KeyType:   keyType,
+				ValueType: valueType,
+			}
+		}
+
+	case *ReferenceType:
+		referencedType := EraseTypeArguments(ty.Type)
+		if referencedType != ty.Type {
+			return &ReferenceType{
+				Authorized: ty.Authorized,
+				Type:       referencedType,
+			}
+		}
+
+	case *CapabilityType:
+		if ty.BorrowType != nil {
+			borrowType := EraseTypeArguments(ty.BorrowType)
+			if borrowType != ty.BorrowType {
+				return &CapabilityType{
+					BorrowType: borrowType,
+				}
+			}
+		}
+	}
+
+	return ty
+}
+
 // IntegerRangedType
 
 type IntegerRangedType interface {
@@ -846,6 +1001,7 @@ self / other, saturating at the numeric bounds instead of overflowing.
 func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[string]MemberResolver) {
 
 	arithmeticFunctionType := &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1971,6 +2127,7 @@ func ArrayInsertFunctionType(elementType Type) *FunctionType {
 func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 	typeAnnotation := NewTypeAnnotation(arrayType)
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1984,6 +2141,7 @@ func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 
 func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "of",
@@ -1997,6 +2155,7 @@ func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
 }
 func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -2040,6 +2199,7 @@ func ArrayAppendFunctionType(elementType Type) *FunctionType {
 
 func ArraySliceFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "from",
@@ -2510,9 +2670,27 @@ func formatFunctionType(
 	return builder.String()
 }
 
+// FunctionPurity
+
+type FunctionPurity int
+
+const (
+	FunctionPurityImpure FunctionPurity = iota
+	FunctionPurityView
+)
+
+// NewFunctionPurity returns the function purity for the given purity annotation
+func NewFunctionPurity(purity ast.FunctionPurity) FunctionPurity {
+	if purity == ast.FunctionPurityView {
+		return FunctionPurityView
+	}
+	return FunctionPurityImpure
+}
+
 // FunctionType
 type FunctionType struct {
 	IsConstructor            bool
+	Purity                   FunctionPurity
 	TypeParameters           []*TypeParameter
 	Parameters               []*Parameter
 	ReturnTypeAnnotation     *TypeAnnotation
@@ -2591,6 +2769,8 @@ func (t *FunctionType) QualifiedString() string {
 }
 
 // NOTE: parameter names and argument labels are *not* part of the ID!
+// Neither is the purity, like in the string representations of the function type,
+// so the IDs of function types, e.g. of built-in functions, do not change when they are declared view
 func (t *FunctionType) ID() TypeID {
 
 	typeParameters := make([]string, len(t.TypeParameters))
@@ -2657,6 +2837,12 @@ func (t *FunctionType) Equal(other Type) bool {
 		return false
 	}
 
+	// purity
+
+	if t.Purity != otherFunction.Purity {
+		return false
+	}
+
 	// return type
 
 	if !t.ReturnTypeAnnotation.Type.
@@ -2824,6 +3010,7 @@ func (t *FunctionType) RewriteWithRestrictedTypes() (Type, bool) {
 		}
 
 		return &FunctionType{
+			Purity:                t.Purity,
 			TypeParameters:        rewrittenTypeParameters,
 			Parameters:            rewrittenParameters,
 			ReturnTypeAnnotation:  NewTypeAnnotation(rewrittenReturnType),
@@ -2906,7 +3093,28 @@ func (t *FunctionType) Unify(
 
 func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 
-	// TODO: type parameters ?
+	// type parameters:
+	// the function's own type parameters resolve to themselves,
+	// unless type arguments are given for them
+
+	if len(t.TypeParameters) > 0 {
+		ownTypeArguments := &TypeParameterTypeOrderedMap{}
+		typeArguments.Foreach(func(typeParameter *TypeParameter, ty Type) {
+			ownTypeArguments.Set(typeParameter, ty)
+		})
+		for _, typeParameter := range t.TypeParameters {
+			if ownTypeArguments.Contains(typeParameter) {
+				continue
+			}
+			ownTypeArguments.Set(
+				typeParameter,
+				&GenericType{
+					TypeParameter: typeParameter,
+				},
+			)
+		}
+		typeArguments = ownTypeArguments
+	}
 
 	// parameters
 
@@ -2935,6 +3143,8 @@ func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type
 	}
 
 	return &FunctionType{
+		Purity:                t.Purity,
+		TypeParameters:        t.TypeParameters,
 		Parameters:            newParameters,
 		ReturnTypeAnnotation:  NewTypeAnnotation(newReturnType),
 		RequiredArgumentCount: t.RequiredArgumentCount,
@@ -3223,6 +3433,7 @@ func init() {
 
 func NumberConversionFunctionType(numberType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -3256,6 +3467,7 @@ func baseFunctionVariable(name string, ty *FunctionType, docString string) *Vari
 }
 
 var AddressConversionFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -3334,6 +3546,7 @@ func numberFunctionArgumentExpressionsChecker(targetType Type) ArgumentExpressio
 
 func pathConversionFunctionType(pathType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "identifier",
@@ -3369,6 +3582,7 @@ func init() {
 		baseFunctionVariable(
 			typeName,
 			&FunctionType{
+				Purity:               FunctionPurityView,
 				TypeParameters:       []*TypeParameter{{Name: "T"}},
 				ReturnTypeAnnotation: NewTypeAnnotation(MetaType),
 			},
@@ -3437,13 +3651,27 @@ type CompositeType struct {
 	// TODO: add support for overloaded initializers
 	ConstructorParameters []*Parameter
 	NestedTypes           *StringTypeOrderedMap
-	containerType         Type
-	EnumRawType           Type
-	hasComputedMembers    bool
+	// TypeAliases are the types aliased by the type aliases declared in the composite, if any
+	TypeAliases   *StringTypeOrderedMap
+	containerType Type
+	EnumRawType   Type
+	// AttachmentBaseType is the type an attachment is declared for.
+	// Only applicable for attachment types
+	AttachmentBaseType Type
+	hasComputedMembers bool
 
 	// Only applicable for native composite types.
 	importable bool
 
+	// typeParameters are the type parameters of a generic composite type, if any.
+	// Instantiations share the type parameters of their generic composite type
+	typeParameters []*TypeParameter
+	// typeArguments are the type arguments of an instantiation
+	typeArguments []Type
+	// genericType is the generic composite type of an instantiation
+	genericType             *CompositeType
+	instantiatedMembersOnce sync.Once
+
 	cachedIdentifiers *struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
@@ -3480,11 +3708,35 @@ func (t *CompositeType) addImplicitTypeRequirementConformance(typeRequirement *C
 func (*CompositeType) IsType() {}
 
 func (t *CompositeType) String() string {
-	return t.Identifier
+	if t.genericType == nil {
+		return t.Identifier
+	}
+	return instantiatedTypeString(t.Identifier, t.typeArguments, false)
 }
 
 func (t *CompositeType) QualifiedString() string {
-	return t.QualifiedIdentifier()
+	if t.genericType == nil {
+		return t.QualifiedIdentifier()
+	}
+	return instantiatedTypeString(t.QualifiedIdentifier(), t.typeArguments, true)
+}
+
+func instantiatedTypeString(identifier string, typeArguments []Type, qualified bool) string {
+	var builder strings.Builder
+	builder.WriteString(identifier)
+	builder.WriteRune('<')
+	for i, typeArgument := range typeArguments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		if qualified {
+			builder.WriteString(typeArgument.QualifiedString())
+		} else {
+			builder.WriteString(typeArgument.String())
+		}
+	}
+	builder.WriteRune('>')
+	return builder.String()
 }
 
 func (t *CompositeType) GetContainerType() Type {
@@ -3568,8 +3820,33 @@ func (t *CompositeType) Equal(other Type) bool {
 		return false
 	}
 
-	return otherStructure.Kind == t.Kind &&
-		otherStructure.ID() == t.ID()
+	if otherStructure.Kind != t.Kind ||
+		otherStructure.ID() != t.ID() {
+
+		return false
+	}
+
+	if len(t.typeParameters) == 0 {
+		return true
+	}
+
+	// Instantiations of a generic composite type are only equal
+	// if their type arguments are equal
+
+	typeArguments := t.effectiveTypeArguments()
+	otherTypeArguments := otherStructure.effectiveTypeArguments()
+
+	if len(typeArguments) != len(otherTypeArguments) {
+		return false
+	}
+
+	for i, typeArgument := range typeArguments {
+		if !typeArgument.Equal(otherTypeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (t *CompositeType) GetMembers() map[string]MemberResolver {
@@ -3578,7 +3855,18 @@ func (t *CompositeType) GetMembers() map[string]MemberResolver {
 }
 
 func (t *CompositeType) IsResourceType() bool {
-	return t.Kind == common.CompositeKindResource
+	switch t.Kind {
+	case common.CompositeKindResource:
+		return true
+
+	case common.CompositeKindAttachment:
+		// An attachment is a resource if its base type is a resource
+		return t.AttachmentBaseType != nil &&
+			t.AttachmentBaseType.IsResourceType()
+
+	default:
+		return false
+	}
 }
 
 func (*CompositeType) IsInvalidType() bool {
@@ -3609,7 +3897,7 @@ func (t *CompositeType) IsStorable(results map[*Member]bool) bool {
 	// If this composite type has a member which is non-storable,
 	// then the composite type is not storable.
 
-	for pair := t.Members.Oldest(); pair != nil; pair = pair.Next() {
+	for pair := t.members().Oldest(); pair != nil; pair = pair.Next() {
 		if !pair.Value.IsStorable(results) {
 			return false
 		}
@@ -3624,6 +3912,13 @@ func (t *CompositeType) IsImportable(results map[*Member]bool) bool {
 		return t.importable
 	}
 
+	// Generic composite types are not importable,
+	// as values do not carry their type arguments when exported
+
+	if len(t.typeParameters) > 0 {
+		return false
+	}
+
 	// Only structures and enums can be imported
 
 	switch t.Kind {
@@ -3661,7 +3956,7 @@ func (t *CompositeType) IsExternallyReturnable(results map[*Member]bool) bool {
 	// If this composite type has a member which is not externally returnable,
 	// then the composite type is not externally returnable.
 
-	for p := t.Members.Oldest(); p != nil; p = p.Next() {
+	for p := t.members().Oldest(); p != nil; p = p.Next() {
 		if !p.Value.IsExternallyReturnable(results) {
 			return false
 		}
@@ -3693,6 +3988,7 @@ func (t *CompositeType) InterfaceType() *InterfaceType {
 		InitializerParameters: t.ConstructorParameters,
 		containerType:         t.containerType,
 		NestedTypes:           t.NestedTypes,
+		TypeAliases:           t.TypeAliases,
 	}
 }
 
@@ -3719,13 +4015,170 @@ func (t *CompositeType) TypeRequirements() []*CompositeType {
 	return typeRequirements
 }
 
-func (*CompositeType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
-	// TODO:
-	return false
+func (t *CompositeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+	if len(t.typeParameters) == 0 {
+		return false
+	}
+
+	otherComposite, ok := other.(*CompositeType)
+	if !ok || otherComposite.ID() != t.ID() {
+		return false
+	}
+
+	typeArguments := t.effectiveTypeArguments()
+	otherTypeArguments := otherComposite.effectiveTypeArguments()
+
+	if len(typeArguments) != len(otherTypeArguments) {
+		return false
+	}
+
+	result := false
+
+	for i, typeArgument := range typeArguments {
+		if typeArgument.Unify(otherTypeArguments[i], typeParameters, report, outerRange) {
+			result = true
+		}
+	}
+
+	return result
 }
 
-func (t *CompositeType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	return t
+func (t *CompositeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	if len(t.typeParameters) == 0 {
+		return t
+	}
+
+	currentTypeArguments := t.effectiveTypeArguments()
+	resolvedTypeArguments := make([]Type, len(currentTypeArguments))
+
+	for i, typeArgument := range currentTypeArguments {
+		resolvedTypeArgument := typeArgument.Resolve(typeArguments)
+		if resolvedTypeArgument == nil {
+			return nil
+		}
+		resolvedTypeArguments[i] = resolvedTypeArgument
+	}
+
+	return t.Instantiate(resolvedTypeArguments, nil)
+}
+
+func (t *CompositeType) TypeParameters() []*TypeParameter {
+	return t.typeParameters
+}
+
+// Instantiate returns the instantiation of the generic composite type
+// with the given type arguments.
+//
+// Instantiating the generic composite type with its own type parameters
+// results in the generic composite type itself.
+func (t *CompositeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+	genericType := t
+	if t.genericType != nil {
+		genericType = t.genericType
+	}
+
+	isGenericType := true
+	for i, typeParameter := range genericType.typeParameters {
+		genericTypeArgument, ok := typeArguments[i].(*GenericType)
+		if !ok || genericTypeArgument.TypeParameter != typeParameter {
+			isGenericType = false
+			break
+		}
+	}
+	if isGenericType {
+		return genericType
+	}
+
+	return &CompositeType{
+		Location:                            genericType.Location,
+		Identifier:                          genericType.Identifier,
+		Kind:                                genericType.Kind,
+		ExplicitInterfaceConformances:       genericType.ExplicitInterfaceConformances,
+		ImplicitTypeRequirementConformances: genericType.ImplicitTypeRequirementConformances,
+		NestedTypes:                         genericType.NestedTypes,
+		TypeAliases:                         genericType.TypeAliases,
+		containerType:                       genericType.containerType,
+		typeParameters:                      genericType.typeParameters,
+		typeArguments:                       typeArguments,
+		genericType:                         genericType,
+	}
+}
+
+// BaseType returns the generic composite type of an instantiation,
+// or nil if the composite type is not an instantiation
+func (t *CompositeType) BaseType() Type {
+	if t.genericType == nil {
+		return nil
+	}
+	return t.genericType
+}
+
+func (t *CompositeType) TypeArguments() []Type {
+	return t.typeArguments
+}
+
+// effectiveTypeArguments returns the type arguments of an instantiation,
+// or the type parameters as generic types if the composite type is the generic composite type itself
+func (t *CompositeType) effectiveTypeArguments() []Type {
+	if t.genericType != nil {
+		return t.typeArguments
+	}
+
+	typeArguments := make([]Type, len(t.typeParameters))
+	for i, typeParameter := range t.typeParameters {
+		typeArguments[i] = &GenericType{
+			TypeParameter: typeParameter,
+		}
+	}
+	return typeArguments
+}
+
+// members returns the members of the composite type.
+//
+// The members of an instantiation are the members of the generic composite type,
+// with the type parameters substituted by the type arguments.
+// They are determined lazily, as the members of the generic composite type
+// might not be declared yet when the instantiation is created
+func (t *CompositeType) members() *StringMemberOrderedMap {
+	genericType := t.genericType
+	if genericType == nil {
+		return t.Members
+	}
+
+	t.instantiatedMembersOnce.Do(func() {
+		typeArguments := &TypeParameterTypeOrderedMap{}
+		for i, typeParameter := range genericType.typeParameters {
+			typeArguments.Set(typeParameter, t.typeArguments[i])
+		}
+
+		members := &StringMemberOrderedMap{}
+
+		genericType.Members.Foreach(func(name string, member *Member) {
+			memberType := member.TypeAnnotation.Type
+			resolvedType := memberType.Resolve(typeArguments)
+			if resolvedType == nil || resolvedType == memberType {
+				members.Set(name, member)
+				return
+			}
+
+			instantiatedMember := *member
+			instantiatedMember.TypeAnnotation = NewTypeAnnotation(resolvedType)
+			members.Set(name, &instantiatedMember)
+		})
+
+		t.Members = members
+		t.Fields = genericType.Fields
+		t.ConstructorParameters = genericType.ConstructorParameters
+		t.EnumRawType = genericType.EnumRawType
+		t.hasComputedMembers = genericType.hasComputedMembers
+	})
+
+	return t.Members
 }
 
 func (t *CompositeType) IsContainerType() bool {
@@ -3738,9 +4191,11 @@ func (t *CompositeType) GetNestedTypes() *StringTypeOrderedMap {
 
 func (t *CompositeType) initializeMemberResolvers() {
 	t.memberResolversOnce.Do(func() {
-		members := make(map[string]MemberResolver, t.Members.Len())
+		compositeMembers := t.members()
 
-		t.Members.Foreach(func(name string, loopMember *Member) {
+		members := make(map[string]MemberResolver, compositeMembers.Len())
+
+		compositeMembers.Foreach(func(name string, loopMember *Member) {
 			// NOTE: don't capture loop variable
 			member := loopMember
 			members[name] = MemberResolver{
@@ -3967,7 +4422,9 @@ type InterfaceType struct {
 	InitializerParameters []*Parameter
 	containerType         Type
 	NestedTypes           *StringTypeOrderedMap
-	cachedIdentifiers     *struct {
+	// TypeAliases are the types aliased by the type aliases declared in the interface, if any
+	TypeAliases       *StringTypeOrderedMap
+	cachedIdentifiers *struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
 	}
@@ -4483,6 +4940,7 @@ func (t *DictionaryType) initializeMemberResolvers() {
 
 func DictionaryContainsKeyFunctionType(t *DictionaryType) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -4761,9 +5219,16 @@ func (*ReferenceType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err e
 	return false
 }
 
-func (t *ReferenceType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	// TODO:
-	return t
+func (t *ReferenceType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	newInnerType := t.Type.Resolve(typeArguments)
+	if newInnerType == nil {
+		return nil
+	}
+
+	return &ReferenceType{
+		Authorized: t.Authorized,
+		Type:       newInnerType,
+	}
 }
 
 const AddressTypeName = "Address"
@@ -4854,6 +5319,7 @@ func (t *AddressType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
 const AddressTypeToBytesFunctionName = `toBytes`
 
 var AddressTypeToBytesFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -4957,6 +5423,16 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		return true
 	}
 
+	// A generic type is a subtype of a type if its type bound is,
+	// as any type argument must be a subtype of the type bound
+
+	if typedSubType, ok := subType.(*GenericType); ok {
+		typeBound := typedSubType.TypeParameter.TypeBound
+		if typeBound != nil && IsSubType(typeBound, superType) {
+			return true
+		}
+	}
+
 	switch superType {
 	case AnyType:
 		return true
@@ -5265,6 +5741,15 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			return false
 		}
 
+		// View functions are subtypes of impure functions,
+		// but impure functions are not subtypes of view functions
+
+		if typedSubType.Purity != typedSuperType.Purity &&
+			typedSuperType.Purity == FunctionPurityView {
+
+			return false
+		}
+
 		if len(typedSubType.Parameters) != len(typedSuperType.Parameters) {
 			return false
 		}
@@ -5406,7 +5891,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					// `Us` and `Ws` do *not* have to be subsets:
 					// The owner may freely restrict and unrestrict.
 
-					return restrictedSubType == typedSuperType.Type
+					return restrictedSubType.Equal(typedSuperType.Type)
 				}
 
 			case *CompositeType:
@@ -5450,7 +5935,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				//
 				// The owner may freely unrestrict.
 
-				return restrictedSubType == typedSuperType
+				return restrictedSubType.Equal(typedSuperType)
 			}
 
 		case *CompositeType:
@@ -5528,8 +6013,14 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 
 	// T<Us> <: V
 	// if T <: V
+	//
+	// NOTE: instantiations of generic composite types are invariant
 
 	if typedSubType, ok := subType.(ParameterizedType); ok {
+		if _, ok := subType.(*CompositeType); ok {
+			return false
+		}
+
 		if baseType := typedSubType.BaseType(); baseType != nil {
 			return IsSubType(baseType, superType)
 		}
@@ -6137,6 +6628,7 @@ func CapabilityTypeBorrowFunctionType(borrowType Type) *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity:         FunctionPurityView,
 		TypeParameters: typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(
 			&OptionalType{
@@ -6157,6 +6649,7 @@ func CapabilityTypeCheckFunctionType(borrowType Type) *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity:               FunctionPurityView,
 		TypeParameters:       typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
 	}
@@ -6381,6 +6874,7 @@ var PublicKeyArrayType = &VariableSizedType{
 }
 
 var PublicKeyVerifyFunctionType = &FunctionType{
+	Purity:         FunctionPurityView,
 	TypeParameters: []*TypeParameter{},
 	Parameters: []*Parameter{
 		{
@@ -6408,6 +6902,7 @@ var PublicKeyVerifyFunctionType = &FunctionType{
 }
 
 var PublicKeyVerifyPoPFunctionType = &FunctionType{
+	Purity:         FunctionPurityView,
 	TypeParameters: []*TypeParameter{},
 	Parameters: []*Parameter{
 		{
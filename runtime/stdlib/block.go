@@ -33,6 +33,7 @@ Returns the current block, i.e. the block which contains the currently executed
 `
 
 var getCurrentBlockFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
 	ReturnTypeAnnotation: sema.NewTypeAnnotation(
 		sema.BlockType,
 	),
@@ -43,6 +44,7 @@ Returns the block at the given height. If the given block does not exist the fun
 `
 
 var getBlockFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
 	Parameters: []*sema.Parameter{
 		{
 			Label:      "at",
@@ -142,8 +142,11 @@ func (validator *ContractUpdateValidator) checkDeclarationUpdatability(
 
 	validator.checkNestedDeclarations(oldDeclaration, newDeclaration)
 
+	validator.checkTypeAliases(oldDeclaration, newDeclaration)
+
 	if newDecl, ok := newDeclaration.(*ast.CompositeDeclaration); ok {
 		if oldDecl, ok := oldDeclaration.(*ast.CompositeDeclaration); ok {
+			validator.checkTypeParameters(oldDecl, newDecl)
 			validator.checkConformances(oldDecl, newDecl)
 		}
 	}
@@ -253,6 +256,66 @@ func (validator *ContractUpdateValidator) checkNestedDeclarations(
 	validator.checkEnumCases(oldDeclaration, newDeclaration)
 }
 
+// checkTypeAliases validates updating type aliases. Type aliases may be referred to
+// by stored fields, also of other contracts, so existing type aliases must not be removed,
+// and must keep aliasing the same type. Adding type aliases is allowed.
+func (validator *ContractUpdateValidator) checkTypeAliases(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	oldTypeAliases := map[string]*ast.TypeAliasDeclaration{}
+	for _, oldTypeAlias := range oldDeclaration.DeclarationMembers().TypeAliases() {
+		oldTypeAliases[oldTypeAlias.Identifier.Identifier] = oldTypeAlias
+	}
+
+	for _, newTypeAlias := range newDeclaration.DeclarationMembers().TypeAliases() {
+		name := newTypeAlias.Identifier.Identifier
+
+		oldTypeAlias, found := oldTypeAliases[name]
+		if !found {
+			// Then it's a new declaration
+			continue
+		}
+
+		err := oldTypeAlias.AliasedType.CheckEqual(newTypeAlias.AliasedType, validator)
+		if err != nil {
+			validator.report(&TypeAliasMismatchError{
+				DeclName:      newDeclaration.DeclarationIdentifier().Identifier,
+				TypeAliasName: name,
+				Err:           err,
+				Range:         ast.NewUnmeteredRangeFromPositioned(newTypeAlias.AliasedType),
+			})
+		}
+
+		delete(oldTypeAliases, name)
+	}
+
+	// The remaining old type aliases don't have a corresponding new type alias,
+	// i.e., an existing type alias was removed.
+	// Hence, report an error.
+
+	missingTypeAliases := make([]*ast.TypeAliasDeclaration, 0, len(oldTypeAliases))
+
+	for _, typeAlias := range oldTypeAliases { //nolint:maprangecheck
+		missingTypeAliases = append(missingTypeAliases, typeAlias)
+	}
+
+	sort.Slice(missingTypeAliases, func(i, j int) bool {
+		return missingTypeAliases[i].Identifier.Identifier <
+			missingTypeAliases[j].Identifier.Identifier
+	})
+
+	for _, typeAlias := range missingTypeAliases {
+		validator.report(&MissingDeclarationError{
+			Name: typeAlias.Identifier.Identifier,
+			Kind: typeAlias.DeclarationKind(),
+			Range: ast.NewUnmeteredRangeFromPositioned(
+				newDeclaration.DeclarationIdentifier(),
+			),
+		})
+	}
+}
+
 func getNestedCompositeAndInterfaceDecls(declaration ast.Declaration) map[string]ast.Declaration {
 	compositeAndInterfaceDecls := map[string]ast.Declaration{}
 
@@ -312,6 +375,58 @@ func (validator *ContractUpdateValidator) checkEnumCases(oldDeclaration ast.Decl
 	}
 }
 
+// checkTypeParameters validates updating the type parameters of a composite declaration.
+// The type arguments of stored values are keyed by the name of the type parameter,
+// so the type parameters must not be added, removed, renamed, reordered, or re-bounded.
+func (validator *ContractUpdateValidator) checkTypeParameters(
+	oldDecl *ast.CompositeDeclaration,
+	newDecl *ast.CompositeDeclaration,
+) {
+	var oldTypeParameters, newTypeParameters []*ast.TypeParameter
+	if oldDecl.TypeParameterList != nil {
+		oldTypeParameters = oldDecl.TypeParameterList.TypeParameters
+	}
+	if newDecl.TypeParameterList != nil {
+		newTypeParameters = newDecl.TypeParameterList.TypeParameters
+	}
+
+	reportMismatch := func() {
+		validator.report(&TypeParameterMismatchError{
+			DeclName: newDecl.Identifier.Identifier,
+			Range:    ast.NewUnmeteredRangeFromPositioned(newDecl.Identifier),
+		})
+	}
+
+	if len(oldTypeParameters) != len(newTypeParameters) {
+		reportMismatch()
+		return
+	}
+
+	for index, oldTypeParameter := range oldTypeParameters {
+		newTypeParameter := newTypeParameters[index]
+
+		if oldTypeParameter.Identifier.Identifier != newTypeParameter.Identifier.Identifier {
+			reportMismatch()
+			return
+		}
+
+		oldTypeBound := oldTypeParameter.TypeBound
+		newTypeBound := newTypeParameter.TypeBound
+
+		if (oldTypeBound == nil) != (newTypeBound == nil) {
+			reportMismatch()
+			return
+		}
+
+		if oldTypeBound != nil &&
+			oldTypeBound.Type.CheckEqual(newTypeBound.Type, validator) != nil {
+
+			reportMismatch()
+			return
+		}
+	}
+}
+
 func (validator *ContractUpdateValidator) checkConformances(
 	oldDecl *ast.CompositeDeclaration,
 	newDecl *ast.CompositeDeclaration,
@@ -444,6 +559,48 @@ func (e *FieldMismatchError) SecondaryError() string {
 	return e.Err.Error()
 }
 
+// TypeAliasMismatchError is reported during a contract update, when the aliased type of a type alias
+// does not match the existing aliased type of the same type alias.
+type TypeAliasMismatchError struct {
+	DeclName      string
+	TypeAliasName string
+	Err           error
+	ast.Range
+}
+
+var _ errors.UserError = &TypeAliasMismatchError{}
+var _ errors.SecondaryError = &TypeAliasMismatchError{}
+
+func (*TypeAliasMismatchError) IsUserError() {}
+
+func (e *TypeAliasMismatchError) Error() string {
+	return fmt.Sprintf("mismatching type alias `%s` in `%s`",
+		e.TypeAliasName,
+		e.DeclName,
+	)
+}
+
+func (e *TypeAliasMismatchError) SecondaryError() string {
+	return e.Err.Error()
+}
+
+// TypeParameterMismatchError is reported during a contract update, when the type parameters
+// of a composite declaration do not match the existing type parameters.
+type TypeParameterMismatchError struct {
+	DeclName string
+	ast.Range
+}
+
+var _ errors.UserError = &TypeParameterMismatchError{}
+
+func (*TypeParameterMismatchError) IsUserError() {}
+
+func (e *TypeParameterMismatchError) Error() string {
+	return fmt.Sprintf("mismatching type parameters in `%s`",
+		e.DeclName,
+	)
+}
+
 // TypeMismatchError is reported during a contract update, when a type of the new program
 // does not match the existing type.
 type TypeMismatchError struct {
@@ -20,6 +20,9 @@
 package stdlib
 
 import (
+	"math"
+	"time"
+
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 )
@@ -57,6 +60,42 @@ type TestFramework interface {
 	UseConfiguration(configuration *Configuration)
 
 	StandardLibraryHandler() StandardLibraryHandler
+
+	// Events returns all events emitted from the blockchain, in the order they were emitted.
+	// If the event type is not nil, only the events of the given type are returned.
+	Events(inter *interpreter.Interpreter, eventType interpreter.StaticType) []interpreter.Value
+
+	// TransactionEvents returns the events emitted by the transaction
+	// which was last executed by ExecuteNextTransaction, in the order they were emitted.
+	TransactionEvents(inter *interpreter.Interpreter) []interpreter.Value
+
+	// Logs returns the messages logged by the scripts and transactions
+	// executed on the blockchain, in the order they were logged.
+	Logs() []string
+
+	// CreateSnapshot creates a snapshot of the blockchain with the given name,
+	// at the current block height.
+	CreateSnapshot(name string) error
+
+	// LoadSnapshot restores the blockchain to the snapshot with the given name.
+	LoadSnapshot(name string) error
+
+	// Reset restores the blockchain to the state at the given block height,
+	// i.e. all blocks committed after the given height are discarded.
+	Reset(height uint64) error
+
+	// MoveTime moves the time of the blockchain by the given delta,
+	// i.e. the timestamps of the current block and all following blocks are adjusted.
+	// See TestBlockProvider.
+	MoveTime(delta time.Duration)
+
+	// SetBlockHeight sets the height of the current block of the blockchain.
+	// See TestBlockProvider.
+	SetBlockHeight(height uint64) error
+
+	// ResetBlockHeight clears the height set using SetBlockHeight.
+	// See TestBlockProvider.
+	ResetBlockHeight()
 }
 
 type ScriptResult struct {
@@ -76,3 +115,111 @@ type Account struct {
 type Configuration struct {
 	Addresses map[string]common.Address
 }
+
+// TestBlockProvider is a CurrentBlockProvider which allows test frameworks
+// to control the metadata of the blocks of another provider, e.g. the provider of an emulator.
+//
+// The timestamps of all blocks can be moved by a delta,
+// and the heights of all blocks can be shifted by overriding the height of the current block.
+type TestBlockProvider struct {
+	provider  CurrentBlockProvider
+	timeDelta time.Duration
+	// height is the overridden height of the block
+	// which was the current block of the wrapped provider at baseHeight
+	height     uint64
+	baseHeight uint64
+	overridden bool
+}
+
+var _ CurrentBlockProvider = &TestBlockProvider{}
+
+func NewTestBlockProvider(provider CurrentBlockProvider) *TestBlockProvider {
+	return &TestBlockProvider{
+		provider: provider,
+	}
+}
+
+// MoveTime moves the timestamps of all blocks by the given delta.
+// Deltas accumulate.
+func (p *TestBlockProvider) MoveTime(delta time.Duration) {
+	p.timeDelta += delta
+}
+
+// SetBlockHeight overrides the height of the current block.
+// The heights of all blocks of the wrapped provider are shifted consistently,
+// i.e. the parent of the current block is reported at the given height minus one, etc.
+func (p *TestBlockProvider) SetBlockHeight(height uint64) error {
+	baseHeight, err := p.provider.GetCurrentBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	p.height = height
+	p.baseHeight = baseHeight
+	p.overridden = true
+
+	return nil
+}
+
+// ResetBlockHeight clears the height override,
+// i.e. the blocks of the wrapped provider are reported at their original heights.
+func (p *TestBlockProvider) ResetBlockHeight() {
+	p.height = 0
+	p.baseHeight = 0
+	p.overridden = false
+}
+
+func (p *TestBlockProvider) GetCurrentBlockHeight() (uint64, error) {
+	height, err := p.provider.GetCurrentBlockHeight()
+	if err != nil || !p.overridden {
+		return height, err
+	}
+
+	// The wrapped provider may have been reset far below the base height,
+	// in which case the shifted height is clamped to zero
+	height, ok := shiftBlockHeight(height, p.baseHeight, p.height)
+	if !ok {
+		return 0, nil
+	}
+	return height, nil
+}
+
+func (p *TestBlockProvider) GetBlockAtHeight(height uint64) (block Block, exists bool, err error) {
+	providerHeight := height
+	if p.overridden {
+		var ok bool
+		providerHeight, ok = shiftBlockHeight(height, p.height, p.baseHeight)
+		if !ok {
+			return Block{}, false, nil
+		}
+	}
+
+	block, exists, err = p.provider.GetBlockAtHeight(providerHeight)
+	if err != nil || !exists {
+		return block, exists, err
+	}
+
+	block.Height = height
+	block.Timestamp += int64(p.timeDelta)
+
+	return block, true, nil
+}
+
+// shiftBlockHeight maps the given height, relative to the height from,
+// to the same relative height, relative to the height to.
+// The result is not ok if the mapped height is not representable.
+func shiftBlockHeight(height, from, to uint64) (uint64, bool) {
+	if height >= from {
+		delta := height - from
+		if delta > math.MaxUint64-to {
+			return 0, false
+		}
+		return to + delta, true
+	}
+
+	delta := from - height
+	if delta > to {
+		return 0, false
+	}
+	return to - delta, true
+}
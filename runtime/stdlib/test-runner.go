@@ -0,0 +1,261 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Test runner.
+//
+// A test program declares test functions, i.e. global functions
+// which have a name starting with 'test', no parameters, and no return value.
+//
+// The test program may declare the following lifecycle hooks, with the same signature:
+//   - 'setup' is invoked once, before all tests
+//   - 'beforeEach' is invoked before each test
+//   - 'afterEach' is invoked after each test
+//   - 'tearDown' is invoked once, after all tests
+
+const testFunctionPrefix = "test"
+
+const setupFunctionName = "setup"
+const beforeEachFunctionName = "beforeEach"
+const afterEachFunctionName = "afterEach"
+const tearDownFunctionName = "tearDown"
+
+// TestResult is the result of a test function.
+type TestResult struct {
+	TestName string
+	// Error is nil if the test passed,
+	// and a TestFailedError if the test failed
+	Error error
+}
+
+// Passed returns true if the test passed.
+func (r TestResult) Passed() bool {
+	return r.Error == nil
+}
+
+func (r TestResult) String() string {
+	if r.Passed() {
+		return fmt.Sprintf("- PASS: %s", r.TestName)
+	}
+	return fmt.Sprintf("- FAIL: %s\n\t\t%s", r.TestName, r.Error.Error())
+}
+
+// TestResults are the results of all test functions of a test program, in declaration order.
+type TestResults []TestResult
+
+// Passed returns true if all tests passed.
+func (r TestResults) Passed() bool {
+	for _, result := range r {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the results of the tests which failed.
+func (r TestResults) Failed() TestResults {
+	var failed TestResults
+	for _, result := range r {
+		if !result.Passed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (r TestResults) String() string {
+	var builder strings.Builder
+	builder.WriteString("Test results:\n")
+	for _, result := range r {
+		builder.WriteString(result.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// TestFunctionNames returns the names of the test functions declared in the given program,
+// in declaration order.
+// Functions which have a name starting with 'test', but parameters or a return value,
+// are not test functions.
+// Imported functions are not test functions of the program.
+func TestFunctionNames(program *interpreter.Program) []string {
+	var names []string
+
+	for _, declaration := range program.Program.FunctionDeclarations() {
+		name := declaration.Identifier.Identifier
+		if !strings.HasPrefix(name, testFunctionPrefix) {
+			continue
+		}
+
+		variable, ok := program.Elaboration.GlobalValues.Get(name)
+		if ok && isTestRunnerFunction(variable) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// isDeclaredGlobal returns true if the given program declares a global with the given name,
+// i.e. the global is not imported
+func isDeclaredGlobal(program *interpreter.Program, name string) bool {
+	for _, declaration := range program.Program.Declarations() {
+		identifier := declaration.DeclarationIdentifier()
+		if identifier != nil && identifier.Identifier == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestRunnerFunction returns true if the variable is a global function
+// that can be invoked by the test runner, i.e. it has no parameters and no return value
+func isTestRunnerFunction(variable *sema.Variable) bool {
+	if variable.DeclarationKind != common.DeclarationKindFunction {
+		return false
+	}
+
+	functionType, ok := variable.Type.(*sema.FunctionType)
+	if !ok {
+		return false
+	}
+
+	return len(functionType.Parameters) == 0 &&
+		functionType.ReturnTypeAnnotation.Type.Equal(sema.VoidType)
+}
+
+// TestRunner runs the test functions of an interpreted test program,
+// and invokes the lifecycle hooks around them.
+type TestRunner struct {
+	inter *interpreter.Interpreter
+}
+
+// NewTestRunner returns a runner for the test program of the given interpreter.
+// The program must already be interpreted.
+func NewTestRunner(inter *interpreter.Interpreter) *TestRunner {
+	return &TestRunner{
+		inter: inter,
+	}
+}
+
+// RunTests runs all test functions of the program.
+//
+// The 'setup' hook is invoked before all tests, and the 'tearDown' hook after all tests.
+// If either fails, an error is returned.
+func (r *TestRunner) RunTests() (TestResults, error) {
+	err := r.invokeHook(setupFunctionName)
+	if err != nil {
+		return nil, err
+	}
+
+	testNames := TestFunctionNames(r.inter.Program)
+
+	results := make(TestResults, 0, len(testNames))
+	for _, testName := range testNames {
+		results = append(results, r.RunTest(testName))
+	}
+
+	err = r.invokeHook(tearDownFunctionName)
+	if err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// RunTest runs the test function with the given name.
+//
+// The 'beforeEach' hook is invoked before the test, and the 'afterEach' hook after the test.
+// If either fails, the test fails.
+// The 'setup' and 'tearDown' hooks are not invoked.
+func (r *TestRunner) RunTest(testName string) TestResult {
+	result := TestResult{
+		TestName: testName,
+	}
+
+	err := r.invokeHook(beforeEachFunctionName)
+	if err == nil {
+		_, err = r.inter.Invoke(testName)
+	}
+
+	afterEachErr := r.invokeHook(afterEachFunctionName)
+	if err == nil {
+		err = afterEachErr
+	}
+
+	if err != nil {
+		result.Error = TestFailedError{
+			Err: err,
+		}
+	}
+
+	return result
+}
+
+// invokeHook invokes the lifecycle hook with the given name, if it is declared.
+// Imported functions are not lifecycle hooks of the program.
+func (r *TestRunner) invokeHook(name string) error {
+	program := r.inter.Program
+
+	if !isDeclaredGlobal(program, name) {
+		return nil
+	}
+
+	variable, ok := program.Elaboration.GlobalValues.Get(name)
+	if !ok {
+		return nil
+	}
+
+	if !isTestRunnerFunction(variable) {
+		return InvalidTestLifecycleHookError{
+			Name: name,
+		}
+	}
+
+	_, err := r.inter.Invoke(name)
+	return err
+}
+
+// InvalidTestLifecycleHookError is reported when a lifecycle hook of a test program,
+// e.g. 'setup', is not a function without parameters and without a return value.
+type InvalidTestLifecycleHookError struct {
+	Name string
+}
+
+var _ errors.UserError = InvalidTestLifecycleHookError{}
+
+func (InvalidTestLifecycleHookError) IsUserError() {}
+
+func (e InvalidTestLifecycleHookError) Error() string {
+	return fmt.Sprintf(
+		"invalid test lifecycle hook '%s': expected a function without parameters and without a return value",
+		e.Name,
+	)
+}
@@ -19,7 +19,13 @@
 package stdlib
 
 import (
+	goErrors "errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -54,6 +60,10 @@ const transactionArgsFieldName = "arguments"
 
 const accountAddressFieldName = "address"
 
+const resultStatusFieldName = "status"
+const resultErrorFieldName = "error"
+const transactionResultEventsFieldName = "events"
+
 const matcherTestFunctionName = "test"
 
 const addressesFieldName = "addresses"
@@ -118,12 +128,15 @@ func NewTestContract(
 	compositeValue.Functions[testAssertFunctionName] = testAssertFunction
 	compositeValue.Functions[testFailFunctionName] = testFailFunction
 	compositeValue.Functions[testExpectFunctionName] = testExpectFunction
+	compositeValue.Functions[testExpectFailureFunctionName] = testExpectFailureFunction
 	compositeValue.Functions[testNewEmulatorBlockchainFunctionName] = testNewEmulatorBlockchainFunction(testFramework)
 	compositeValue.Functions[testReadFileFunctionName] = testReadFileFunction(testFramework)
 
 	// Inject natively implemented matchers
 	compositeValue.Functions[newMatcherFunctionName] = newMatcherFunction
+	compositeValue.Functions[newEventMatcherFunctionName] = newEventMatcherFunction
 	compositeValue.Functions[equalMatcherFunctionName] = equalMatcherFunction
+	compositeValue.Functions[containLogMatcherFunctionName] = containLogMatcherFunction
 
 	return compositeValue, nil
 }
@@ -255,6 +268,17 @@ func init() {
 		),
 	)
 
+	// Test.expectFailure()
+	testContractType.Members.Set(
+		testExpectFailureFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testExpectFailureFunctionName,
+			testExpectFailureFunctionType,
+			testExpectFailureFunctionDocString,
+		),
+	)
+
 	// Test.newEmulatorBlockchain()
 	testContractType.Members.Set(
 		testNewEmulatorBlockchainFunctionName,
@@ -277,6 +301,17 @@ func init() {
 		),
 	)
 
+	// Test.newEventMatcher()
+	testContractType.Members.Set(
+		newEventMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			newEventMatcherFunctionName,
+			newEventMatcherFunctionType,
+			newEventMatcherFunctionDocString,
+		),
+	)
+
 	// Matcher functions
 	testContractType.Members.Set(
 		equalMatcherFunctionName,
@@ -287,6 +322,15 @@ func init() {
 			equalMatcherFunctionDocString,
 		),
 	)
+	testContractType.Members.Set(
+		containLogMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			containLogMatcherFunctionName,
+			containLogMatcherFunctionType,
+			containLogMatcherFunctionDocString,
+		),
+	)
 
 	// Test.readFile()
 	testContractType.Members.Set(
@@ -476,6 +520,116 @@ var testExpectFunction = interpreter.NewUnmeteredHostFunctionValue(
 	testExpectFunctionType,
 )
 
+// 'Test.expectFailure' function
+
+const testExpectFailureFunctionDocString = `
+Expect function tests that the given function fails with an error that includes the given error message,
+and fails the test if the function succeeds, or fails with a different error.
+Only errors caused by the function are expected failures, other errors, e.g. internal errors, are propagated.
+`
+
+const testExpectFailureFunctionName = "expectFailure"
+
+var testExpectFailureFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "functionWrapper",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.NewTypeAnnotation(
+						sema.VoidType,
+					),
+				},
+			),
+		},
+		{
+			Identifier: "errorMessageSubstring",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+var testExpectFailureFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		function, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		errorMessageSubstring, ok := invocation.Arguments[1].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		_, err := inter.InvokeFunction(
+			function,
+			interpreter.NewInvocation(
+				inter,
+				nil,
+				nil,
+				nil,
+				nil,
+				locationRange,
+			),
+		)
+
+		if err == nil {
+			panic(AssertionError{
+				Message:       "expected a failure, but the function succeeded",
+				LocationRange: locationRange,
+			})
+		}
+
+		// Only failures of the program are expected,
+		// other errors, e.g. internal errors, are propagated
+		if !isProgramFailure(err) {
+			panic(err)
+		}
+
+		if !strings.Contains(err.Error(), errorMessageSubstring.Str) {
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"expected error message to include: %q, got: %q",
+					errorMessageSubstring.Str,
+					err.Error(),
+				),
+				LocationRange: locationRange,
+			})
+		}
+
+		return interpreter.Void
+	},
+	testExpectFailureFunctionType,
+)
+
+// isProgramFailure returns true if the given error is a user error caused by the program,
+// and not e.g. an internal error, an external error, or a memory error
+func isProgramFailure(err error) bool {
+	if errors.IsInternalError(err) {
+		return false
+	}
+
+	if _, ok := errors.GetExternalError(err); ok {
+		return false
+	}
+
+	var memoryErr errors.MemoryError
+	if goErrors.As(err, &memoryErr) {
+		return false
+	}
+
+	return errors.IsUserError(err)
+}
+
 func invokeMatcherTest(
 	inter *interpreter.Interpreter,
 	matcher interpreter.MemberAccessibleValue,
@@ -692,11 +846,49 @@ var newMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
 			panic(errors.NewUnreachableError())
 		}
 
-		return newMatcherWithGenericTestFunction(invocation, test)
+		return newMatcherWithGenericTestFunction(invocation, test, false)
 	},
 	newMatcherFunctionType,
 )
 
+// 'Test.newEventMatcher' function.
+// Constructs a matcher that tests values of a specific type, e.g. events.
+// Unlike matchers created with 'Test.newMatcher', values of other types do not match,
+// instead of failing the test, so the matcher can be applied to all emitted events.
+//
+// Signature:
+//    fun newEventMatcher<T: AnyStruct>(test: ((T): Bool)): Test.Matcher
+//
+// Sample usage:
+//    `Test.contain(Test.newEventMatcher(fun (_ event: Foo.Minted): Bool { return event.amount > 5 }))`
+
+const newEventMatcherFunctionDocString = `
+Creates a matcher with a test function, which only matches values of the parameter type of the test function, e.g. events.
+The test function is of type '((T): Bool)', where 'T' is bound to 'AnyStruct'.
+Values of other types do not match.
+`
+
+const newEventMatcherFunctionName = "newEventMatcher"
+
+var newEventMatcherFunctionType = &sema.FunctionType{
+	IsConstructor:        newMatcherFunctionType.IsConstructor,
+	Parameters:           newMatcherFunctionType.Parameters,
+	ReturnTypeAnnotation: newMatcherFunctionType.ReturnTypeAnnotation,
+	TypeParameters:       newMatcherFunctionType.TypeParameters,
+}
+
+var newEventMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		test, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		return newMatcherWithGenericTestFunction(invocation, test, true)
+	},
+	newEventMatcherFunctionType,
+)
+
 // 'EmulatorBackend' struct.
 //
 // 'EmulatorBackend' is the native implementation of the 'Test.BlockchainBackend' interface.
@@ -758,6 +950,54 @@ var EmulatorBackendType = func() *sema.CompositeType {
 			emulatorBackendUseConfigFunctionType,
 			emulatorBackendUseConfigFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendEventsFunctionName,
+			emulatorBackendEventsFunctionType,
+			emulatorBackendEventsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendLogsFunctionName,
+			emulatorBackendLogsFunctionType,
+			emulatorBackendLogsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendCreateSnapshotFunctionName,
+			emulatorBackendCreateSnapshotFunctionType,
+			emulatorBackendCreateSnapshotFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendLoadSnapshotFunctionName,
+			emulatorBackendLoadSnapshotFunctionType,
+			emulatorBackendLoadSnapshotFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendResetFunctionName,
+			emulatorBackendResetFunctionType,
+			emulatorBackendResetFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendMoveTimeFunctionName,
+			emulatorBackendMoveTimeFunctionType,
+			emulatorBackendMoveTimeFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendSetBlockHeightFunctionName,
+			emulatorBackendSetBlockHeightFunctionType,
+			emulatorBackendSetBlockHeightFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendResetBlockHeightFunctionName,
+			emulatorBackendResetBlockHeightFunctionType,
+			emulatorBackendResetBlockHeightFunctionDocString,
+		),
 	}
 
 	ty.Members = sema.GetMembersAsMap(members)
@@ -799,6 +1039,38 @@ func newEmulatorBackend(
 			Name:  emulatorBackendUseConfigFunctionName,
 			Value: emulatorBackendUseConfigFunction(testFramework),
 		},
+		{
+			Name:  emulatorBackendEventsFunctionName,
+			Value: emulatorBackendEventsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendLogsFunctionName,
+			Value: emulatorBackendLogsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendCreateSnapshotFunctionName,
+			Value: emulatorBackendCreateSnapshotFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendLoadSnapshotFunctionName,
+			Value: emulatorBackendLoadSnapshotFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendResetFunctionName,
+			Value: emulatorBackendResetFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendMoveTimeFunctionName,
+			Value: emulatorBackendMoveTimeFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendSetBlockHeightFunctionName,
+			Value: emulatorBackendSetBlockHeightFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendResetBlockHeightFunctionName,
+			Value: emulatorBackendResetBlockHeightFunction(testFramework),
+		},
 	}
 
 	return interpreter.NewCompositeValue(
@@ -1186,6 +1458,9 @@ var emulatorBackendExecuteNextTransactionFunctionType = interfaceFunctionType(
 func emulatorBackendExecuteNextTransactionFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
 	return interpreter.NewUnmeteredHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
 			result := testFramework.ExecuteNextTransaction()
 
 			// If there are no transactions to run, then return `nil`.
@@ -1193,14 +1468,22 @@ func emulatorBackendExecuteNextTransactionFunction(testFramework TestFramework)
 				return interpreter.Nil
 			}
 
-			return newTransactionResult(invocation.Interpreter, result)
+			events := testFramework.TransactionEvents(inter)
+
+			return newTransactionResult(inter, locationRange, result, events)
 		},
 		emulatorBackendExecuteNextTransactionFunctionType,
 	)
 }
 
-// newTransactionResult Creates a "TransactionResult" indicating the status of the transaction execution.
-func newTransactionResult(inter *interpreter.Interpreter, result *TransactionResult) interpreter.Value {
+// newTransactionResult Creates a "TransactionResult" indicating the status of the transaction execution,
+// and the events emitted by the transaction.
+func newTransactionResult(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	result *TransactionResult,
+	events []interpreter.Value,
+) interpreter.Value {
 	// Lookup and get 'ResultStatus' enum value.
 	resultStatusConstructor := getConstructor(inter, resultStatusTypeName)
 	var status interpreter.Value
@@ -1212,25 +1495,60 @@ func newTransactionResult(inter *interpreter.Interpreter, result *TransactionRes
 		status = failedVar.GetValue()
 	}
 
-	// Create a 'TransactionResult' by calling its constructor.
-	transactionResultConstructor := getConstructor(inter, transactionResultTypeName)
-
 	errValue := newErrorValue(inter, result.Error)
 
-	transactionResult, err := inter.InvokeExternally(
-		transactionResultConstructor,
-		transactionResultConstructor.Type,
-		[]interpreter.Value{
-			status,
-			errValue,
+	// Create a 'TransactionResult' with the events of the transaction.
+	// The initializer only accepts the status and the error,
+	// so the value is created directly instead of calling the constructor.
+	return newTestContractCompositeValue(
+		inter,
+		locationRange,
+		transactionResultTypeName,
+		[]interpreter.CompositeField{
+			{
+				Name:  resultStatusFieldName,
+				Value: status,
+			},
+			{
+				Name:  resultErrorFieldName,
+				Value: errValue,
+			},
+			{
+				Name:  transactionResultEventsFieldName,
+				Value: newEventsArrayValue(inter, locationRange, events),
+			},
 		},
 	)
+}
 
-	if err != nil {
-		panic(err)
+// newTestContractCompositeValue creates a value of the structure with the given name,
+// which is declared in the test contract, with the given fields.
+// Like arguments of a constructor, the values of the fields are transferred.
+func newTestContractCompositeValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	typeName string,
+	fields []interpreter.CompositeField,
+) *interpreter.CompositeValue {
+	for i, field := range fields {
+		fields[i].Value = field.Value.Transfer(
+			inter,
+			locationRange,
+			atree.Address{},
+			false,
+			nil,
+		)
 	}
 
-	return transactionResult
+	return interpreter.NewCompositeValue(
+		inter,
+		locationRange,
+		TestContractLocation,
+		testContractTypeName+"."+typeName,
+		common.CompositeKindStructure,
+		fields,
+		common.Address{},
+	)
 }
 
 func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value {
@@ -1238,22 +1556,161 @@ func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value
 		return interpreter.Nil
 	}
 
-	// Create a 'Error' by calling its constructor.
-	errorConstructor := getConstructor(inter, errorTypeName)
-
-	errorValue, invocationErr := inter.InvokeExternally(
-		errorConstructor,
-		errorConstructor.Type,
-		[]interpreter.Value{
-			interpreter.NewUnmeteredStringValue(err.Error()),
+	// Create a 'Error' with the kind of the error.
+	// The initializer only accepts the message,
+	// so the value is created directly instead of calling the constructor.
+	return newTestContractCompositeValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		errorTypeName,
+		[]interpreter.CompositeField{
+			{
+				Name:  errorMessageFieldName,
+				Value: interpreter.NewUnmeteredStringValue(err.Error()),
+			},
+			{
+				Name:  errorKindFieldName,
+				Value: interpreter.NewUnmeteredStringValue(ErrorKind(err)),
+			},
 		},
 	)
+}
+
+const errorMessageFieldName = "message"
+const errorKindFieldName = "kind"
+
+// Error kinds, exposed as the 'kind' field of 'Test.Error'
+const (
+	ErrorKindCondition              = "ConditionError"
+	ErrorKindAssertion              = "AssertionError"
+	ErrorKindPanic                  = "PanicError"
+	ErrorKindForceNil               = "ForceNilError"
+	ErrorKindForceCastTypeMismatch  = "ForceCastTypeMismatchError"
+	ErrorKindOverflow               = "OverflowError"
+	ErrorKindUnderflow              = "UnderflowError"
+	ErrorKindDivisionByZero         = "DivisionByZeroError"
+	ErrorKindArrayIndexOutOfBounds  = "ArrayIndexOutOfBoundsError"
+	ErrorKindStringIndexOutOfBounds = "StringIndexOutOfBoundsError"
+	ErrorKindDereference            = "DereferenceError"
+	ErrorKindInvalidatedResource    = "InvalidatedResourceError"
+	ErrorKindDestroyedResource      = "DestroyedResourceError"
+	ErrorKindOverwrite              = "OverwriteError"
+	ErrorKindContractUpdate         = "ContractUpdateError"
+	ErrorKindParser                 = "ParserError"
+	ErrorKindChecker                = "CheckerError"
+)
+
+// Checker error kinds, exposed as the 'kind' field of 'Test.Error'.
+// The kind of a checker error is the kind of the first reported error which has a known kind,
+// or ErrorKindChecker if none of the reported errors has a known kind
+const (
+	ErrorKindTypeMismatch               = "TypeMismatchError"
+	ErrorKindNotDeclared                = "NotDeclaredError"
+	ErrorKindNotDeclaredMember          = "NotDeclaredMemberError"
+	ErrorKindRedeclaration              = "RedeclarationError"
+	ErrorKindAssignmentToConstant       = "AssignmentToConstantError"
+	ErrorKindAssignmentToConstantMember = "AssignmentToConstantMemberError"
+	ErrorKindArgumentCount              = "ArgumentCountError"
+	ErrorKindMissingArgumentLabel       = "MissingArgumentLabelError"
+	ErrorKindIncorrectArgumentLabel     = "IncorrectArgumentLabelError"
+	ErrorKindInvalidBinaryOperands      = "InvalidBinaryOperandsError"
+	ErrorKindMissingReturnStatement     = "MissingReturnStatementError"
+	ErrorKindResourceLoss               = "ResourceLossError"
+	ErrorKindInvalidAccess              = "InvalidAccessError"
+	ErrorKindConformance                = "ConformanceError"
+	ErrorKindPurity                     = "PurityError"
+)
+
+// ErrorKind returns the kind of the given error, which is exposed as the 'kind' field of 'Test.Error'.
+//
+// Wrapping errors, e.g. interpreter.Error, are unwrapped until an error of a known kind is found.
+// If the kind of the error is unknown, the kind is empty.
+func ErrorKind(err error) string {
+	for err != nil {
+		switch err := err.(type) {
+		case interpreter.ConditionError:
+			return ErrorKindCondition
+		case AssertionError:
+			return ErrorKindAssertion
+		case PanicError:
+			return ErrorKindPanic
+		case interpreter.ForceNilError:
+			return ErrorKindForceNil
+		case interpreter.ForceCastTypeMismatchError:
+			return ErrorKindForceCastTypeMismatch
+		case interpreter.OverflowError:
+			return ErrorKindOverflow
+		case interpreter.UnderflowError:
+			return ErrorKindUnderflow
+		case interpreter.DivisionByZeroError:
+			return ErrorKindDivisionByZero
+		case interpreter.ArrayIndexOutOfBoundsError:
+			return ErrorKindArrayIndexOutOfBounds
+		case interpreter.StringIndexOutOfBoundsError:
+			return ErrorKindStringIndexOutOfBounds
+		case interpreter.DereferenceError:
+			return ErrorKindDereference
+		case interpreter.InvalidatedResourceError:
+			return ErrorKindInvalidatedResource
+		case interpreter.DestroyedResourceError:
+			return ErrorKindDestroyedResource
+		case interpreter.OverwriteError:
+			return ErrorKindOverwrite
+		case *ContractUpdateError:
+			return ErrorKindContractUpdate
+		case parser.Error:
+			return ErrorKindParser
+		case sema.CheckerError:
+			return checkerErrorKind(err.Errors)
+		case *sema.CheckerError:
+			return checkerErrorKind(err.Errors)
+		}
+
+		err = goErrors.Unwrap(err)
+	}
+
+	return ""
+}
 
-	if invocationErr != nil {
-		panic(invocationErr)
+// checkerErrorKind returns the kind of the first of the given checker errors which has a known kind,
+// or ErrorKindChecker if none of the errors has a known kind
+func checkerErrorKind(errs []error) string {
+	for _, err := range errs {
+		switch err.(type) {
+		case *sema.TypeMismatchError:
+			return ErrorKindTypeMismatch
+		case *sema.NotDeclaredError:
+			return ErrorKindNotDeclared
+		case *sema.NotDeclaredMemberError:
+			return ErrorKindNotDeclaredMember
+		case *sema.RedeclarationError:
+			return ErrorKindRedeclaration
+		case *sema.AssignmentToConstantError:
+			return ErrorKindAssignmentToConstant
+		case *sema.AssignmentToConstantMemberError:
+			return ErrorKindAssignmentToConstantMember
+		case *sema.ArgumentCountError:
+			return ErrorKindArgumentCount
+		case *sema.MissingArgumentLabelError:
+			return ErrorKindMissingArgumentLabel
+		case *sema.IncorrectArgumentLabelError:
+			return ErrorKindIncorrectArgumentLabel
+		case *sema.InvalidBinaryOperandsError:
+			return ErrorKindInvalidBinaryOperands
+		case *sema.MissingReturnStatementError:
+			return ErrorKindMissingReturnStatement
+		case *sema.ResourceLossError:
+			return ErrorKindResourceLoss
+		case *sema.InvalidAccessError:
+			return ErrorKindInvalidAccess
+		case *sema.ConformanceError:
+			return ErrorKindConformance
+		case *sema.PurityError:
+			return ErrorKindPurity
+		}
 	}
 
-	return errorValue
+	return ErrorKindChecker
 }
 
 // 'EmulatorBackend.commitBlock' function
@@ -1345,11 +1802,68 @@ var equalMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
 			matcherTestFunctionType,
 		)
 
-		return newMatcherWithGenericTestFunction(invocation, equalTestFunc)
+		return newMatcherWithGenericTestFunction(invocation, equalTestFunc, false)
 	},
 	equalMatcherFunctionType,
 )
 
+const containLogMatcherFunctionName = "containLog"
+
+const containLogMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is an array of log messages,
+and one of the messages contains the given text.
+`
+
+var containLogMatcherFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "text",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var containLogMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		text, ok := invocation.Arguments[0].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+
+		containLogTestFunc := interpreter.NewHostFunctionValue(
+			nil,
+			func(invocation interpreter.Invocation) interpreter.Value {
+
+				logs, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+				if !ok {
+					return interpreter.FalseValue
+				}
+
+				found := false
+
+				logs.Iterate(inter, func(element interpreter.Value) (resume bool) {
+					message, ok := element.(*interpreter.StringValue)
+					if ok && strings.Contains(message.Str, text.Str) {
+						found = true
+						return false
+					}
+					return true
+				})
+
+				return interpreter.AsBoolValue(found)
+			},
+			matcherTestFunctionType,
+		)
+
+		return newMatcherWithGenericTestFunction(invocation, containLogTestFunc, false)
+	},
+	containLogMatcherFunctionType,
+)
+
 // 'EmulatorBackend.deployContract' function
 
 const emulatorBackendDeployContractFunctionName = "deployContract"
@@ -1467,6 +1981,294 @@ func emulatorBackendUseConfigFunction(testFramework TestFramework) *interpreter.
 	)
 }
 
+// 'EmulatorBackend.events' function
+
+const emulatorBackendEventsFunctionName = "events"
+
+const emulatorBackendEventsFunctionDocString = `
+Returns all events emitted from the blockchain, in the order they were emitted.
+If a type is given, only the events of the given type are returned.
+`
+
+var emulatorBackendEventsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendEventsFunctionName,
+)
+
+func emulatorBackendEventsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			var eventType interpreter.StaticType
+
+			switch value := invocation.Arguments[0].(type) {
+			case interpreter.NilValue:
+				// All events
+
+			case *interpreter.SomeValue:
+				typeValue, ok := value.InnerValue(inter, locationRange).(interpreter.TypeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				eventType = typeValue.Type
+
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
+			events := testFramework.Events(inter, eventType)
+
+			return newEventsArrayValue(inter, locationRange, events)
+		},
+		emulatorBackendEventsFunctionType,
+	)
+}
+
+// 'EmulatorBackend.logs' function
+
+const emulatorBackendLogsFunctionName = "logs"
+
+const emulatorBackendLogsFunctionDocString = `
+Returns the messages logged by the scripts and transactions executed on the blockchain,
+in the order they were logged.
+`
+
+var emulatorBackendLogsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendLogsFunctionName,
+)
+
+var logsArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: interpreter.PrimitiveStaticTypeString,
+}
+
+func emulatorBackendLogsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			logs := testFramework.Logs()
+
+			values := make([]interpreter.Value, 0, len(logs))
+			for _, message := range logs {
+				values = append(values, interpreter.NewUnmeteredStringValue(message))
+			}
+
+			return interpreter.NewArrayValue(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				logsArrayStaticType,
+				common.Address{},
+				values...,
+			)
+		},
+		emulatorBackendLogsFunctionType,
+	)
+}
+
+// 'EmulatorBackend.createSnapshot' function
+
+const emulatorBackendCreateSnapshotFunctionName = "createSnapshot"
+
+const emulatorBackendCreateSnapshotFunctionDocString = `
+Creates a snapshot of the blockchain with the given name, at the current block height.
+`
+
+var emulatorBackendCreateSnapshotFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendCreateSnapshotFunctionName,
+)
+
+func emulatorBackendCreateSnapshotFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			err := testFramework.CreateSnapshot(name.Str)
+
+			return newErrorValue(invocation.Interpreter, err)
+		},
+		emulatorBackendCreateSnapshotFunctionType,
+	)
+}
+
+// 'EmulatorBackend.loadSnapshot' function
+
+const emulatorBackendLoadSnapshotFunctionName = "loadSnapshot"
+
+const emulatorBackendLoadSnapshotFunctionDocString = `
+Restores the blockchain to the snapshot with the given name.
+`
+
+var emulatorBackendLoadSnapshotFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendLoadSnapshotFunctionName,
+)
+
+func emulatorBackendLoadSnapshotFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			err := testFramework.LoadSnapshot(name.Str)
+
+			return newErrorValue(invocation.Interpreter, err)
+		},
+		emulatorBackendLoadSnapshotFunctionType,
+	)
+}
+
+// 'EmulatorBackend.reset' function
+
+const emulatorBackendResetFunctionName = "reset"
+
+const emulatorBackendResetFunctionDocString = `
+Resets the blockchain to the state at the given block height,
+i.e. all blocks committed after the given height are discarded.
+`
+
+var emulatorBackendResetFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendResetFunctionName,
+)
+
+func emulatorBackendResetFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			height, ok := invocation.Arguments[0].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			err := testFramework.Reset(uint64(height))
+
+			return newErrorValue(invocation.Interpreter, err)
+		},
+		emulatorBackendResetFunctionType,
+	)
+}
+
+// 'EmulatorBackend.moveTime' function
+
+const emulatorBackendMoveTimeFunctionName = "moveTime"
+
+const emulatorBackendMoveTimeFunctionDocString = `
+Moves the time of the blockchain by the given number of seconds,
+i.e. the timestamps of the current block and all following blocks are adjusted.
+`
+
+var emulatorBackendMoveTimeFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendMoveTimeFunctionName,
+)
+
+// nanosecondsPerFix64Unit is the number of nanoseconds represented by the smallest Fix64 unit,
+// when the Fix64 value is a number of seconds
+const nanosecondsPerFix64Unit = int64(time.Second) / sema.Fix64Factor
+
+func emulatorBackendMoveTimeFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			delta, ok := invocation.Arguments[0].(interpreter.Fix64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			if int64(delta) > math.MaxInt64/nanosecondsPerFix64Unit ||
+				int64(delta) < math.MinInt64/nanosecondsPerFix64Unit {
+
+				panic(interpreter.OverflowError{
+					LocationRange: invocation.LocationRange,
+				})
+			}
+
+			testFramework.MoveTime(time.Duration(int64(delta) * nanosecondsPerFix64Unit))
+
+			return interpreter.Void
+		},
+		emulatorBackendMoveTimeFunctionType,
+	)
+}
+
+// 'EmulatorBackend.setBlockHeight' function
+
+const emulatorBackendSetBlockHeightFunctionName = "setBlockHeight"
+
+const emulatorBackendSetBlockHeightFunctionDocString = `
+Sets the height of the current block of the blockchain.
+`
+
+var emulatorBackendSetBlockHeightFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendSetBlockHeightFunctionName,
+)
+
+func emulatorBackendSetBlockHeightFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			height, ok := invocation.Arguments[0].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			err := testFramework.SetBlockHeight(uint64(height))
+
+			return newErrorValue(invocation.Interpreter, err)
+		},
+		emulatorBackendSetBlockHeightFunctionType,
+	)
+}
+
+// 'EmulatorBackend.resetBlockHeight' function
+
+const emulatorBackendResetBlockHeightFunctionName = "resetBlockHeight"
+
+const emulatorBackendResetBlockHeightFunctionDocString = `
+Resets the height of the current block of the blockchain,
+i.e. clears the height set using setBlockHeight.
+`
+
+var emulatorBackendResetBlockHeightFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendResetBlockHeightFunctionName,
+)
+
+func emulatorBackendResetBlockHeightFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			testFramework.ResetBlockHeight()
+
+			return interpreter.Void
+		},
+		emulatorBackendResetBlockHeightFunctionType,
+	)
+}
+
+var eventsArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: interpreter.PrimitiveStaticTypeAnyStruct,
+}
+
+// newEventsArrayValue creates an array of type '[AnyStruct]' with the given events
+func newEventsArrayValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	events []interpreter.Value,
+) *interpreter.ArrayValue {
+	return interpreter.NewArrayValue(
+		inter,
+		locationRange,
+		eventsArrayStaticType,
+		common.Address{},
+		events...,
+	)
+}
+
 // TestFailedError
 
 type TestFailedError struct {
@@ -1485,9 +2287,13 @@ func (e TestFailedError) Error() string {
 	return fmt.Sprintf("test failed: %s", e.Err.Error())
 }
 
+// newMatcherWithGenericTestFunction creates a matcher which invokes the given test function.
+// If the tested value does not have the parameter type of the test function,
+// the matcher does not match if skipMismatchingTypes is true, and fails otherwise.
 func newMatcherWithGenericTestFunction(
 	invocation interpreter.Invocation,
 	testFunc interpreter.FunctionValue,
+	skipMismatchingTypes bool,
 ) interpreter.Value {
 
 	inter := invocation.Interpreter
@@ -1520,6 +2326,10 @@ func newMatcherWithGenericTestFunction(
 				argumentStaticType := argument.StaticType(inter)
 
 				if !inter.IsSubTypeOfSemaType(argumentStaticType, paramType) {
+					if skipMismatchingTypes {
+						return interpreter.FalseValue
+					}
+
 					argumentSemaType := inter.MustConvertStaticToSemaType(argumentStaticType)
 
 					panic(interpreter.TypeMismatchError{
@@ -20,13 +20,17 @@ package stdlib
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	cadenceErrors "github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
@@ -35,6 +39,14 @@ import (
 )
 
 func newTestContractInterpreter(t *testing.T, code string) (*interpreter.Interpreter, error) {
+	return newTestContractInterpreterWithTestFramework(t, code, nil)
+}
+
+func newTestContractInterpreterWithTestFramework(
+	t *testing.T,
+	code string,
+	testFramework TestFramework,
+) (*interpreter.Interpreter, error) {
 	program, err := parser.ParseProgram(
 		[]byte(code),
 		nil,
@@ -96,7 +108,7 @@ func newTestContractInterpreter(t *testing.T, code string) (*interpreter.Interpr
 
 				return nil
 			},
-			ContractValueHandler: NewTestInterpreterContractValueHandler(nil),
+			ContractValueHandler: NewTestInterpreterContractValueHandler(testFramework),
 			UUIDHandler: func() (uint64, error) {
 				uuid++
 				return uuid, nil
@@ -757,3 +769,1377 @@ func TestTestExpect(t *testing.T) {
 		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 	})
 }
+
+func TestTestEvents(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub event Transfer(amount: Int)
+        pub event Mint(amount: Int)
+
+        pub fun testAllEvents() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let events = blockchain.events(type: nil)
+            Test.expect(events, Test.haveCount(3))
+            Test.expect(events, Test.contain(Test.beOfType(Type<Mint>())))
+        }
+
+        pub fun testEventsOfType() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let events = blockchain.events(type: Type<Transfer>())
+            Test.expect(events, Test.haveCount(2))
+
+            let transfer = events[0] as! Transfer
+            Test.assert(transfer.amount == 1)
+        }
+
+        pub fun testEventMatcher() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let events = blockchain.events(type: nil)
+
+            Test.expect(
+                events,
+                Test.contain(Test.newEventMatcher(fun (_ event: Transfer): Bool {
+                    return event.amount == 2
+                }))
+            )
+        }
+
+        pub fun testEventMatcherFailure() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let events = blockchain.events(type: nil)
+
+            Test.expect(
+                events,
+                Test.contain(Test.newEventMatcher(fun (_ event: Mint): Bool {
+                    return event.amount == 2
+                }))
+            )
+        }
+
+        pub fun testTransactionEvents() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let result = blockchain.executeNextTransaction()!
+
+            Test.assert(result.status == Test.ResultStatus.succeeded)
+            Test.expect(result.events, Test.haveCount(1))
+            Test.expect(result.events, Test.contain(Test.beOfType(Type<Mint>())))
+        }
+
+        pub fun testTransactionResultInitializer() {
+            let result = Test.TransactionResult(status: Test.ResultStatus.succeeded, error: nil)
+            Test.expect(result.events, Test.haveCount(0))
+        }
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		var events []interpreter.Value
+
+		newEvent := func(inter *interpreter.Interpreter, name string, amount int64) interpreter.Value {
+			return interpreter.NewCompositeValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				utils.TestLocation,
+				name,
+				common.CompositeKindEvent,
+				[]interpreter.CompositeField{
+					{
+						Name:  "amount",
+						Value: interpreter.NewUnmeteredIntValueFromInt64(amount),
+					},
+				},
+				common.Address{},
+			)
+		}
+
+		return &mockedTestFramework{
+			events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) []interpreter.Value {
+				if events == nil {
+					events = []interpreter.Value{
+						newEvent(inter, "Transfer", 1),
+						newEvent(inter, "Mint", 3),
+						newEvent(inter, "Transfer", 2),
+					}
+				}
+
+				var result []interpreter.Value
+				for _, event := range events {
+					if eventType == nil || event.StaticType(inter).Equal(eventType) {
+						result = append(result, event)
+					}
+				}
+				return result
+			},
+			executeNextTransaction: func() *TransactionResult {
+				return &TransactionResult{}
+			},
+			transactionEvents: func(inter *interpreter.Interpreter) []interpreter.Value {
+				event := newEvent(inter, "Mint", 4)
+				events = append(events, event)
+				return []interpreter.Value{event}
+			},
+		}
+	}
+
+	for _, name := range []string{
+		"testAllEvents",
+		"testEventsOfType",
+		"testEventMatcher",
+		"testTransactionEvents",
+		"testTransactionResultInitializer",
+	} {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+			require.NoError(t, err)
+
+			_, err = inter.Invoke(name)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("testEventMatcherFailure", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testEventMatcherFailure")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+}
+
+func TestTestArrayMatchers(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("haveCount", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect([1, 2, 3], Test.haveCount(3))
+                Test.expect([] as [String], Test.haveCount(0))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("haveCount fail", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect([1, 2, 3], Test.haveCount(2))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("haveCount non-array", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect("abc", Test.haveCount(3))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("contain", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect([1, 2, 3], Test.contain(Test.equal(2)))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("contain fail", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect([1, 2, 3], Test.contain(Test.equal(4)))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("beOfType", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect(Foo(), Test.beOfType(Type<Foo>()))
+                Test.expect(1, Test.beOfType(Type<Integer>()))
+            }
+
+            pub struct Foo {}
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("beOfType fail", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expect("1", Test.beOfType(Type<Int>()))
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+}
+
+func TestTestLogs(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun testLogs() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let logs = blockchain.logs()
+
+            Test.assert(logs.length == 2)
+            Test.assert(logs[0] == "\"hello\"")
+            Test.expect(logs, Test.containLog("world"))
+        }
+
+        pub fun testLogsFailure() {
+            let blockchain = Test.newEmulatorBlockchain()
+            Test.expect(blockchain.logs(), Test.containLog("universe"))
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		logs: func() []string {
+			return []string{`"hello"`, `"hello, world"`}
+		},
+	}
+
+	t.Run("logs", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testLogs")
+		require.NoError(t, err)
+	})
+
+	t.Run("matcher failure", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testLogsFailure")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+}
+
+func TestTestSnapshots(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+
+            Test.assert(blockchain.createSnapshot(name: "fixture") == nil)
+            blockchain.commitBlock()
+            blockchain.commitBlock()
+
+            Test.assert(blockchain.reset(to: 1) == nil)
+            Test.assert(blockchain.reset(to: 5) != nil)
+
+            Test.assert(blockchain.loadSnapshot(name: "fixture") == nil)
+
+            let err = blockchain.loadSnapshot(name: "unknown")
+            Test.assert(err != nil)
+            Test.assert(err!.message == "no snapshot with name 'unknown'")
+        }
+    `
+
+	var height uint64
+	snapshots := map[string]uint64{}
+
+	testFramework := &mockedTestFramework{
+		commitBlock: func() error {
+			height++
+			return nil
+		},
+		createSnapshot: func(name string) error {
+			snapshots[name] = height
+			return nil
+		},
+		loadSnapshot: func(name string) error {
+			snapshotHeight, ok := snapshots[name]
+			if !ok {
+				return fmt.Errorf("no snapshot with name '%s'", name)
+			}
+			height = snapshotHeight
+			return nil
+		},
+		reset: func(resetHeight uint64) error {
+			if resetHeight > height {
+				return fmt.Errorf("cannot reset to future height %d", resetHeight)
+			}
+			height = resetHeight
+			return nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]uint64{"fixture": 0}, snapshots)
+	assert.Equal(t, uint64(0), height)
+}
+
+func TestTestRunner(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("discovery and lifecycle hooks", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub var calls: [String] = []
+
+            pub fun setup() {
+                calls.append("setup")
+            }
+
+            pub fun beforeEach() {
+                calls.append("beforeEach")
+            }
+
+            pub fun afterEach() {
+                calls.append("afterEach")
+            }
+
+            pub fun tearDown() {
+                calls.append("tearDown")
+            }
+
+            pub fun testSuccess() {
+                calls.append("testSuccess")
+                Test.assert(true)
+            }
+
+            pub fun testFailure() {
+                calls.append("testFailure")
+                Test.assert(false, message: "expected failure")
+            }
+
+            pub fun testHelper(_ value: Int) {}
+
+            pub fun testValue(): Int {
+                return 1
+            }
+
+            pub fun other() {}
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{"testSuccess", "testFailure"},
+			TestFunctionNames(inter.Program),
+		)
+
+		results, err := NewTestRunner(inter).RunTests()
+		require.NoError(t, err)
+
+		require.Len(t, results, 2)
+
+		assert.Equal(t, "testSuccess", results[0].TestName)
+		assert.True(t, results[0].Passed())
+
+		assert.Equal(t, "testFailure", results[1].TestName)
+		assert.False(t, results[1].Passed())
+		require.IsType(t, TestFailedError{}, results[1].Error)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, results[1].Error, &assertionErr)
+		assert.Equal(t, "expected failure", assertionErr.Message)
+
+		assert.False(t, results.Passed())
+		assert.Equal(t, TestResults{results[1]}, results.Failed())
+
+		assert.Equal(t,
+			`["setup", "beforeEach", "testSuccess", "afterEach", `+
+				`"beforeEach", "testFailure", "afterEach", "tearDown"]`,
+			inter.Globals.Get("calls").GetValue().String(),
+		)
+
+		assert.True(t,
+			strings.HasPrefix(
+				results.String(),
+				"Test results:\n- PASS: testSuccess\n- FAIL: testFailure\n",
+			),
+		)
+	})
+
+	t.Run("imported functions", func(t *testing.T) {
+		t.Parallel()
+
+		importedLocation := common.StringLocation("helpers")
+
+		importedChecker, err := checker.ParseAndCheckWithOptions(t,
+			`
+              pub fun testImported() {}
+
+              pub fun setup() {}
+            `,
+			checker.ParseAndCheckOptions{
+				Location: importedLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		testChecker, err := checker.ParseAndCheckWithOptions(t,
+			`
+              import testImported, setup from "helpers"
+
+              pub fun testLocal() {}
+            `,
+			checker.ParseAndCheckOptions{
+				Config: &sema.Config{
+					ImportHandler: func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		program := interpreter.ProgramFromChecker(testChecker)
+
+		assert.Equal(t,
+			[]string{"testLocal"},
+			TestFunctionNames(program),
+		)
+		assert.False(t, isDeclaredGlobal(program, setupFunctionName))
+	})
+
+	t.Run("failing hooks", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun beforeEach() {
+                Test.fail(message: "before")
+            }
+
+            pub fun testSuccess() {}
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		results, err := NewTestRunner(inter).RunTests()
+		require.NoError(t, err)
+
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Passed())
+		assert.ErrorAs(t, results[0].Error, &AssertionError{})
+	})
+
+	t.Run("failing setup", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun setup() {
+                Test.fail(message: "setup")
+            }
+
+            pub fun testSuccess() {}
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		results, err := NewTestRunner(inter).RunTests()
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.Nil(t, results)
+	})
+
+	t.Run("invalid hook", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            pub fun tearDown(_ value: Int) {}
+
+            pub fun testSuccess() {}
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		results, err := NewTestRunner(inter).RunTests()
+		require.ErrorAs(t, err, &InvalidTestLifecycleHookError{})
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Passed())
+	})
+}
+
+func TestTestExpectFailure(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expectFailure(fun () {
+                    let value: Int? = nil
+                    value!
+                }, errorMessageSubstring: "unexpectedly found nil")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("different failure", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expectFailure(fun () {
+                    let value: UInt8 = 255
+                    value + 1
+                }, errorMessageSubstring: "unexpectedly found nil")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, err, &assertionErr)
+		assert.Contains(t, assertionErr.Message, "overflow")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            pub fun test() {
+                Test.expectFailure(fun () {}, errorMessageSubstring: "")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	const failingBackendScript = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+
+            Test.expectFailure(fun () {
+                blockchain.executeNextTransaction()
+            }, errorMessageSubstring: "")
+        }
+    `
+
+	newFailingTestFramework := func(err error) *mockedTestFramework {
+		return &mockedTestFramework{
+			events: func(_ *interpreter.Interpreter, _ interpreter.StaticType) []interpreter.Value {
+				return nil
+			},
+			executeNextTransaction: func() *TransactionResult {
+				panic(err)
+			},
+		}
+	}
+
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(
+			t,
+			failingBackendScript,
+			newFailingTestFramework(cadenceErrors.NewUnreachableError()),
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.True(t, cadenceErrors.IsInternalError(err))
+	})
+
+	t.Run("external error", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(
+			t,
+			failingBackendScript,
+			newFailingTestFramework(cadenceErrors.NewExternalError(errors.New("backend failed"))),
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		_, ok := cadenceErrors.GetExternalError(err)
+		assert.True(t, ok)
+	})
+
+	t.Run("memory error", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(
+			t,
+			failingBackendScript,
+			newFailingTestFramework(cadenceErrors.MemoryError{Err: errors.New("limit reached")}),
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &cadenceErrors.MemoryError{})
+	})
+}
+
+func TestErrorKind(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		kind string
+	}{
+		{
+			name: "condition",
+			err:  interpreter.ConditionError{},
+			kind: ErrorKindCondition,
+		},
+		{
+			name: "assertion",
+			err:  AssertionError{},
+			kind: ErrorKindAssertion,
+		},
+		{
+			name: "panic",
+			err:  PanicError{},
+			kind: ErrorKindPanic,
+		},
+		{
+			name: "force nil",
+			err:  interpreter.ForceNilError{},
+			kind: ErrorKindForceNil,
+		},
+		{
+			name: "force cast type mismatch",
+			err:  interpreter.ForceCastTypeMismatchError{},
+			kind: ErrorKindForceCastTypeMismatch,
+		},
+		{
+			name: "overflow",
+			err:  interpreter.OverflowError{},
+			kind: ErrorKindOverflow,
+		},
+		{
+			name: "underflow",
+			err:  interpreter.UnderflowError{},
+			kind: ErrorKindUnderflow,
+		},
+		{
+			name: "division by zero",
+			err:  interpreter.DivisionByZeroError{},
+			kind: ErrorKindDivisionByZero,
+		},
+		{
+			name: "array index out of bounds",
+			err:  interpreter.ArrayIndexOutOfBoundsError{},
+			kind: ErrorKindArrayIndexOutOfBounds,
+		},
+		{
+			name: "string index out of bounds",
+			err:  interpreter.StringIndexOutOfBoundsError{},
+			kind: ErrorKindStringIndexOutOfBounds,
+		},
+		{
+			name: "dereference",
+			err:  interpreter.DereferenceError{},
+			kind: ErrorKindDereference,
+		},
+		{
+			name: "invalidated resource",
+			err:  interpreter.InvalidatedResourceError{},
+			kind: ErrorKindInvalidatedResource,
+		},
+		{
+			name: "destroyed resource",
+			err:  interpreter.DestroyedResourceError{},
+			kind: ErrorKindDestroyedResource,
+		},
+		{
+			name: "overwrite",
+			err:  interpreter.OverwriteError{},
+			kind: ErrorKindOverwrite,
+		},
+		{
+			name: "contract update",
+			err:  &ContractUpdateError{},
+			kind: ErrorKindContractUpdate,
+		},
+		{
+			name: "parser",
+			err:  parser.Error{},
+			kind: ErrorKindParser,
+		},
+		{
+			name: "checker",
+			err: &sema.CheckerError{
+				Errors: []error{
+					&sema.TypeMismatchError{},
+				},
+			},
+			kind: ErrorKindTypeMismatch,
+		},
+		{
+			name: "checker, first known kind",
+			err: sema.CheckerError{
+				Errors: []error{
+					&sema.UnreachableStatementError{},
+					&sema.NotDeclaredError{},
+					&sema.TypeMismatchError{},
+				},
+			},
+			kind: ErrorKindNotDeclared,
+		},
+		{
+			name: "checker, unknown kind",
+			err: &sema.CheckerError{
+				Errors: []error{
+					&sema.UnreachableStatementError{},
+				},
+			},
+			kind: ErrorKindChecker,
+		},
+		{
+			name: "wrapped",
+			err: TestFailedError{
+				Err: interpreter.Error{
+					Err: interpreter.PositionedError{
+						Err: interpreter.OverflowError{},
+					},
+				},
+			},
+			kind: ErrorKindOverflow,
+		},
+		{
+			name: "unknown",
+			err:  fmt.Errorf("unknown"),
+			kind: "",
+		},
+		{
+			name: "unknown interpreter error",
+			err: interpreter.Error{
+				Err: interpreter.TypeMismatchError{},
+			},
+			kind: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			assert.Equal(t, test.kind, ErrorKind(test.err))
+		})
+	}
+}
+
+func TestTestErrorKindMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let result = blockchain.executeNextTransaction()!
+
+            Test.assert(result.status == Test.ResultStatus.failed)
+            Test.assert(result.error!.kind == "ConditionError")
+
+            Test.expect(result, Test.haveErrorKind("ConditionError"))
+            Test.expect(result.error!, Test.haveErrorKind("ConditionError"))
+        }
+
+        pub fun testMismatch() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let result = blockchain.executeNextTransaction()!
+
+            Test.expect(result, Test.haveErrorKind("ForceNilError"))
+        }
+
+        pub fun testConstructor() {
+            let error = Test.Error("failed")
+
+            Test.assert(error.message == "failed")
+            Test.assert(error.kind == "")
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		executeNextTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				Error: interpreter.Error{
+					Err: interpreter.ConditionError{
+						ConditionKind: ast.ConditionKindPre,
+						Message:       "amount must be positive",
+					},
+				},
+			}
+		},
+		transactionEvents: func(_ *interpreter.Interpreter) []interpreter.Value {
+			return nil
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMismatch")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("constructor", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testConstructor")
+		require.NoError(t, err)
+	})
+
+	t.Run("immutable kind", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test() {
+                let error = Test.Error("failed")
+                error.kind = "ConditionError"
+            }
+        `
+
+		_, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		errs := checker.RequireCheckerErrors(t, err, 2)
+		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
+		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[1])
+	})
+}
+
+func TestTestBlockControl(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+
+            blockchain.moveTime(by: 1.5)
+            blockchain.moveTime(by: -10.0)
+
+            Test.assert(blockchain.setBlockHeight(42) == nil)
+            Test.assert(blockchain.setBlockHeight(0) != nil)
+
+            blockchain.resetBlockHeight()
+        }
+    `
+
+	var deltas []time.Duration
+	var heights []uint64
+	var resets int
+
+	testFramework := &mockedTestFramework{
+		moveTime: func(delta time.Duration) {
+			deltas = append(deltas, delta)
+		},
+		setBlockHeight: func(height uint64) error {
+			if height == 0 {
+				return fmt.Errorf("invalid height")
+			}
+			heights = append(heights, height)
+			return nil
+		},
+		resetBlockHeight: func() {
+			resets++
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]time.Duration{
+			1500 * time.Millisecond,
+			-10 * time.Second,
+		},
+		deltas,
+	)
+	assert.Equal(t, []uint64{42}, heights)
+	assert.Equal(t, 1, resets)
+}
+
+func TestTestMoveTimeOverflow(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+            blockchain.moveTime(by: 92233720368.0)
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		moveTime: func(delta time.Duration) {
+			require.FailNow(t, "unexpected move of time")
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.Error(t, err)
+
+	var overflowErr interpreter.OverflowError
+	require.ErrorAs(t, err, &overflowErr)
+	assert.NotNil(t, overflowErr.Location)
+}
+
+func TestTestBlockProvider(t *testing.T) {
+
+	t.Parallel()
+
+	provider := NewTestBlockProvider(testCurrentBlockProvider{height: 3})
+
+	height, err := provider.GetCurrentBlockHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), height)
+
+	// Move time
+
+	provider.MoveTime(time.Minute)
+	provider.MoveTime(time.Second)
+
+	block, exists, err := provider.GetBlockAtHeight(2)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t,
+		Block{
+			Height:    2,
+			View:      2,
+			Timestamp: int64(63 * time.Second),
+		},
+		block,
+	)
+
+	// Override height
+
+	err = provider.SetBlockHeight(10)
+	require.NoError(t, err)
+
+	height, err = provider.GetCurrentBlockHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+
+	// The current block is the current block of the wrapped provider
+
+	block, exists, err = provider.GetBlockAtHeight(10)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t,
+		Block{
+			Height:    10,
+			View:      3,
+			Timestamp: int64(64 * time.Second),
+		},
+		block,
+	)
+
+	_, exists, err = provider.GetBlockAtHeight(11)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Blocks below the current height are shifted consistently
+
+	block, exists, err = provider.GetBlockAtHeight(9)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t,
+		Block{
+			Height:    9,
+			View:      2,
+			Timestamp: int64(63 * time.Second),
+		},
+		block,
+	)
+
+	block, exists, err = provider.GetBlockAtHeight(7)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, uint64(7), block.Height)
+	assert.Equal(t, uint64(0), block.View)
+
+	_, exists, err = provider.GetBlockAtHeight(6)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Override height below the height of the wrapped provider
+
+	err = provider.SetBlockHeight(1)
+	require.NoError(t, err)
+
+	height, err = provider.GetCurrentBlockHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+
+	block, exists, err = provider.GetBlockAtHeight(0)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, uint64(0), block.Height)
+	assert.Equal(t, uint64(2), block.View)
+
+	_, exists, err = provider.GetBlockAtHeight(2)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Reset height
+
+	provider.ResetBlockHeight()
+
+	height, err = provider.GetCurrentBlockHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), height)
+
+	block, exists, err = provider.GetBlockAtHeight(1)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, uint64(1), block.Height)
+	assert.Equal(t, uint64(1), block.View)
+}
+
+type mockedTestFramework struct {
+	runScript              func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value) *ScriptResult
+	createAccount          func() (*Account, error)
+	addTransaction         func(inter *interpreter.Interpreter, code string, authorizers []common.Address, signers []*Account, arguments []interpreter.Value) error
+	executeNextTransaction func() *TransactionResult
+	commitBlock            func() error
+	deployContract         func(inter *interpreter.Interpreter, name string, code string, account *Account, arguments []interpreter.Value) error
+	readFile               func(path string) (string, error)
+	useConfiguration       func(configuration *Configuration)
+	events                 func(inter *interpreter.Interpreter, eventType interpreter.StaticType) []interpreter.Value
+	transactionEvents      func(inter *interpreter.Interpreter) []interpreter.Value
+	logs                   func() []string
+	createSnapshot         func(name string) error
+	loadSnapshot           func(name string) error
+	reset                  func(height uint64) error
+	moveTime               func(delta time.Duration)
+	setBlockHeight         func(height uint64) error
+	resetBlockHeight       func()
+}
+
+var _ TestFramework = &mockedTestFramework{}
+
+func (m *mockedTestFramework) RunScript(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) *ScriptResult {
+	if m.runScript == nil {
+		panic("'RunScript' is not implemented")
+	}
+	return m.runScript(inter, code, arguments)
+}
+
+func (m *mockedTestFramework) CreateAccount() (*Account, error) {
+	if m.createAccount == nil {
+		panic("'CreateAccount' is not implemented")
+	}
+	return m.createAccount()
+}
+
+func (m *mockedTestFramework) AddTransaction(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	signers []*Account,
+	arguments []interpreter.Value,
+) error {
+	if m.addTransaction == nil {
+		panic("'AddTransaction' is not implemented")
+	}
+	return m.addTransaction(inter, code, authorizers, signers, arguments)
+}
+
+func (m *mockedTestFramework) ExecuteNextTransaction() *TransactionResult {
+	if m.executeNextTransaction == nil {
+		panic("'ExecuteNextTransaction' is not implemented")
+	}
+	return m.executeNextTransaction()
+}
+
+func (m *mockedTestFramework) CommitBlock() error {
+	if m.commitBlock == nil {
+		panic("'CommitBlock' is not implemented")
+	}
+	return m.commitBlock()
+}
+
+func (m *mockedTestFramework) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *Account,
+	arguments []interpreter.Value,
+) error {
+	if m.deployContract == nil {
+		panic("'DeployContract' is not implemented")
+	}
+	return m.deployContract(inter, name, code, account, arguments)
+}
+
+func (m *mockedTestFramework) ReadFile(path string) (string, error) {
+	if m.readFile == nil {
+		panic("'ReadFile' is not implemented")
+	}
+	return m.readFile(path)
+}
+
+func (m *mockedTestFramework) UseConfiguration(configuration *Configuration) {
+	if m.useConfiguration == nil {
+		panic("'UseConfiguration' is not implemented")
+	}
+	m.useConfiguration(configuration)
+}
+
+func (m *mockedTestFramework) StandardLibraryHandler() StandardLibraryHandler {
+	return nil
+}
+
+func (m *mockedTestFramework) Events(
+	inter *interpreter.Interpreter,
+	eventType interpreter.StaticType,
+) []interpreter.Value {
+	if m.events == nil {
+		panic("'Events' is not implemented")
+	}
+	return m.events(inter, eventType)
+}
+
+func (m *mockedTestFramework) TransactionEvents(inter *interpreter.Interpreter) []interpreter.Value {
+	if m.transactionEvents == nil {
+		panic("'TransactionEvents' is not implemented")
+	}
+	return m.transactionEvents(inter)
+}
+
+func (m *mockedTestFramework) Logs() []string {
+	if m.logs == nil {
+		panic("'Logs' is not implemented")
+	}
+	return m.logs()
+}
+
+func (m *mockedTestFramework) CreateSnapshot(name string) error {
+	if m.createSnapshot == nil {
+		panic("'CreateSnapshot' is not implemented")
+	}
+	return m.createSnapshot(name)
+}
+
+func (m *mockedTestFramework) LoadSnapshot(name string) error {
+	if m.loadSnapshot == nil {
+		panic("'LoadSnapshot' is not implemented")
+	}
+	return m.loadSnapshot(name)
+}
+
+func (m *mockedTestFramework) Reset(height uint64) error {
+	if m.reset == nil {
+		panic("'Reset' is not implemented")
+	}
+	return m.reset(height)
+}
+
+func (m *mockedTestFramework) MoveTime(delta time.Duration) {
+	if m.moveTime == nil {
+		panic("'MoveTime' is not implemented")
+	}
+	m.moveTime(delta)
+}
+
+func (m *mockedTestFramework) SetBlockHeight(height uint64) error {
+	if m.setBlockHeight == nil {
+		panic("'SetBlockHeight' is not implemented")
+	}
+	return m.setBlockHeight(height)
+}
+
+func (m *mockedTestFramework) ResetBlockHeight() {
+	if m.resetBlockHeight == nil {
+		panic("'ResetBlockHeight' is not implemented")
+	}
+	m.resetBlockHeight()
+}
+
+type testCurrentBlockProvider struct {
+	height uint64
+}
+
+var _ CurrentBlockProvider = testCurrentBlockProvider{}
+
+func (p testCurrentBlockProvider) GetBlockAtHeight(height uint64) (Block, bool, error) {
+	if height > p.height {
+		return Block{}, false, nil
+	}
+	return Block{
+		Height:    height,
+		View:      height,
+		Timestamp: int64(height) * int64(time.Second),
+	}, true, nil
+}
+
+func (p testCurrentBlockProvider) GetCurrentBlockHeight() (uint64, error) {
+	return p.height, nil
+}
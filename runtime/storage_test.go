@@ -3381,3 +3381,194 @@ func TestRuntimeStorageInternalAccess(t *testing.T) {
 	_, err = ExportValue(rValue, inter, interpreter.EmptyLocationRange)
 	require.NoError(t, err)
 }
+
+func TestRuntimeStorageGenericComposite(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signingAddress := common.MustBytesToAddress([]byte{0x1})
+
+	const contract = `
+      pub contract Test {
+
+          pub resource NFT {
+              pub let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          pub resource Token {}
+
+          pub resource Collection<T: @AnyResource> {
+              pub var items: @[T]
+
+              init() {
+                  self.items <- []
+              }
+
+              pub fun deposit(_ item: @T) {
+                  self.items.append(<-item)
+              }
+
+              pub fun withdraw(): @T {
+                  return <-self.items.removeFirst()
+              }
+
+              pub fun itemType(): Type {
+                  return Type<@T>()
+              }
+
+              destroy() {
+                  destroy self.items
+              }
+          }
+
+          pub fun createCollection<T: @AnyResource>(): @Collection<@T> {
+              return <-create Collection<@T>()
+          }
+
+          pub fun mint(id: Int): @NFT {
+              return <-create NFT(id: id)
+          }
+      }
+    `
+
+	deployTx := DeploymentTransaction("Test", []byte(contract))
+
+	accountCodes := map[Location][]byte{}
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signingAddress}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			code = accountCodes[location]
+			return code, nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: deployTx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	const saveTx = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              let collection <- Test.createCollection<@Test.NFT>()
+              collection.deposit(<-Test.mint(id: 1))
+              collection.deposit(<-Test.mint(id: 2))
+              signer.save(<-collection, to: /storage/collection)
+          }
+      }
+    `
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(saveTx),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	// The type arguments are stored with the value,
+	// so the loaded value can be borrowed with the type arguments,
+	// and its functions can use the generic type
+
+	const borrowTx = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              let collection = signer.borrow<&Test.Collection<@Test.NFT>>(from: /storage/collection)!
+              log(collection.itemType())
+
+              let nft <- collection.withdraw()
+              log(nft.id)
+              collection.deposit(<-nft)
+          }
+      }
+    `
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(borrowTx),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]string{
+			"Type<A.0000000000000001.Test.NFT>()",
+			"1",
+		},
+		loggedMessages,
+	)
+
+	// Borrowing with different type arguments fails
+
+	const invalidBorrowTx = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.borrow<&Test.Collection<@Test.Token>>(from: /storage/collection)
+          }
+      }
+    `
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(invalidBorrowTx),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	RequireError(t, err)
+
+	require.ErrorAs(t, err, &interpreter.ForceCastTypeMismatchError{})
+}
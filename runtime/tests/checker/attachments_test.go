@@ -0,0 +1,726 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct base", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              fun foo(): Int {
+                  return 1
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		aType := RequireGlobalType(t, checker.Elaboration, "A")
+		require.IsType(t, &sema.CompositeType{}, aType)
+		attachmentType := aType.(*sema.CompositeType)
+
+		assert.Equal(t, common.CompositeKindAttachment, attachmentType.Kind)
+		assert.False(t, attachmentType.IsResourceType())
+
+		sType := RequireGlobalType(t, checker.Elaboration, "S")
+		assert.Equal(t, sType, attachmentType.AttachmentBaseType)
+	})
+
+	t.Run("resource base", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+        `)
+		require.NoError(t, err)
+
+		aType := RequireGlobalType(t, checker.Elaboration, "A")
+		assert.True(t, aType.IsResourceType())
+	})
+
+	t.Run("declared before base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for S {}
+
+          struct S {}
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("interface base", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource interface I {}
+
+          attachment A for I {}
+        `)
+		require.NoError(t, err)
+
+		aType := RequireGlobalType(t, checker.Elaboration, "A")
+		assert.True(t, aType.IsResourceType())
+		assert.Equal(t,
+			"AnyResource{I}",
+			aType.(*sema.CompositeType).AttachmentBaseType.QualifiedString(),
+		)
+	})
+
+	t.Run("AnyStruct base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for AnyStruct {}
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for Int {}
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseTypeError{}, errs[0])
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              resource R {}
+
+              attachment A for R {}
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              attachment A for S {}
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("resource field in resource attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {
+              let r: @R
+
+              init(r: @R) {
+                  self.r <- r
+              }
+
+              destroy() {
+                  destroy self.r
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource field in struct attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          struct S {}
+
+          attachment A for S {
+              let r: @R
+
+              init(r: @R) {
+                  self.r <- r
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceFieldError{}, errs[0])
+	})
+}
+
+func TestCheckAttachmentBase(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {
+              fun foo(): Int {
+                  return base.x
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.NotNil(t, RequireGlobalType(t, checker.Elaboration, "A"))
+	})
+
+	t.Run("interface base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              let x: Int
+          }
+
+          attachment A for I {
+              fun foo(): Int {
+                  return base.x
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("not available in initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              init() {
+                  base
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("not available outside attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              fun foo() {
+                  base
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+}
+
+func TestCheckAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+        `)
+		require.NoError(t, err)
+
+		sType := RequireGlobalType(t, checker.Elaboration, "S")
+		assert.Equal(t, sType, RequireGlobalValue(t, checker.Elaboration, "s"))
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): @R {
+              let r <- create R()
+              return <-attach A() to <-r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource, missing move", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): @R {
+              return <-attach A() to create R()
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingMoveOperationError{}, errs[0])
+	})
+
+	t.Run("resource, use after attach", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): @R {
+              let r <- create R()
+              let r2 <- attach A() to <-r
+              destroy r
+              return <-r2
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	})
+
+	t.Run("interface base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {}
+
+          struct S: I {}
+
+          attachment A for I {}
+
+          let s = attach A() to S()
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let t = attach A() to T()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseError{}, errs[0])
+	})
+
+	t.Run("non-composite base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for AnyStruct {}
+
+          let x = attach A() to 1
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseError{}, errs[0])
+	})
+
+	t.Run("non-attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let s = attach S() to S()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotAnAttachmentError{}, errs[0])
+	})
+
+	t.Run("construction outside attach", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let a = A()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentUsageError{}, errs[0])
+	})
+
+	t.Run("in view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          view fun test(): S {
+              return attach A() to S()
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
+
+func TestCheckRemoveStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = attach A() to S()
+              remove A from s
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              let r <- attach A() to <-create R()
+              remove A from r
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("struct reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = attach A() to S()
+              let ref = &s as &S
+              remove A from ref
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              let r <- attach A() to <-create R()
+              let ref = &r as &R
+              remove A from ref
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("reference, wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          fun test() {
+              let t = T()
+              let ref = &t as &T
+              remove A from ref
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseError{}, errs[0])
+	})
+
+	t.Run("non-attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          fun test() {
+              let s = S()
+              remove S from s
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotAnAttachmentError{}, errs[0])
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          fun test() {
+              let t = T()
+              remove A from t
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseError{}, errs[0])
+	})
+}
+
+func TestCheckAttachmentAccess(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("value", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+          let a = s[A]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"&A?",
+			RequireGlobalValue(t, checker.Elaboration, "a").QualifiedString(),
+		)
+	})
+
+	t.Run("reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {
+              fun foo(): Int {
+                  return 1
+              }
+          }
+
+          fun test(r: &R): Int? {
+              return r[A]?.foo()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              struct S {}
+
+              attachment A for S {}
+          }
+
+          fun test(s: C.S): &C.A? {
+              return s[C.A]
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let t = T()
+          let a = t[A]
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseError{}, errs[0])
+	})
+
+	t.Run("non-attachment type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let s = S()
+          let x = s[S]
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotIndexableTypeError{}, errs[0])
+	})
+
+	t.Run("assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = S()
+              s[A] = nil
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentAssignmentError{}, errs[0])
+	})
+}
@@ -374,9 +374,10 @@ func TestCheckInvalidFunctionPostConditionWithFunction(t *testing.T) {
       }
     `)
 
-	errs := RequireCheckerErrors(t, err, 1)
+	errs := RequireCheckerErrors(t, err, 2)
 
 	assert.IsType(t, &sema.FunctionExpressionInConditionError{}, errs[0])
+	assert.IsType(t, &sema.PurityError{}, errs[1])
 }
 
 func TestCheckFunctionPostConditionWithMessageUsingStringLiteral(t *testing.T) {
@@ -491,10 +492,12 @@ func TestCheckFunctionWithPostConditionAndResourceResult(t *testing.T) {
         }
     `)
 
-	errs := RequireCheckerErrors(t, err, 2)
+	errs := RequireCheckerErrors(t, err, 4)
 
-	require.IsType(t, &sema.InvalidMoveOperationError{}, errs[0])
-	require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	require.IsType(t, &sema.PurityError{}, errs[0])
+	require.IsType(t, &sema.PurityError{}, errs[1])
+	require.IsType(t, &sema.InvalidMoveOperationError{}, errs[2])
+	require.IsType(t, &sema.TypeMismatchError{}, errs[3])
 }
 
 // TestCheckConditionCreateBefore tests if the AST expression extractor properly handles
@@ -22,9 +22,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -107,3 +109,139 @@ func TestCheckErrorShortCircuiting(t *testing.T) {
 		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
 	})
 }
+
+func TestCheckErrorSuggestedFixes(t *testing.T) {
+
+	t.Parallel()
+
+	applyFix := func(
+		t *testing.T,
+		code string,
+		err error,
+	) string {
+		hasSuggestedFixes, ok := err.(errors.HasSuggestedFixes[ast.TextEdit])
+		require.True(t, ok)
+
+		fixes := hasSuggestedFixes.SuggestedFixes(code)
+		require.Len(t, fixes, 1)
+
+		result, applyErr := ast.ApplyTextEdits(code, fixes[0].TextEdits)
+		require.NoError(t, applyErr)
+
+		return result
+	}
+
+	t.Run("missing force-unwrap", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(x: Int?): Int {
+              return x
+          }
+        `
+
+		_, err := ParseAndCheck(t, code)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		fixedCode := applyFix(t, code, errs[0])
+		assert.Contains(t, fixedCode, "return x!\n")
+
+		_, err = ParseAndCheck(t, fixedCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing force-unwrap, parenthesized", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(x: Int??): Int {
+              return x ?? nil
+          }
+        `
+
+		_, err := ParseAndCheck(t, code)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		fixedCode := applyFix(t, code, errs[0])
+		assert.Contains(t, fixedCode, "return (x ?? nil)!\n")
+
+		_, err = ParseAndCheck(t, fixedCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("no force-unwrap for unrelated types", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(x: String?): Int {
+              return x
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		fixes := errs[0].(*sema.TypeMismatchError).SuggestedFixes("")
+		assert.Empty(t, fixes)
+	})
+
+	t.Run("missing access modifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test() {}
+        `
+
+		_, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					AccessCheckMode: sema.AccessCheckModeStrict,
+				},
+			},
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.MissingAccessModifierError{}, errs[0])
+
+		fixedCode := applyFix(t, code, errs[0])
+		assert.Equal(t, `
+          access(all) fun test() {}
+        `,
+			fixedCode,
+		)
+	})
+
+	t.Run("incorrect transfer operation", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          resource R {}
+
+          fun test() {
+              let r = create R()
+              destroy r
+          }
+        `
+
+		_, err := ParseAndCheck(t, code)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.IncorrectTransferOperationError{}, errs[0])
+
+		fixedCode := applyFix(t, code, errs[0])
+		assert.Contains(t, fixedCode, "let r <- create R()")
+
+		_, err = ParseAndCheck(t, fixedCode)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,407 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity("x")
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun wrap<T>(_ value: T): [T] {
+              return [value]
+          }
+
+          let x = wrap<UInt8>(1 as UInt8)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.UInt8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("type bound members", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface HasID {
+              let id: Int
+          }
+
+          fun getID<T: AnyStruct{HasID}>(_ value: T): Int {
+              return value.id
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type bound violation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun double<T: Integer>(_ value: T): [T] {
+              return [value, value]
+          }
+
+          let x = double<String>("x")
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("undeclared type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T: X>(_ value: T) {}
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("resource type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun move<T: @AnyResource>(_ value: @T): @T {
+              return <-value
+          }
+
+          fun test() {
+              let r <- move(<-create R())
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource type bound, loss", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun drop<T: @AnyResource>(_ value: @T) {}
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceLossError{}, errs[0])
+	})
+
+	t.Run("redeclared type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T, T>() {}
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("type argument not inferable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun make<T>(): [T] {
+              return []
+          }
+
+          let x = make()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
+	})
+
+	t.Run("interface conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface Duplicator {
+              fun duplicate<T>(_ value: T): [T]
+          }
+
+          struct D: Duplicator {
+              fun duplicate<U>(_ value: U): [U] {
+                  return [value, value]
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ConformanceError{}, errs[0])
+	})
+}
+
+func TestCheckGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              fun get(): T {
+                  return self.value
+              }
+          }
+
+          let inferred = Box(value: 1)
+          let explicit = Box<String>(value: "x")
+          let value = inferred.get()
+        `)
+		require.NoError(t, err)
+
+		inferredType := RequireGlobalValue(t, checker.Elaboration, "inferred")
+		assert.Equal(t, "Box<Int>", inferredType.String())
+
+		explicitType := RequireGlobalValue(t, checker.Elaboration, "explicit")
+		assert.Equal(t, "Box<String>", explicitType.String())
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "value"),
+		)
+	})
+
+	t.Run("instantiations are invariant", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<AnyStruct> = Box<Int>(value: 1)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("type bound violation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T: Integer> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<String>? = nil
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("incorrect number of type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Pair<A, B> {}
+
+          let pair: Pair<Int>? = nil
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentCountError{}, errs[0])
+	})
+
+	t.Run("non-generic instantiation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let s: S<Int>? = nil
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnparameterizedTypeInstantiationError{}, errs[0])
+	})
+
+	t.Run("resource collection", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource NFT {}
+
+          resource Collection<T: @AnyResource> {
+              var items: @[T]
+
+              init() {
+                  self.items <- []
+              }
+
+              fun deposit(_ item: @T) {
+                  self.items.append(<-item)
+              }
+
+              fun withdraw(): @T {
+                  return <-self.items.removeFirst()
+              }
+
+              destroy() {
+                  destroy self.items
+              }
+          }
+
+          fun test() {
+              let collection <- create Collection<@NFT>()
+              collection.deposit(<-create NFT())
+              let nft: @NFT <- collection.withdraw()
+              destroy nft
+              destroy collection
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("generic function with generic composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          fun unwrap<T>(_ box: Box<T>): T {
+              return box.value
+          }
+
+          let x = unwrap(Box(value: true))
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.BoolType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C<T> {}
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeParametersError{}, errs[0])
+	})
+
+	t.Run("type requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract interface CI {
+              struct S<T> {}
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeParametersError{}, errs[0])
+	})
+}
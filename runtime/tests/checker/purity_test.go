@@ -0,0 +1,715 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckViewFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("function type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          view fun foo(): Int {
+              return 1
+          }
+        `)
+		require.NoError(t, err)
+
+		fooType := RequireGlobalValue(t, checker.Elaboration, "foo")
+		require.IsType(t, &sema.FunctionType{}, fooType)
+
+		assert.Equal(t,
+			sema.FunctionPurityView,
+			fooType.(*sema.FunctionType).Purity,
+		)
+		assert.Equal(t,
+			"((): Int)",
+			fooType.QualifiedString(),
+		)
+	})
+
+	t.Run("function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let foo: (view (): Int) = view fun (): Int {
+              return 1
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let view = 1
+
+          fun test(): Int {
+              return view
+          }
+        `)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckViewFunctionInvocation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view calling view", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(): Int {
+              return 1
+          }
+
+          view fun bar(): Int {
+              return foo()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view calling impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo(): Int {
+              return 1
+          }
+
+          view fun bar(): Int {
+              return foo()
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("impure calling impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo(): Int {
+              return 1
+          }
+
+          fun bar(): Int {
+              return foo()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view calling view built-in", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(_ array: [Int]): Bool {
+              return array.contains(1) && array.length > 0
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view calling impure built-in", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let array: [Int] = []
+
+          view fun foo() {
+              array.append(1)
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("view calling mutating built-in on local container", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var ids: [UInt64]
+
+              view init() {
+                  self.ids = []
+              }
+          }
+
+          view fun foo(_ parameter: [Int]): [UInt64] {
+              parameter.append(1)
+
+              let ids: [UInt64] = []
+              for id in [1, 2, 3] as [UInt64] {
+                  ids.append(id)
+              }
+              ids.appendAll([4, 5])
+              ids.insert(at: 0, 0)
+              ids.remove(at: 0)
+              ids.removeFirst()
+              ids.removeLast()
+
+              let names: {String: Int} = {}
+              names.insert(key: "a", 1)
+              names.remove(key: "a")
+
+              let s = S()
+              s.ids.append(1)
+
+              return ids
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view calling mutating built-in through reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(_ ref: &[Int]) {
+              ref.append(1)
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("view calling mutating built-in on field", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var ids: [UInt64]
+
+              init() {
+                  self.ids = []
+              }
+
+              view fun foo() {
+                  self.ids.append(1)
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("view calling impure built-in with function argument on local container", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo() {
+              let names: {String: Int} = {}
+              names.forEachKey(fun (key: String): Bool {
+                  return true
+              })
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("nested impure function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo() {}
+
+          view fun bar() {
+              let f = fun () {
+                  foo()
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckViewFunctionSideEffects(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("emit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          event Foo()
+
+          view fun foo() {
+              emit Foo()
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          view fun foo(_ r: @R) {
+              destroy r
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("move", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          view fun foo(_ r: @R): @R {
+              return <-r
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("global variable write", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var x = 1
+
+          view fun foo() {
+              x = 2
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("field write", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var x: Int
+
+              init() {
+                  self.x = 1
+              }
+
+              view fun foo() {
+                  self.x = 2
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("local variable writes", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var x: Int
+
+              view init() {
+                  self.x = 1
+              }
+          }
+
+          view fun foo(): Int {
+              var x = 1
+              x = 2
+
+              let xs = [1, 2]
+              xs[0] = 3
+
+              var s = S()
+              s.x = 4
+
+              var y = 5
+              x <-> y
+
+              return x
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("parameter write", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(_ xs: [Int]) {
+              xs[0] = 1
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("write through reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(_ ref: &[Int]) {
+              ref[0] = 1
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("swap with global", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var x = 1
+
+          view fun foo() {
+              var y = 2
+              x <-> y
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("impure function allows side effects", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          event Foo()
+
+          var x = 1
+
+          fun foo() {
+              x = 2
+              emit Foo()
+          }
+        `)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckViewInitializer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              let x: Int
+
+              view init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          view fun foo(): S {
+              return S(x: 1)
+          }
+        `)
+		require.NoError(t, err)
+
+		sType := RequireGlobalValue(t, checker.Elaboration, "S")
+		require.IsType(t, &sema.FunctionType{}, sType)
+
+		assert.Equal(t,
+			sema.FunctionPurityView,
+			sType.(*sema.FunctionType).Purity,
+		)
+	})
+
+	t.Run("impure initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          view fun foo(): S {
+              return S(x: 1)
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("no initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          view fun foo(): S {
+              return S()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("view initializer with side effect", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          event Foo()
+
+          struct S {
+              view init() {
+                  emit Foo()
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
+
+func TestCheckConditionPurity(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("pre-condition calling view", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun isValid(_ x: Int): Bool {
+              return x > 0
+          }
+
+          fun foo(_ x: Int) {
+              pre {
+                  isValid(x)
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("pre-condition calling impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun isValid(_ x: Int): Bool {
+              return x > 0
+          }
+
+          fun foo(_ x: Int) {
+              pre {
+                  isValid(x)
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("post-condition calling impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun isValid(_ x: Int): Bool {
+              return x > 0
+          }
+
+          fun foo(_ x: Int) {
+              post {
+                  isValid(x)
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("post-condition before calling impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun isValid(_ x: Int): Bool {
+              return x > 0
+          }
+
+          fun foo(_ x: Int) {
+              post {
+                  before(isValid(x))
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
+
+func TestCheckViewFunctionSubtyping(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view to impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo() {}
+
+          let f: (() : Void) = foo
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("impure to view", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo() {}
+
+          let f: (view () : Void) = foo
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("interface requires view", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              view fun foo(): Int
+          }
+
+          struct S: I {
+              fun foo(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ConformanceError{}, errs[0])
+	})
+
+	t.Run("interface allows view", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun foo(): Int
+          }
+
+          struct S: I {
+              view fun foo(): Int {
+                  return 1
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+}
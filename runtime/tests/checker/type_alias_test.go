@@ -0,0 +1,491 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Count = Int
+
+          let x: Count = 1
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalType(t, checker.Elaboration, "Count"),
+		)
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("composite type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Things = {String: [Thing]}
+
+          struct Thing {}
+
+          let things: Things = {"a": [Thing()]}
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType: sema.StringType,
+				ValueType: &sema.VariableSizedType{
+					Type: RequireGlobalType(t, checker.Elaboration, "Thing"),
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "things"),
+		)
+	})
+
+	t.Run("forward reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Counts = [Count]
+          typealias Count = Int
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			RequireGlobalType(t, checker.Elaboration, "Counts"),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias Alias = R
+
+          fun test(): @Alias {
+              return <- create R()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource, missing annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias Alias = R
+
+          fun test(r: Alias) {
+              destroy r
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+
+	t.Run("interface in restricted type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface Receiver {}
+
+          resource interface Balance {}
+
+          typealias ReceiverCapability = Capability<&{Receiver, Balance}>
+
+          fun test(capability: ReceiverCapability): &{Receiver, Balance}? {
+              return capability.borrow()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("casting", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Count = Int
+
+          let x: AnyStruct = 1
+          let y = x as? Count
+          let z = Type<Count>()
+        `)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckInvalidTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("not declared", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Alias = Unknown
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("self-referential", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Alias = [Alias]
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.Equal(t,
+			"Alias",
+			errs[0].(*sema.CyclicTypeAliasError).Name,
+		)
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = B
+          typealias B = {String: C}
+          typealias C = A
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 21, Line: 2, Column: 20},
+				EndPos:   ast.Position{Offset: 21, Line: 2, Column: 20},
+			},
+			errs[0].(*sema.CyclicTypeAliasError).Range,
+		)
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          typealias S = Int
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("private", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          priv typealias Alias = Int
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessModifierError{}, errs[0])
+	})
+}
+
+func TestCheckNestedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract C {
+
+              pub typealias Counts = [Count]
+
+              pub typealias Count = Int
+
+              pub typealias Rs = [R]
+
+              pub resource R {
+                  pub let counts: Counts
+
+                  init(counts: Counts) {
+                      self.counts = counts
+                  }
+              }
+
+              pub fun createRs(): @Rs {
+                  return <- [<- create R(counts: [1, 2])]
+              }
+          }
+
+          fun test(): C.Counts {
+              let rs: @C.Rs <- C.createRs()
+              let counts = rs[0].counts
+              destroy rs
+              return counts
+          }
+        `)
+		require.NoError(t, err)
+
+		contractType := RequireGlobalType(t, checker.Elaboration, "C").(*sema.CompositeType)
+
+		countsType, ok := contractType.TypeAliases.Get("Counts")
+		require.True(t, ok)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			countsType,
+		)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              typealias Count = Int
+
+              let count: Count
+
+              init() {
+                  self.count = 1
+              }
+          }
+
+          let count: S.Count = S().count
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("qualified, declared later", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct A {
+              let count: S.Count
+
+              init() {
+                  self.count = 1
+              }
+          }
+
+          struct interface I {
+              fun count(): S.Count
+          }
+
+          struct S {
+              typealias Count = Int
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("qualified, in type alias declared later", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+
+              pub struct T {
+                  pub typealias Counts = [S.Count]
+              }
+
+              pub struct S {
+                  pub typealias Count = Int
+              }
+          }
+
+          let counts: C.T.Counts = [1, 2]
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("qualified, invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct A {
+              let count: S.Count
+
+              init() {
+                  self.count = "1"
+              }
+          }
+
+          struct S {
+              typealias Count = Int
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract interface CI {
+              pub typealias Counts = [Int]
+
+              pub fun counts(): Counts
+          }
+
+          let counts: CI.Counts = [1]
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              pub struct S {}
+
+              pub typealias S = Int
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              pub typealias A = B
+              pub typealias B = A
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("private", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              priv typealias A = Int
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessModifierError{}, errs[0])
+	})
+}
+
+func TestCheckImportedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub contract C {
+              pub typealias Count = Int
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	checker, err := ParseAndCheckWithOptions(t,
+		`
+          import C from "imported"
+
+          typealias Counts = [C.Count]
+
+          let count: C.Count = 1
+          let counts: Counts = [count]
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				ImportHandler: func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+					return sema.ElaborationImport{
+						Elaboration: importedChecker.Elaboration,
+					}, nil
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: sema.IntType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "counts"),
+	)
+}
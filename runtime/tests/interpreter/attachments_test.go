@@ -0,0 +1,544 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretAttachments(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("attach and access", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {
+              let y: Int
+
+              init(y: Int) {
+                  self.y = y
+              }
+
+              fun sum(): Int {
+                  return base.x + self.y
+              }
+          }
+
+          fun test(): Int {
+              let s = attach A(y: 2) to S()
+              return s[A]!.sum()
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+			value,
+		)
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = S()
+              return s[A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("struct base is copied", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): [Bool] {
+              let s = S()
+              let s2 = attach A() to s
+              return [s[A] == nil, s2[A] != nil]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.Address{},
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+			),
+			value,
+		)
+	})
+
+	t.Run("attachments are not shown", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+        `)
+
+		require.Equal(t,
+			"S.test.S(x: 1)",
+			inter.Globals.Get("s").GetValue().String(),
+		)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = attach A() to S()
+              remove A from s
+              return s[A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("remove through reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = attach A() to S()
+              let ref = &s as &S
+              remove A from ref
+              return s[A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = attach A() to S()
+              attach A() to s
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.DuplicateAttachmentError{})
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          attachment A for R {
+              fun double(): Int {
+                  return base.x * 2
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R(x: 21)
+              let ref = &r as &R
+              let doubled = ref[A]!.double()
+              destroy r
+              return doubled
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(42),
+			value,
+		)
+	})
+
+	t.Run("destroyed with base", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          var log: [String] = []
+
+          resource R {
+              destroy() {
+                  log.append("R")
+              }
+          }
+
+          attachment A for R {
+              destroy() {
+                  log.append("A")
+              }
+          }
+
+          fun test() {
+              let r <- attach A() to <-create R()
+              destroy r
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Equal(t,
+			`["A", "R"]`,
+			inter.Globals.Get("log").GetValue().String(),
+		)
+	})
+
+	t.Run("remove resource attachment destroys it", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              var removed: Bool
+
+              init() {
+                  self.removed = false
+              }
+
+              fun markRemoved() {
+                  self.removed = true
+              }
+          }
+
+          attachment A for R {
+              destroy() {
+                  base.markRemoved()
+              }
+          }
+
+          fun test(): Bool {
+              let r <- attach A() to <-create R()
+              remove A from r
+              let removed = r.removed
+              destroy r
+              return removed
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("remove resource attachment through reference destroys it", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              var removed: Bool
+
+              init() {
+                  self.removed = false
+              }
+
+              fun markRemoved() {
+                  self.removed = true
+              }
+          }
+
+          attachment A for R {
+              destroy() {
+                  base.markRemoved()
+              }
+          }
+
+          fun test(): Bool {
+              let r <- attach A() to <-create R()
+              let ref = &r as &R
+              remove A from ref
+              let removed = r.removed && r[A] == nil
+              destroy r
+              return removed
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("remove through storage reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(t, address, true, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): Bool {
+              account.save(<-attach A() to <-create R(), to: /storage/r)
+              let ref = account.borrow<&R>(from: /storage/r)!
+              remove A from ref
+              return account.borrow<&R>(from: /storage/r)![A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+        `)
+
+		s := inter.Globals.Get("s").GetValue().(*interpreter.CompositeValue)
+
+		var fieldNames []string
+		s.ForEachField(inter, func(name string, _ interpreter.Value) {
+			fieldNames = append(fieldNames, name)
+		})
+
+		assert.Equal(t, []string{"x"}, fieldNames)
+
+		// Attachments are still children of the value they are attached to
+
+		var children []interpreter.Value
+		s.Walk(inter, func(child interpreter.Value) {
+			children = append(children, child)
+		})
+
+		assert.Len(t, children, 2)
+	})
+
+	t.Run("equality", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {
+              let y: Int
+
+              init(y: Int) {
+                  self.y = y
+              }
+          }
+
+          attachment B for S {}
+
+          let withoutAttachment = S()
+          let withA1 = attach A(y: 1) to S()
+          let withOtherA1 = attach A(y: 1) to S()
+          let withA2 = attach A(y: 2) to S()
+          let withB = attach B() to S()
+        `)
+
+		value := func(name string) *interpreter.CompositeValue {
+			return inter.Globals.Get(name).GetValue().(*interpreter.CompositeValue)
+		}
+
+		equal := func(a, b string) bool {
+			return value(a).Equal(inter, interpreter.EmptyLocationRange, value(b))
+		}
+
+		assert.True(t, equal("withA1", "withOtherA1"))
+		assert.True(t, equal("withOtherA1", "withA1"))
+
+		assert.False(t, equal("withoutAttachment", "withA1"))
+		assert.False(t, equal("withA1", "withoutAttachment"))
+
+		assert.False(t, equal("withA1", "withA2"))
+		assert.False(t, equal("withA1", "withB"))
+		assert.False(t, equal("withB", "withA1"))
+	})
+
+	t.Run("conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+        `)
+
+		s := inter.Globals.Get("s").GetValue()
+
+		assert.True(t,
+			s.ConformsToStaticType(
+				inter,
+				interpreter.EmptyLocationRange,
+				interpreter.TypeConformanceResults{},
+			),
+		)
+	})
+}
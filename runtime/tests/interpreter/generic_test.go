@@ -0,0 +1,683 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretGenericFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("identity", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let result = identity(1)
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("array literal of generic type", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun wrap<T>(_ value: T): [T] {
+              let values: [T] = [value]
+              return values
+          }
+
+          let result = wrap("x").getType() == Type<[String]>()
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("runtime type of type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun typeOf<T>(_ value: T): Type {
+              return Type<T>()
+          }
+
+          fun nested<T>(_ value: T): Type {
+              return typeOf(value)
+          }
+
+          let inferred = typeOf("x") == Type<String>()
+          let explicit = typeOf<UInt8>(1 as UInt8) == Type<UInt8>()
+          let fromNested = nested<Int8>(1 as Int8) == Type<Int8>()
+        `)
+
+		for _, name := range []string{"inferred", "explicit", "fromNested"} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("cast to type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun cast<T>(_ value: AnyStruct): T? {
+              return value as? T
+          }
+
+          let success = cast<Int>(1)
+          let failure = cast<String>(1)
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.NewUnmeteredIntValueFromInt64(1),
+			),
+			inter.Globals.Get("success").GetValue(),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.Nil,
+			inter.Globals.Get("failure").GetValue(),
+		)
+	})
+
+	t.Run("function type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun apply<T, U>(_ value: T, _ f: ((T): U)): U {
+              return f(value)
+          }
+
+          let result = apply(1, fun (x: Int): String { return x.toString() })
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredStringValue("1"),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("closure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun makeGetter<T>(_ value: T): ((): T) {
+              fun get(): T {
+                  return value
+              }
+              return get
+          }
+
+          let result = makeGetter(true)()
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun repeat<T>(_ n: Int, _ value: T): [T] {
+              if n == 0 {
+                  return []
+              }
+              let values = repeat(n - 1, value)
+              values.append(value)
+              return values
+          }
+
+          let result = repeat(3, "a").length
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          fun first<T: @AnyResource>(_ values: @[T]): @T {
+              let value <- values.removeFirst()
+              destroy values
+              return <-value
+          }
+
+          fun test(): Int {
+              let r <- first(<-[<-create R(id: 1), <-create R(id: 2)])
+              let id = r.id
+              destroy r
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("interface function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct interface Duplicator {
+              fun duplicate<T>(_ value: T): [T]
+          }
+
+          struct D: Duplicator {
+              fun duplicate<T>(_ value: T): [T] {
+                  return [value, value]
+              }
+          }
+
+          let duplicator: {Duplicator} = D()
+          let result = duplicator.duplicate<Bool>(true).length
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(2),
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+}
+
+func TestInterpretGenericComposite(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              fun get(): T {
+                  return self.value
+              }
+
+              fun map<U>(_ f: ((T): U)): Box<U> {
+                  return Box<U>(value: f(self.value))
+              }
+          }
+
+          let box = Box(value: 1)
+          let value = box.get()
+          let mapped = box.map(fun (x: Int): String { return x.toString() }).value
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			inter.Globals.Get("value").GetValue(),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredStringValue("1"),
+			inter.Globals.Get("mapped").GetValue(),
+		)
+	})
+
+	t.Run("type argument in function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              fun valueType(): Type {
+                  return Type<T>()
+              }
+
+              fun values(): [T] {
+                  return [self.value]
+              }
+          }
+
+          let intType = Box(value: 1).valueType() == Type<Int>()
+          let stringType = Box(value: "a").valueType() == Type<String>()
+          let arrayType = Box(value: "a").values().getType() == Type<[String]>()
+        `)
+
+		for _, name := range []string{"intType", "stringType", "arrayType"} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: AnyStruct = Box(value: 1)
+          let success = (box as? Box<Int>) != nil
+          let failure = (box as? Box<String>) == nil
+        `)
+
+		for _, name := range []string{"success", "failure"} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("cast, nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: AnyStruct = Box(value: Box(value: 1))
+          let success = (box as? Box<Box<Int>>) != nil
+          let failure = (box as? Box<Box<String>>) == nil
+        `)
+
+		for _, name := range []string{"success", "failure"} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("force cast, nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          fun test() {
+              let box: AnyStruct = Box(value: Box(value: 1))
+              let b = box as! Box<Box<String>>
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ForceCastTypeMismatchError{})
+	})
+
+	t.Run("run-time types", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box = Box(value: 1)
+          let nestedBox = Box(value: box)
+
+          let isInstance = box.isInstance(Type<Box<Int>>())
+          let isNotInstance = !box.isInstance(Type<Box<String>>())
+          let nestedIsInstance = nestedBox.isInstance(Type<Box<Box<Int>>>())
+          let nestedIsNotInstance = !nestedBox.isInstance(Type<Box<Box<String>>>())
+          let typesEqual = Type<Box<Int>>() == Type<Box<Int>>()
+          let typesNotEqual = Type<Box<Int>>() != Type<Box<String>>()
+          let getType = box.getType() == Type<Box<Int>>()
+          let nestedGetType = nestedBox.getType() == Type<Box<Box<Int>>>()
+        `)
+
+		for _, name := range []string{
+			"isInstance",
+			"isNotInstance",
+			"nestedIsInstance",
+			"nestedIsNotInstance",
+			"typesEqual",
+			"typesNotEqual",
+			"getType",
+			"nestedGetType",
+		} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("cast, containers", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let array: AnyStruct = [Box(value: 1)]
+          let dictionary: AnyStruct = {"a": Box(value: 1)}
+          let box: AnyStruct = Box(value: 1)
+          let reference: AnyStruct = &box as auth &AnyStruct
+
+          let arraySuccess = (array as? [Box<Int>]) != nil
+          let arrayFailure = (array as? [Box<String>]) == nil
+          let nestedArrayFailure = ([array] as? [[Box<String>]]) == nil
+          let dictionarySuccess = (dictionary as? {String: Box<Int>}) != nil
+          let dictionaryFailure = (dictionary as? {String: Box<String>}) == nil
+          let referenceSuccess = (reference as? &Box<Int>) != nil
+          let referenceFailure = (reference as? &Box<String>) == nil
+        `)
+
+		for _, name := range []string{
+			"arraySuccess",
+			"arrayFailure",
+			"nestedArrayFailure",
+			"dictionarySuccess",
+			"dictionaryFailure",
+			"referenceSuccess",
+			"referenceFailure",
+		} {
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.BoolValue(true),
+				inter.Globals.Get(name).GetValue(),
+			)
+		}
+	})
+
+	t.Run("force cast, array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          fun test() {
+              let x: AnyStruct = [Box(value: 1)]
+              let b = x as! [Box<String>]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ForceCastTypeMismatchError{})
+	})
+
+	t.Run("resource collection", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource NFT {
+              let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          resource Collection<T: @AnyResource> {
+              var items: @[T]
+
+              init() {
+                  self.items <- []
+              }
+
+              fun deposit(_ item: @T) {
+                  self.items.append(<-item)
+              }
+
+              fun withdraw(): @T {
+                  return <-self.items.removeFirst()
+              }
+
+              destroy() {
+                  destroy self.items
+              }
+          }
+
+          fun test(): Int {
+              let collection <- create Collection<@NFT>()
+              collection.deposit(<-create NFT(id: 1))
+              collection.deposit(<-create NFT(id: 2))
+
+              let nft <- collection.withdraw()
+              let id = nft.id
+
+              destroy nft
+              destroy collection
+
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("string representation", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box = Box(value: 1)
+        `)
+
+		require.Equal(t,
+			"S.test.Box(value: 1)",
+			inter.Globals.Get("box").GetValue().String(),
+		)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box = Box(value: 1)
+        `)
+
+		box := inter.Globals.Get("box").GetValue().(*interpreter.CompositeValue)
+
+		var fieldNames []string
+		box.ForEachField(inter, func(name string, _ interpreter.Value) {
+			fieldNames = append(fieldNames, name)
+		})
+
+		assert.Equal(t, []string{"value"}, fieldNames)
+
+		typeArguments := box.StaticType(inter).(interpreter.CompositeStaticType).TypeArguments()
+		assert.Equal(t,
+			[]interpreter.StaticType{interpreter.PrimitiveStaticTypeInt},
+			typeArguments,
+		)
+	})
+
+	t.Run("equality", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box1 = Box(value: 1)
+          let box2 = Box(value: 1)
+          let box3 = Box(value: 2)
+        `)
+
+		box1 := inter.Globals.Get("box1").GetValue().(*interpreter.CompositeValue)
+		box2 := inter.Globals.Get("box2").GetValue().(*interpreter.CompositeValue)
+		box3 := inter.Globals.Get("box3").GetValue().(*interpreter.CompositeValue)
+
+		assert.True(t, box1.Equal(inter, interpreter.EmptyLocationRange, box2))
+		assert.False(t, box1.Equal(inter, interpreter.EmptyLocationRange, box3))
+	})
+}
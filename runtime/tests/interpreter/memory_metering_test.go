@@ -7404,6 +7404,26 @@ func TestInterpretStringMetering(t *testing.T) {
 		// 1 + 4 (max UTF8 encoding)
 		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
 	})
+	t.Run("template", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub fun main() {
+                let x = "ab\(1)\("c")"
+            }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// 1 + 4 (length of the result)
+		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
+		// 2 (number of interpolated expressions) + 1
+		assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindStringTemplateExpression))
+	})
 }
 
 func TestInterpretCharacterMetering(t *testing.T) {
@@ -2526,7 +2526,7 @@ func TestInterpretResourceDestroyedInPreCondition(t *testing.T) {
 
 	t.Parallel()
 
-	inter := parseCheckAndInterpret(t, `
+	inter, err := parseCheckAndInterpretWithOptions(t, `
         resource interface I {
              pub fun receiveResource(_ r: @Bar) {
                 pre {
@@ -2555,9 +2555,18 @@ func TestInterpretResourceDestroyedInPreCondition(t *testing.T) {
             foo.receiveResource(<- bar)
             destroy foo
         }
-    `)
+    `,
+		ParseCheckAndInterpretOptions{
+			HandleCheckerError: func(err error) {
+				errs := checker.RequireCheckerErrors(t, err, 2)
+				require.IsType(t, &sema.PurityError{}, errs[0])
+				require.IsType(t, &sema.PurityError{}, errs[1])
+			},
+		},
+	)
+	require.NoError(t, err)
 
-	_, err := inter.Invoke("test")
+	_, err = inter.Invoke("test")
 	RequireError(t, err)
 
 	require.ErrorAs(t, err, &interpreter.InvalidatedResourceError{})
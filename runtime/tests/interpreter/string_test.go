@@ -462,3 +462,30 @@ func TestInterpretCompareCharacters(t *testing.T) {
 		inter.Globals.Get("z").GetValue(),
 	)
 }
+
+func TestInterpretStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          let name = "Flow"
+          let character: Character = "!"
+          let count = 3
+          let fix: Fix64 = -1.5
+          let address: Address = 0x1
+          let path = /storage/foo
+          return "Hello, \(name)\(character) \(count + 1) \(fix) \(true) \(address) \(path) \("[\(name)]")\t\\(x)"
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewUnmeteredStringValue(
+			"Hello, Flow! 4 -1.50000000 true 0x0000000000000001 /storage/foo [Flow]\t\\(x)",
+		),
+		result,
+	)
+}
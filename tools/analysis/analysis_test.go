@@ -295,3 +295,41 @@ func TestStdlib(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+func TestApplySuggestedFixes(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "let x = y"
+
+	replace := func(message string, start, end int, replacement string) analysis.SuggestedFix {
+		return analysis.SuggestedFix{
+			Message: message,
+			TextEdits: []ast.TextEdit{
+				{
+					Replacement: replacement,
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: start, Line: 1, Column: start},
+						EndPos:   ast.Position{Offset: end, Line: 1, Column: end},
+					},
+				},
+			},
+		}
+	}
+
+	rename := replace("rename", 4, 4, "z")
+	overlapping := replace("overlapping", 4, 6, "a:")
+	unwrap := replace("unwrap", 8, 8, "y!")
+
+	result, applied, err := analysis.ApplySuggestedFixes(
+		[]byte(code),
+		[]analysis.SuggestedFix{rename, overlapping, unwrap},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "let z = y!", string(result))
+	require.Equal(t,
+		[]analysis.SuggestedFix{rename, unwrap},
+		applied,
+	)
+}
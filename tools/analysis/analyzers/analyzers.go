@@ -0,0 +1,54 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package analyzers provides a catalog of ready-made analyzers,
+// which can be run on programs loaded by package analysis.
+package analyzers
+
+import (
+	"sort"
+
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// Analyzers is the catalog of built-in analyzers, by name.
+//
+// The name of an analyzer is also the category of the diagnostics it reports.
+var Analyzers = map[string]*analysis.Analyzer{
+	UnusedVariableCategory:        UnusedVariableAnalyzer,
+	UnusedImportCategory:          UnusedImportAnalyzer,
+	UnusedPrivateFunctionCategory: UnusedPrivateFunctionAnalyzer,
+	UnnecessaryForceCategory:      UnnecessaryForceAnalyzer,
+	RedundantCastCategory:         RedundantCastAnalyzer,
+	DeprecatedAccessCategory:      DeprecatedAccessAnalyzer,
+	ShadowedVariableCategory:      ShadowedVariableAnalyzer,
+	PublicCapabilityCategory:      PublicCapabilityAnalyzer,
+	PublicCollectionFieldCategory: PublicCollectionFieldAnalyzer,
+	PublicVariableFieldCategory:   PublicVariableFieldAnalyzer,
+	UncheckedBorrowCategory:       UncheckedBorrowAnalyzer,
+}
+
+// Names returns the sorted names of the analyzers in the catalog
+func Names() []string {
+	names := make([]string, 0, len(Analyzers))
+	for name := range Analyzers { //nolint:maprangecheck
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,851 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/tools/analysis"
+	"github.com/onflow/cadence/tools/analysis/analyzers"
+)
+
+var testLocation = common.StringLocation("test")
+
+var testContractLocation = common.AddressLocation{
+	Address: common.MustBytesToAddress([]byte{0x1}),
+	Name:    "Foo",
+}
+
+const testContractCode = `
+  pub contract Foo {
+      pub struct Bar {}
+  }
+`
+
+var testOtherContractLocation = common.AddressLocation{
+	Address: testContractLocation.Address,
+	Name:    "Baz",
+}
+
+const testOtherContractCode = `
+  pub contract Baz {}
+`
+
+func runAnalyzers(t *testing.T, code string, analyzersToRun ...*analysis.Analyzer) []analysis.Diagnostic {
+
+	config := analysis.NewSimpleConfig(
+		analysis.NeedTypes|analysis.NeedExtendedElaboration|analysis.NeedPositionInfo,
+		map[common.Location][]byte{
+			testLocation:              []byte(code),
+			testContractLocation:      []byte(testContractCode),
+			testOtherContractLocation: []byte(testOtherContractCode),
+		},
+		map[common.Address][]string{
+			testContractLocation.Address: {
+				testContractLocation.Name,
+				testOtherContractLocation.Name,
+			},
+		},
+		nil,
+	)
+
+	programs, err := analysis.Load(config, testLocation)
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	var diagnostics []analysis.Diagnostic
+
+	programs[testLocation].Run(
+		analyzersToRun,
+		func(diagnostic analysis.Diagnostic) {
+			lock.Lock()
+			defer lock.Unlock()
+			diagnostics = append(diagnostics, diagnostic)
+		},
+	)
+
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return diagnostics[i].StartPos.Offset < diagnostics[j].StartPos.Offset
+	})
+
+	return diagnostics
+}
+
+func diagnosticMessages(diagnostics []analysis.Diagnostic) []string {
+	messages := make([]string, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		messages = append(messages, diagnostic.Message)
+	}
+	return messages
+}
+
+func applySuggestedFixes(t *testing.T, code string, diagnostics []analysis.Diagnostic) string {
+	var fixes []analysis.SuggestedFix
+	for _, diagnostic := range diagnostics {
+		fixes = append(fixes, diagnostic.SuggestedFixes...)
+	}
+
+	result, _, err := analysis.ApplySuggestedFixes([]byte(code), fixes)
+	require.NoError(t, err)
+
+	return string(result)
+}
+
+func TestAnalyzersCatalog(t *testing.T) {
+
+	t.Parallel()
+
+	names := analyzers.Names()
+	require.Len(t, names, len(analyzers.Analyzers))
+
+	for _, name := range names {
+		analyzer := analyzers.Analyzers[name]
+		require.NotNil(t, analyzer, name)
+		assert.NotEmpty(t, analyzer.Description, name)
+	}
+}
+
+func TestUnusedVariableAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub fun test(a: Int): Int {
+              let x = 1
+              var y = a
+              let z = y
+              for i, element in [1] {}
+              if let w = 1 as Int? {
+                  var v = 2
+                  return z
+              }
+              return 0
+          }
+        `,
+		analyzers.UnusedVariableAnalyzer,
+	)
+
+	require.Equal(t,
+		[]analysis.Diagnostic{
+			{
+				Location: testLocation,
+				Category: analyzers.UnusedVariableCategory,
+				Message:  "unused constant `x`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 57, Line: 3, Column: 18},
+					EndPos:   ast.Position{Offset: 57, Line: 3, Column: 18},
+				},
+			},
+			{
+				Location: testLocation,
+				Category: analyzers.UnusedVariableCategory,
+				Message:  "unused constant `w`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 171, Line: 7, Column: 21},
+					EndPos:   ast.Position{Offset: 171, Line: 7, Column: 21},
+				},
+			},
+			{
+				Location: testLocation,
+				Category: analyzers.UnusedVariableCategory,
+				Message:  "unused variable `v`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 209, Line: 8, Column: 22},
+					EndPos:   ast.Position{Offset: 209, Line: 8, Column: 22},
+				},
+			},
+		},
+		diagnostics,
+	)
+}
+
+func TestShadowedVariableAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub fun test(a: Int): Int {
+              let x = a
+              if true {
+                  let x = x + 1
+                  let a = x
+              }
+              switch x {
+              case 1:
+                  let y = 1
+              case 2:
+                  let y = 2
+              }
+              let f = fun (x: Int): Int {
+                  return x
+              }
+              return f(x)
+          }
+        `,
+		analyzers.ShadowedVariableAnalyzer,
+	)
+
+	require.Equal(t,
+		[]string{
+			"constant `x` shadows constant",
+			"constant `a` shadows parameter",
+			"parameter `x` shadows constant",
+		},
+		diagnosticMessages(diagnostics),
+	)
+
+	assert.Equal(t, "previously declared on line 3", diagnostics[0].SecondaryMessage)
+	assert.Equal(t, analyzers.ShadowedVariableCategory, diagnostics[0].Category)
+	assert.Equal(t,
+		ast.Range{
+			StartPos: ast.Position{Offset: 109, Line: 5, Column: 22},
+			EndPos:   ast.Position{Offset: 109, Line: 5, Column: 22},
+		},
+		diagnostics[0].Range,
+	)
+}
+
+func TestLocalVariablesIfLetElse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unused", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(
+			t,
+			`
+              pub fun test(a: Int?): Int {
+                  let x = 1
+                  if let x = a {
+                      return 0
+                  } else {
+                      return x
+                  }
+              }
+            `,
+			analyzers.UnusedVariableAnalyzer,
+		)
+
+		// The outer constant is referred to in the else branch,
+		// the binding of the if-let is not in scope there
+
+		require.Equal(t,
+			[]string{
+				"unused constant `x`",
+			},
+			diagnosticMessages(diagnostics),
+		)
+		assert.Equal(t, 4, diagnostics[0].StartPos.Line)
+	})
+
+	t.Run("shadowed", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(
+			t,
+			`
+              pub fun test(a: Int?): Int {
+                  let x = 1
+                  if let x = a {
+                      return x
+                  } else {
+                      let x = 2
+                      return x
+                  }
+              }
+            `,
+			analyzers.ShadowedVariableAnalyzer,
+		)
+
+		require.Equal(t,
+			[]string{
+				"constant `x` shadows constant",
+				"constant `x` shadows constant",
+			},
+			diagnosticMessages(diagnostics),
+		)
+
+		// The declaration in the else branch shadows the outer constant,
+		// not the binding of the if-let
+
+		assert.Equal(t, 4, diagnostics[0].StartPos.Line)
+		assert.Equal(t, "previously declared on line 3", diagnostics[0].SecondaryMessage)
+
+		assert.Equal(t, 7, diagnostics[1].StartPos.Line)
+		assert.Equal(t, "previously declared on line 3", diagnostics[1].SecondaryMessage)
+	})
+
+	t.Run("sibling branches", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(
+			t,
+			`
+              pub fun test(a: Int?): Int {
+                  if let y = a {
+                      return y
+                  } else {
+                      let y = 2
+                      return y
+                  }
+              }
+            `,
+			analyzers.ShadowedVariableAnalyzer,
+		)
+
+		require.Empty(t, diagnostics)
+	})
+}
+
+func TestUnusedImportAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("identifiers", func(t *testing.T) {
+
+		t.Parallel()
+
+		code := `
+              import Foo from 0x1
+
+              pub fun test() {}
+            `
+
+		diagnostics := runAnalyzers(
+			t,
+			code,
+			analyzers.UnusedImportAnalyzer,
+		)
+
+		require.Equal(t,
+			[]analysis.Diagnostic{
+				{
+					Location: testLocation,
+					Category: analyzers.UnusedImportCategory,
+					Message:  "unused import `Foo`",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 22, Line: 2, Column: 21},
+						EndPos:   ast.Position{Offset: 24, Line: 2, Column: 23},
+					},
+					SuggestedFixes: []analysis.SuggestedFix{
+						{
+							Message: "remove import",
+							TextEdits: []ast.TextEdit{
+								{
+									Range: ast.Range{
+										StartPos: ast.Position{Offset: 15, Line: 2, Column: 14},
+										EndPos:   ast.Position{Offset: 34, Line: 2, Column: 33},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			diagnostics,
+		)
+
+		require.Equal(t,
+			`
+              
+              pub fun test() {}
+            `,
+			applySuggestedFixes(t, code, diagnostics),
+		)
+	})
+
+	t.Run("some identifiers", func(t *testing.T) {
+
+		t.Parallel()
+
+		test := func(code string, expected string) {
+			diagnostics := runAnalyzers(t, code, analyzers.UnusedImportAnalyzer)
+
+			require.Equal(t,
+				[]string{"unused import `Baz`"},
+				diagnosticMessages(diagnostics),
+			)
+
+			require.Equal(t,
+				expected,
+				applySuggestedFixes(t, code, diagnostics),
+			)
+		}
+
+		test(
+			`
+              import Baz, Foo from 0x1
+
+              pub fun test(bar: Foo.Bar) {}
+            `,
+			`
+              import Foo from 0x1
+
+              pub fun test(bar: Foo.Bar) {}
+            `,
+		)
+
+		test(
+			`
+              import Foo, Baz from 0x1
+
+              pub fun test(bar: Foo.Bar) {}
+            `,
+			`
+              import Foo from 0x1
+
+              pub fun test(bar: Foo.Bar) {}
+            `,
+		)
+	})
+
+	t.Run("used in type", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(
+			t,
+			`
+              import Foo from 0x1
+
+              pub fun test(bar: Foo.Bar) {}
+            `,
+			analyzers.UnusedImportAnalyzer,
+		)
+
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("address", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(
+			t,
+			`
+              import 0x1
+
+              pub fun test() {}
+            `,
+			analyzers.UnusedImportAnalyzer,
+		)
+
+		require.Equal(t,
+			[]string{"unused import of `0x1`"},
+			diagnosticMessages(diagnostics),
+		)
+	})
+}
+
+func TestUnusedPrivateFunctionAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          priv fun unused() {}
+
+          priv fun used() {}
+
+          pub fun test() {
+              used()
+          }
+
+          pub struct S {
+              priv fun unusedMember() {}
+
+              priv fun used() {}
+
+              pub fun test() {
+                  self.used()
+              }
+          }
+        `,
+		analyzers.UnusedPrivateFunctionAnalyzer,
+	)
+
+	require.Equal(t,
+		[]analysis.Diagnostic{
+			{
+				Location: testLocation,
+				Category: analyzers.UnusedPrivateFunctionCategory,
+				Message:  "unused private function `unused`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 20, Line: 2, Column: 19},
+					EndPos:   ast.Position{Offset: 25, Line: 2, Column: 24},
+				},
+			},
+			{
+				Location: testLocation,
+				Category: analyzers.UnusedPrivateFunctionCategory,
+				Message:  "unused private function `unusedMember`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 172, Line: 11, Column: 23},
+					EndPos:   ast.Position{Offset: 183, Line: 11, Column: 34},
+				},
+			},
+		},
+		diagnostics,
+	)
+}
+
+func TestUnnecessaryForceAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	code := `
+      pub fun test() {
+          let x = 1
+          let y: Int? = 2
+          let a = x!
+          let b = y!
+      }
+    `
+
+	diagnostics := runAnalyzers(
+		t,
+		code,
+		analyzers.UnnecessaryForceAnalyzer,
+	)
+
+	require.Equal(t,
+		[]analysis.Diagnostic{
+			{
+				Location: testLocation,
+				Category: analyzers.UnnecessaryForceCategory,
+				Message:  "unnecessary force-unwrap of non-optional type `Int`",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 88, Line: 5, Column: 18},
+					EndPos:   ast.Position{Offset: 89, Line: 5, Column: 19},
+				},
+				SuggestedFixes: []analysis.SuggestedFix{
+					{
+						Message: "remove force-unwrap",
+						TextEdits: []ast.TextEdit{
+							{
+								Range: ast.Range{
+									StartPos: ast.Position{Offset: 89, Line: 5, Column: 19},
+									EndPos:   ast.Position{Offset: 89, Line: 5, Column: 19},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		diagnostics,
+	)
+
+	require.Equal(t,
+		`
+      pub fun test() {
+          let x = 1
+          let y: Int? = 2
+          let a = x
+          let b = y!
+      }
+    `,
+		applySuggestedFixes(t, code, diagnostics),
+	)
+}
+
+func TestRedundantCastAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub fun test() {
+              let x = 1
+              let a = x as Int
+              let b = 1 as UInt8
+              let c: UInt8 = 1 as UInt8
+              let d = x as? Int
+              let e = x as! Int
+              let f = x as AnyStruct
+              let g = f as? Int
+          }
+        `,
+		analyzers.RedundantCastAnalyzer,
+	)
+
+	require.Equal(t,
+		[]string{
+			"redundant cast to `Int`",
+			"redundant cast to `UInt8`",
+			"cast of `Int` to `Int` always succeeds",
+			"cast of `Int` to `Int` always succeeds",
+		},
+		diagnosticMessages(diagnostics),
+	)
+
+	assert.Equal(t,
+		ast.Range{
+			StartPos: ast.Position{Offset: 74, Line: 4, Column: 22},
+			EndPos:   ast.Position{Offset: 81, Line: 4, Column: 29},
+		},
+		diagnostics[0].Range,
+	)
+}
+
+func TestDeprecatedAccessAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub struct S {
+              pub(set) var a: Int
+              priv let b: Int
+              access(all) let c: Int
+              access(self) let d: Int
+
+              init() {
+                  self.a = 1
+                  self.b = 2
+                  self.c = 3
+                  self.d = 4
+              }
+          }
+        `,
+		analyzers.DeprecatedAccessAnalyzer,
+	)
+
+	require.Equal(t,
+		[]analysis.Diagnostic{
+			{
+				Location: testLocation,
+				Category: analyzers.DeprecatedAccessCategory,
+				Message:  "`pub` is deprecated, use `access(all)` instead",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 11, Line: 2, Column: 10},
+					EndPos:   ast.Position{Offset: 13, Line: 2, Column: 12},
+				},
+			},
+			{
+				Location: testLocation,
+				Category: analyzers.DeprecatedAccessCategory,
+				Message:  "`pub(set)` is deprecated, declare a setter function instead",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 40, Line: 3, Column: 14},
+					EndPos:   ast.Position{Offset: 47, Line: 3, Column: 21},
+				},
+			},
+			{
+				Location: testLocation,
+				Category: analyzers.DeprecatedAccessCategory,
+				Message:  "`priv` is deprecated, use `access(self)` instead",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 74, Line: 4, Column: 14},
+					EndPos:   ast.Position{Offset: 77, Line: 4, Column: 17},
+				},
+			},
+		},
+		diagnostics,
+	)
+}
+
+func TestPublicCapabilityAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub resource interface Provider {
+              pub fun withdraw(): @R
+          }
+
+          pub resource interface Receiver {
+              pub fun deposit(r: @R)
+          }
+
+          pub resource R: Provider, Receiver {
+              pub fun withdraw(): @R {
+                  return <- create R()
+              }
+
+              pub fun deposit(r: @R) {
+                  destroy r
+              }
+          }
+
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.link<&AuthAccount>(/public/account, target: /private/account)
+                  signer.link<&R{Provider}>(/public/provider, target: /storage/r)
+                  signer.link<&R>(/public/r, target: /storage/r)
+                  signer.link<auth &R{Receiver}>(/public/auth, target: /storage/r)
+                  signer.link<&R{Receiver}>(/public/receiver, target: /storage/r)
+                  signer.link<&R{Provider}>(/private/provider, target: /storage/r)
+              }
+          }
+        `,
+		analyzers.PublicCapabilityAnalyzer,
+	)
+
+	require.Equal(t,
+		[]string{
+			"public capability to `&AuthAccount`",
+			"public capability to `&R{Provider}`",
+			"public capability to `&R`",
+			"public capability to `auth &R{Receiver}`",
+		},
+		diagnosticMessages(diagnostics),
+	)
+
+	assert.Equal(t, analyzers.PublicCapabilityCategory, diagnostics[0].Category)
+	assert.Equal(t,
+		"anyone can borrow the account and access its storage, keys, and contracts",
+		diagnostics[0].SecondaryMessage,
+	)
+	assert.Equal(t,
+		"anyone can borrow the resource and withdraw from it",
+		diagnostics[1].SecondaryMessage,
+	)
+}
+
+func TestPublicFieldAnalyzers(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      pub resource R {}
+
+      pub resource Collection {
+          pub let resources: @[R]
+          pub let names: {String: Int}
+          access(contract) let ids: [UInt64]
+          pub var count: Int
+          pub(set) var name: String
+          priv var total: Int
+
+          init() {
+              self.resources <- []
+              self.names = {}
+              self.ids = []
+              self.count = 0
+              self.name = ""
+              self.total = 0
+          }
+
+          destroy() {
+              destroy self.resources
+          }
+      }
+    `
+
+	t.Run("collection", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(t, code, analyzers.PublicCollectionFieldAnalyzer)
+
+		require.Equal(t,
+			[]string{
+				"public field `resources` has mutable collection type `[R]`",
+				"public field `names` has mutable collection type `{String: Int}`",
+			},
+			diagnosticMessages(diagnostics),
+		)
+
+		assert.Equal(t, analyzers.PublicCollectionFieldCategory, diagnostics[0].Category)
+		assert.Contains(t, diagnostics[0].SecondaryMessage, "remove resources")
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 76, Line: 5, Column: 18},
+				EndPos:   ast.Position{Offset: 84, Line: 5, Column: 26},
+			},
+			diagnostics[0].Range,
+		)
+	})
+
+	t.Run("variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := runAnalyzers(t, code, analyzers.PublicVariableFieldAnalyzer)
+
+		require.Equal(t,
+			[]string{
+				"public variable field `count`",
+				"public variable field `name`",
+			},
+			diagnosticMessages(diagnostics),
+		)
+
+		assert.Equal(t, analyzers.PublicVariableFieldCategory, diagnostics[0].Category)
+		assert.Equal(t,
+			"anyone with a reference to the composite can set the field",
+			diagnostics[1].SecondaryMessage,
+		)
+	})
+}
+
+func TestUncheckedBorrowAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := runAnalyzers(
+		t,
+		`
+          pub resource R {}
+
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let a = signer.borrow<&R>(from: /storage/r)!
+                  let b = signer.getCapability<&R>(/public/r).borrow()!
+                  let c = signer.borrow<&R>(from: /storage/r) ?? panic("missing R")
+                  let d = signer.getCapability<&R>(/public/r).borrow()
+              }
+          }
+        `,
+		analyzers.UncheckedBorrowAnalyzer,
+	)
+
+	require.Equal(t,
+		[]string{
+			"result of `borrow` is force-unwrapped",
+			"result of `borrow` is force-unwrapped",
+		},
+		diagnosticMessages(diagnostics),
+	)
+
+	assert.Equal(t, analyzers.UncheckedBorrowCategory, diagnostics[0].Category)
+	assert.Equal(t, 6, diagnostics[0].StartPos.Line)
+	assert.Equal(t, 7, diagnostics[1].StartPos.Line)
+}
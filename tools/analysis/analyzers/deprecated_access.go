@@ -0,0 +1,129 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const DeprecatedAccessCategory = "deprecated-access"
+
+// deprecatedAccessKeywords are the deprecated access modifier keywords,
+// and the access modifiers which replace them, if any
+var deprecatedAccessKeywords = []struct {
+	keyword     string
+	replacement string
+}{
+	{keyword: "priv", replacement: "access(self)"},
+	{keyword: "pub", replacement: "access(all)"},
+}
+
+// DeprecatedAccessAnalyzer reports declarations which use the deprecated access modifiers
+// `pub`, `pub(set)`, and `priv`, instead of the equivalent `access(...)` modifiers
+var DeprecatedAccessAnalyzer = &analysis.Analyzer{
+	Description: "Detects usages of the deprecated access modifiers `pub`, `pub(set)`, and `priv`",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+		code := pass.Program.Code
+
+		inspector.Preorder(
+			nil,
+			func(element ast.Element) {
+				declaration, ok := element.(ast.Declaration)
+				if !ok {
+					return
+				}
+
+				switch declaration.DeclarationAccess() {
+				case ast.AccessPrivate, ast.AccessPublic, ast.AccessPublicSettable:
+					break
+				default:
+					return
+				}
+
+				// The declaration starts at its access modifier
+
+				startPos := declaration.StartPosition()
+				if startPos.Offset >= len(code) {
+					return
+				}
+				source := code[startPos.Offset:]
+
+				for _, deprecated := range deprecatedAccessKeywords {
+					keyword := deprecated.keyword
+					if !hasKeywordPrefix(source, keyword) {
+						continue
+					}
+
+					length := len(keyword)
+					message := fmt.Sprintf(
+						"`%s` is deprecated, use `%s` instead",
+						keyword,
+						deprecated.replacement,
+					)
+
+					if declaration.DeclarationAccess() == ast.AccessPublicSettable {
+						length = bytes.IndexByte(source, ')') + 1
+						message = "`pub(set)` is deprecated, declare a setter function instead"
+					}
+
+					pass.Report(
+						analysis.Diagnostic{
+							Location: pass.Program.Location,
+							Range: ast.NewUnmeteredRange(
+								startPos,
+								startPos.Shifted(nil, length-1),
+							),
+							Category: DeprecatedAccessCategory,
+							Message:  message,
+						},
+					)
+
+					return
+				}
+			},
+		)
+
+		return nil
+	},
+}
+
+// hasKeywordPrefix returns true if the given source starts with the given keyword,
+// and the keyword is not just the prefix of a longer identifier
+func hasKeywordPrefix(source []byte, keyword string) bool {
+	if !bytes.HasPrefix(source, []byte(keyword)) {
+		return false
+	}
+	if len(source) == len(keyword) {
+		return true
+	}
+	next := source[len(keyword)]
+	return !(next == '_' ||
+		('a' <= next && next <= 'z') ||
+		('A' <= next && next <= 'Z') ||
+		('0' <= next && next <= '9'))
+}
@@ -0,0 +1,75 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser/lexer"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// Identifiers are the ranges of all identifier tokens in a program, by identifier.
+//
+// Unlike the AST, the tokens also include identifiers in types and in conditions,
+// so they can be used to conservatively determine if a declaration might be referred to.
+type Identifiers map[string][]ast.Range
+
+// CountIn returns the number of occurrences of the given identifier in the given range,
+// excluding the given occurrence, e.g. the declaration of the identifier
+func (identifiers Identifiers) CountIn(identifier ast.Identifier, r ast.Range) int {
+	count := 0
+	for _, occurrence := range identifiers[identifier.Identifier] {
+		if occurrence.StartPos.Offset == identifier.Pos.Offset {
+			continue
+		}
+		if occurrence.StartPos.Offset < r.StartPos.Offset ||
+			occurrence.EndPos.Offset > r.EndPos.Offset {
+
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// IdentifiersAnalyzer lexes the code of the program
+// and returns the Identifiers of the program
+var IdentifiersAnalyzer = &analysis.Analyzer{
+	Description: "Collects the identifiers of the program",
+	Run: func(pass *analysis.Pass) interface{} {
+		identifiers := Identifiers{}
+
+		tokens := lexer.Lex(pass.Program.Code, nil)
+		defer tokens.Reclaim()
+
+		for {
+			token := tokens.Next()
+			if token.Is(lexer.TokenEOF) {
+				break
+			}
+			if !token.Is(lexer.TokenIdentifier) {
+				continue
+			}
+			identifier := string(pass.Program.Code[token.StartPos.Offset : token.EndPos.Offset+1])
+			identifiers[identifier] = append(identifiers[identifier], token.Range)
+		}
+
+		return identifiers
+	},
+}
@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// LocalVariable is a variable, parameter, or function declared in a function
+type LocalVariable struct {
+	Identifier      ast.Identifier
+	DeclarationKind common.DeclarationKind
+	// Declaration is the variable declaration which declares the variable, if any.
+	// It is nil for parameters, loop variables, and functions
+	Declaration *ast.VariableDeclaration
+	// References are the ranges of the identifier expressions which refer to the variable
+	References []ast.Range
+	// Shadowed is the local variable of an enclosing scope which has the same name, if any
+	Shadowed *LocalVariable
+}
+
+// LocalVariablesAnalyzer returns all LocalVariable of the program, in declaration order.
+//
+// The variables and their references are determined from the occurrences recorded by the checker,
+// so the program must be loaded with position information (see analysis.NeedPositionInfo).
+// Global declarations and members are not included
+var LocalVariablesAnalyzer = &analysis.Analyzer{
+	Description: "Resolves the local variables of functions",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		positionInfo := pass.Program.PositionInfo
+		if positionInfo == nil {
+			return []*LocalVariable(nil)
+		}
+
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+		// Variable declarations, by the offset of their identifier
+		declarations := map[int]*ast.VariableDeclaration{}
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.VariableDeclaration)(nil),
+			},
+			func(element ast.Element) {
+				declaration := element.(*ast.VariableDeclaration)
+				declarations[declaration.Identifier.Pos.Offset] = declaration
+			},
+		)
+
+		localVariables := map[*sema.Variable]*LocalVariable{}
+		var variables []*LocalVariable
+
+		for variable, origin := range positionInfo.VariableOrigins {
+			if !isLocalVariable(variable) {
+				continue
+			}
+
+			identifier := ast.Identifier{
+				Identifier: variable.Identifier,
+				Pos:        *variable.Pos,
+			}
+
+			var references []ast.Range
+			for _, occurrence := range origin.Occurrences {
+				// Skip the occurrence of the declaration itself
+				if occurrence.StartPos.Offset == identifier.Pos.Offset {
+					continue
+				}
+				references = append(references, occurrence)
+			}
+
+			localVariable := &LocalVariable{
+				Identifier:      identifier,
+				DeclarationKind: variable.DeclarationKind,
+				Declaration:     declarations[identifier.Pos.Offset],
+				References:      references,
+			}
+
+			localVariables[variable] = localVariable
+			variables = append(variables, localVariable)
+		}
+
+		for variable, localVariable := range localVariables {
+			shadowedVariable, ok := positionInfo.ShadowedVariables[variable]
+			if !ok {
+				continue
+			}
+			// Variables of enclosing scopes which are not local, e.g. globals, are not included
+			localVariable.Shadowed = localVariables[shadowedVariable]
+		}
+
+		sort.Slice(variables, func(i, j int) bool {
+			return variables[i].Identifier.Pos.Offset < variables[j].Identifier.Pos.Offset
+		})
+
+		return variables
+	},
+}
+
+// programDepth is the depth of the activation in which the global declarations of a program are declared
+const programDepth = 1
+
+func isLocalVariable(variable *sema.Variable) bool {
+	if variable.Pos == nil ||
+		variable.Identifier == "" ||
+		variable.Identifier == "_" {
+
+		return false
+	}
+
+	switch variable.DeclarationKind {
+	case common.DeclarationKindParameter:
+		return true
+
+	case common.DeclarationKindConstant,
+		common.DeclarationKindVariable,
+		common.DeclarationKindFunction:
+
+		return variable.ActivationDepth > programDepth
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const PublicCapabilityCategory = "public-capability"
+
+// providerFunctionName is the name of the function of provider-style resources,
+// which allows withdrawing funds or NFTs, e.g. `FungibleToken.Provider.withdraw`
+const providerFunctionName = "withdraw"
+
+// PublicCapabilityAnalyzer reports capabilities which are linked in the public domain,
+// and allow anyone to access the account, or to withdraw from a resource.
+//
+// The analyzer requires the program to be type-checked
+var PublicCapabilityAnalyzer = &analysis.Analyzer{
+	Description: "Detects public capabilities to accounts, provider-style resources, and authorized references",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		elaboration := pass.Program.Elaboration
+		if elaboration == nil {
+			return nil
+		}
+
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.InvocationExpression)(nil),
+			},
+			func(element ast.Element) {
+				invocationExpression := element.(*ast.InvocationExpression)
+
+				if !isAccountFunctionInvocation(elaboration, invocationExpression, sema.AuthAccountLinkField) {
+					return
+				}
+
+				invocationTypes := elaboration.InvocationExpressionTypes[invocationExpression]
+
+				argumentTypes := invocationTypes.ArgumentTypes
+				if len(argumentTypes) == 0 ||
+					!argumentTypes[0].Equal(sema.PublicPathType) {
+
+					return
+				}
+
+				typeArguments := invocationTypes.TypeArguments
+				if typeArguments == nil || typeArguments.Len() == 0 {
+					return
+				}
+
+				referenceType, ok := typeArguments.Oldest().Value.(*sema.ReferenceType)
+				if !ok {
+					return
+				}
+
+				risk := publicReferenceRisk(referenceType)
+				if risk == "" {
+					return
+				}
+
+				pass.Report(
+					analysis.Diagnostic{
+						Location: pass.Program.Location,
+						Range:    ast.NewUnmeteredRangeFromPositioned(invocationExpression),
+						Category: PublicCapabilityCategory,
+						Message: fmt.Sprintf(
+							"public capability to `%s`",
+							referenceType.QualifiedString(),
+						),
+						SecondaryMessage: risk,
+					},
+				)
+			},
+		)
+
+		return nil
+	},
+}
+
+// publicReferenceRisk returns an explanation of the risk of linking the given reference type publicly,
+// or an empty string if there is no known risk
+func publicReferenceRisk(referenceType *sema.ReferenceType) string {
+	referencedType := referenceType.Type
+
+	switch {
+	case isAuthAccountType(referencedType):
+		return "anyone can borrow the account and access its storage, keys, and contracts"
+
+	case isProviderType(referencedType):
+		return "anyone can borrow the resource and withdraw from it"
+
+	case referenceType.Authorized:
+		return "anyone can borrow the reference and downcast it to the concrete type, " +
+			"gaining access to all of its public members, not just the ones of the restricted type"
+	}
+
+	return ""
+}
+
+// isAuthAccountType returns true if the given type is the AuthAccount type,
+// or a type nested in it, e.g. `AuthAccount.Keys`
+func isAuthAccountType(ty sema.Type) bool {
+	for ty != nil {
+		if ty == sema.AuthAccountType {
+			return true
+		}
+
+		containedType, ok := ty.(sema.ContainedType)
+		if !ok {
+			return false
+		}
+
+		containerType := containedType.GetContainerType()
+		if containerType == nil {
+			return false
+		}
+		ty = containerType
+	}
+	return false
+}
+
+// isProviderType returns true if the given type exposes provider-style functionality
+func isProviderType(ty sema.Type) bool {
+	switch ty := ty.(type) {
+	case *sema.RestrictedType:
+		// Only the restrictions are accessible
+		for _, restriction := range ty.Restrictions {
+			if isProviderInterfaceType(restriction) {
+				return true
+			}
+		}
+		return false
+
+	case *sema.CompositeType:
+		if ty.Members.Contains(providerFunctionName) {
+			return true
+		}
+		for _, conformance := range ty.ExplicitInterfaceConformances {
+			if isProviderInterfaceType(conformance) {
+				return true
+			}
+		}
+		return false
+
+	case *sema.InterfaceType:
+		return isProviderInterfaceType(ty)
+	}
+
+	return false
+}
+
+func isProviderInterfaceType(interfaceType *sema.InterfaceType) bool {
+	return strings.HasSuffix(interfaceType.Identifier, "Provider") ||
+		interfaceType.Members.Contains(providerFunctionName)
+}
+
+// isAccountFunctionInvocation returns true if the given expression is an invocation
+// of the function with the given name of an AuthAccount
+func isAccountFunctionInvocation(
+	elaboration *sema.Elaboration,
+	invocationExpression *ast.InvocationExpression,
+	functionName string,
+) bool {
+	memberInfo, ok := invokedMemberInfo(elaboration, invocationExpression, functionName)
+	return ok && memberInfo.AccessedType == sema.AuthAccountType
+}
+
+// invokedMemberInfo returns the member information of the invoked member,
+// if the given expression is an invocation of the member with the given name
+func invokedMemberInfo(
+	elaboration *sema.Elaboration,
+	invocationExpression *ast.InvocationExpression,
+	memberName string,
+) (
+	sema.MemberInfo,
+	bool,
+) {
+	memberExpression, ok := invocationExpression.InvokedExpression.(*ast.MemberExpression)
+	if !ok || memberExpression.Identifier.Identifier != memberName {
+		return sema.MemberInfo{}, false
+	}
+
+	memberInfo, ok := elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok || memberInfo.Member == nil {
+		return sema.MemberInfo{}, false
+	}
+
+	return memberInfo, true
+}
@@ -0,0 +1,158 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const (
+	PublicCollectionFieldCategory = "public-collection-field"
+	PublicVariableFieldCategory   = "public-variable-field"
+)
+
+// PublicCollectionFieldAnalyzer reports public fields of composites
+// which have a mutable collection type, i.e. an array or dictionary type.
+//
+// The analyzer requires the program to be type-checked
+var PublicCollectionFieldAnalyzer = &analysis.Analyzer{
+	Description: "Detects public fields of mutable collection types",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		forEachPublicField(pass, func(field *ast.FieldDeclaration, fieldType sema.Type) {
+
+			switch fieldType.(type) {
+			case sema.ArrayType, *sema.DictionaryType:
+				break
+			default:
+				return
+			}
+
+			risk := "anyone with a reference to the composite can modify the collection, " +
+				"e.g. insert or remove elements, even though the field cannot be assigned"
+			if fieldType.IsResourceType() {
+				risk = "anyone with a reference to the composite can modify the collection, " +
+					"e.g. remove resources from it, even though the field cannot be assigned"
+			}
+
+			pass.Report(
+				analysis.Diagnostic{
+					Location: pass.Program.Location,
+					Range:    ast.NewUnmeteredRangeFromPositioned(field.Identifier),
+					Category: PublicCollectionFieldCategory,
+					Message: fmt.Sprintf(
+						"public field `%s` has mutable collection type `%s`",
+						field.Identifier.Identifier,
+						fieldType.QualifiedString(),
+					),
+					SecondaryMessage: risk,
+				},
+			)
+		})
+
+		return nil
+	},
+}
+
+// PublicVariableFieldAnalyzer reports public variable fields of composites.
+//
+// The analyzer requires the program to be type-checked
+var PublicVariableFieldAnalyzer = &analysis.Analyzer{
+	Description: "Detects public variable fields",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		forEachPublicField(pass, func(field *ast.FieldDeclaration, _ sema.Type) {
+
+			if field.VariableKind != ast.VariableKindVariable {
+				return
+			}
+
+			risk := "the field can be reassigned by any function of the composite, " +
+				"and it is easily mistaken for a field which can be set by anyone, or by no one; " +
+				"consider declaring it with `let`, or restricting its access"
+			if field.Access == ast.AccessPublicSettable {
+				risk = "anyone with a reference to the composite can set the field"
+			}
+
+			pass.Report(
+				analysis.Diagnostic{
+					Location: pass.Program.Location,
+					Range:    ast.NewUnmeteredRangeFromPositioned(field.Identifier),
+					Category: PublicVariableFieldCategory,
+					Message: fmt.Sprintf(
+						"public variable field `%s`",
+						field.Identifier.Identifier,
+					),
+					SecondaryMessage: risk,
+				},
+			)
+		})
+
+		return nil
+	},
+}
+
+// forEachPublicField calls the given function for each public field of a composite
+// declared in the program, with the type of the field
+func forEachPublicField(pass *analysis.Pass, f func(field *ast.FieldDeclaration, fieldType sema.Type)) {
+	elaboration := pass.Program.Elaboration
+	if elaboration == nil {
+		return
+	}
+
+	inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+	inspector.Preorder(
+		[]ast.Element{
+			(*ast.CompositeDeclaration)(nil),
+		},
+		func(element ast.Element) {
+			declaration := element.(*ast.CompositeDeclaration)
+
+			compositeType := elaboration.CompositeDeclarationTypes[declaration]
+			if compositeType == nil {
+				return
+			}
+
+			for _, field := range declaration.Members.Fields() {
+				switch field.Access {
+				case ast.AccessPublic, ast.AccessPublicSettable:
+					break
+				default:
+					continue
+				}
+
+				member, ok := compositeType.Members.Get(field.Identifier.Identifier)
+				if !ok {
+					continue
+				}
+
+				f(field, member.TypeAnnotation.Type)
+			}
+		},
+	)
+}
@@ -0,0 +1,138 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const RedundantCastCategory = "redundant-cast"
+
+// RedundantCastAnalyzer reports static casts to the type the expression already has,
+// and failable casts which always succeed.
+//
+// The analyzer requires the program to be loaded with an extended elaboration
+var RedundantCastAnalyzer = &analysis.Analyzer{
+	Description: "Detects redundant casts",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		elaboration := pass.Program.Elaboration
+		if elaboration == nil {
+			return nil
+		}
+
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+		report := func(castingExpression *ast.CastingExpression, message string) {
+			pass.Report(
+				analysis.Diagnostic{
+					Location: pass.Program.Location,
+					Range:    ast.NewUnmeteredRangeFromPositioned(castingExpression),
+					Category: RedundantCastCategory,
+					Message:  message,
+				},
+			)
+		}
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.CastingExpression)(nil),
+			},
+			func(element ast.Element) {
+				castingExpression := element.(*ast.CastingExpression)
+
+				switch castingExpression.Operation {
+				case ast.OperationCast:
+					castTypes, ok := elaboration.StaticCastTypes[castingExpression]
+					if !ok || !isRedundantStaticCast(castingExpression.Expression, castTypes) {
+						return
+					}
+
+					report(
+						castingExpression,
+						fmt.Sprintf(
+							"redundant cast to `%s`",
+							castTypes.TargetType.QualifiedString(),
+						),
+					)
+
+				case ast.OperationFailableCast, ast.OperationForceCast:
+					castTypes, ok := elaboration.RuntimeCastTypes[castingExpression]
+					if !ok ||
+						castTypes.Left.IsInvalidType() ||
+						!sema.IsSubType(castTypes.Left, castTypes.Right) {
+
+						return
+					}
+
+					report(
+						castingExpression,
+						fmt.Sprintf(
+							"cast of `%s` to `%s` always succeeds",
+							castTypes.Left.QualifiedString(),
+							castTypes.Right.QualifiedString(),
+						),
+					)
+				}
+			},
+		)
+
+		return nil
+	},
+}
+
+// isRedundantStaticCast returns true if the static cast of the given expression is redundant,
+// i.e. if the type is already expected by the context of the cast,
+// or if the expression already has the type and its type is not inferred from the cast
+func isRedundantStaticCast(expression ast.Expression, castTypes sema.CastTypes) bool {
+	targetType := castTypes.TargetType
+	if targetType == nil || targetType.IsInvalidType() {
+		return false
+	}
+
+	expectedType := castTypes.ExpectedType
+	if expectedType != nil &&
+		!expectedType.IsInvalidType() &&
+		expectedType.Equal(targetType) {
+
+		return true
+	}
+
+	switch expression.(type) {
+	case *ast.IntegerExpression,
+		*ast.FixedPointExpression,
+		*ast.StringExpression,
+		*ast.ArrayExpression,
+		*ast.DictionaryExpression,
+		*ast.NilExpression,
+		*ast.PathExpression:
+
+		// The type of literals is inferred from the target type
+		return false
+	}
+
+	actualType := castTypes.ExprActualType
+	return actualType != nil && actualType.Equal(targetType)
+}
@@ -0,0 +1,67 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const ShadowedVariableCategory = "shadowed-variable"
+
+// ShadowedVariableAnalyzer reports local variables, parameters, and functions
+// which shadow a local declaration of an enclosing scope
+var ShadowedVariableAnalyzer = &analysis.Analyzer{
+	Description: "Detects local declarations which shadow a declaration of an enclosing scope",
+	Requires: []*analysis.Analyzer{
+		LocalVariablesAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		variables := pass.ResultOf[LocalVariablesAnalyzer].([]*LocalVariable)
+
+		for _, variable := range variables {
+			shadowed := variable.Shadowed
+			if shadowed == nil {
+				continue
+			}
+
+			pass.Report(
+				analysis.Diagnostic{
+					Location: pass.Program.Location,
+					Range:    ast.NewUnmeteredRangeFromPositioned(variable.Identifier),
+					Category: ShadowedVariableCategory,
+					Message: fmt.Sprintf(
+						"%s `%s` shadows %s",
+						variable.DeclarationKind.Name(),
+						variable.Identifier.Identifier,
+						shadowed.DeclarationKind.Name(),
+					),
+					SecondaryMessage: fmt.Sprintf(
+						"previously declared on line %d",
+						shadowed.Identifier.Pos.Line,
+					),
+				},
+			)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,90 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const UncheckedBorrowCategory = "unchecked-borrow"
+
+// UncheckedBorrowAnalyzer reports results of `borrow` functions which are force-unwrapped
+// instead of being checked.
+//
+// The analyzer requires the program to be type-checked
+var UncheckedBorrowAnalyzer = &analysis.Analyzer{
+	Description: "Detects force-unwrapped results of borrowing from capabilities and account storage",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		elaboration := pass.Program.Elaboration
+		if elaboration == nil {
+			return nil
+		}
+
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.ForceExpression)(nil),
+			},
+			func(element ast.Element) {
+				forceExpression := element.(*ast.ForceExpression)
+
+				invocationExpression, ok := forceExpression.Expression.(*ast.InvocationExpression)
+				if !ok || !isBorrowInvocation(elaboration, invocationExpression) {
+					return
+				}
+
+				pass.Report(
+					analysis.Diagnostic{
+						Location: pass.Program.Location,
+						Range:    ast.NewUnmeteredRangeFromPositioned(forceExpression),
+						Category: UncheckedBorrowCategory,
+						Message:  "result of `borrow` is force-unwrapped",
+						SecondaryMessage: "borrowing fails if the capability is unlinked or the stored value has a different type, " +
+							"and the force-unwrap then aborts without explanation; " +
+							"check the result, e.g. with `?? panic(\"...\")` or optional binding",
+					},
+				)
+			},
+		)
+
+		return nil
+	},
+}
+
+// isBorrowInvocation returns true if the given expression is an invocation
+// of the `borrow` function of a capability or an AuthAccount
+func isBorrowInvocation(elaboration *sema.Elaboration, invocationExpression *ast.InvocationExpression) bool {
+	memberInfo, ok := invokedMemberInfo(elaboration, invocationExpression, sema.CapabilityTypeBorrowField)
+	if !ok {
+		return false
+	}
+
+	switch memberInfo.AccessedType.(type) {
+	case *sema.CapabilityType:
+		return true
+	}
+
+	return memberInfo.AccessedType == sema.AuthAccountType
+}
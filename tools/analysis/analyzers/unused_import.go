@@ -0,0 +1,192 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const UnusedImportCategory = "unused-import"
+
+// UnusedImportAnalyzer reports imported declarations which are never referred to.
+//
+// Imports which do not name the imported declarations, e.g. `import 0x1`,
+// are only analyzed if the program is type-checked,
+// and are reported if none of the imported declarations are referred to
+var UnusedImportAnalyzer = &analysis.Analyzer{
+	Description: "Detects unused imports",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+		IdentifiersAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+		identifiers := pass.ResultOf[IdentifiersAnalyzer].(Identifiers)
+
+		program := pass.Program
+		location := program.Location
+		programRange := ast.NewUnmeteredRangeFromPositioned(program.Program)
+
+		isUsed := func(identifier ast.Identifier) bool {
+			return identifiers.CountIn(identifier, programRange) > 0
+		}
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.ImportDeclaration)(nil),
+			},
+			func(element ast.Element) {
+				declaration := element.(*ast.ImportDeclaration)
+
+				if len(declaration.Identifiers) > 0 {
+
+					unusedCount := 0
+					for _, identifier := range declaration.Identifiers {
+						if !isUsed(identifier) {
+							unusedCount++
+						}
+					}
+
+					for i, identifier := range declaration.Identifiers {
+						if isUsed(identifier) {
+							continue
+						}
+
+						// If all imported declarations are unused, remove the whole import,
+						// otherwise only remove the identifier
+
+						var fix analysis.SuggestedFix
+						if unusedCount == len(declaration.Identifiers) {
+							fix = removeImportFix(program.Code, declaration)
+						} else {
+							fix = removeImportedIdentifierFix(declaration.Identifiers, i)
+						}
+
+						pass.Report(
+							analysis.Diagnostic{
+								Location: location,
+								Range:    ast.NewUnmeteredRangeFromPositioned(identifier),
+								Category: UnusedImportCategory,
+								Message: fmt.Sprintf(
+									"unused import `%s`",
+									identifier.Identifier,
+								),
+								SuggestedFixes: []analysis.SuggestedFix{fix},
+							},
+						)
+					}
+
+					return
+				}
+
+				if program.Elaboration == nil {
+					return
+				}
+
+				resolvedLocations := program.Elaboration.ImportDeclarationsResolvedLocations[declaration]
+
+				imported := false
+				for _, resolvedLocation := range resolvedLocations {
+					for _, identifier := range resolvedLocation.Identifiers {
+						imported = true
+						if isUsed(identifier) {
+							return
+						}
+					}
+				}
+
+				if !imported {
+					return
+				}
+
+				pass.Report(
+					analysis.Diagnostic{
+						Location: location,
+						Range:    ast.NewUnmeteredRangeFromPositioned(declaration),
+						Category: UnusedImportCategory,
+						Message: fmt.Sprintf(
+							"unused import of `%s`",
+							program.Code[declaration.LocationPos.Offset:declaration.EndPos.Offset+1],
+						),
+						SuggestedFixes: []analysis.SuggestedFix{
+							removeImportFix(program.Code, declaration),
+						},
+					},
+				)
+			},
+		)
+
+		return nil
+	},
+}
+
+// removeImportFix returns a fix which removes the whole import declaration,
+// including the line break following it
+func removeImportFix(code []byte, declaration *ast.ImportDeclaration) analysis.SuggestedFix {
+	removedRange := declaration.Range
+
+	nextOffset := removedRange.EndPos.Offset + 1
+	if nextOffset < len(code) && code[nextOffset] == '\n' {
+		removedRange.EndPos = removedRange.EndPos.Shifted(nil, 1)
+	}
+
+	return analysis.SuggestedFix{
+		Message: "remove import",
+		TextEdits: []ast.TextEdit{
+			{
+				Range: removedRange,
+			},
+		},
+	}
+}
+
+// removeImportedIdentifierFix returns a fix which removes the identifier at the given index
+// from the imported identifiers, including the separating comma
+func removeImportedIdentifierFix(identifiers []ast.Identifier, index int) analysis.SuggestedFix {
+	identifier := identifiers[index]
+
+	var removedRange ast.Range
+	if index < len(identifiers)-1 {
+		// Remove up to the next identifier
+		next := identifiers[index+1]
+		removedRange = ast.Range{
+			StartPos: identifier.StartPosition(),
+			EndPos:   next.StartPosition().Shifted(nil, -1),
+		}
+	} else {
+		// Remove from the end of the previous identifier
+		previous := identifiers[index-1]
+		removedRange = ast.Range{
+			StartPos: previous.EndPosition(nil).Shifted(nil, 1),
+			EndPos:   identifier.EndPosition(nil),
+		}
+	}
+
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("remove `%s` from import", identifier.Identifier),
+		TextEdits: []ast.TextEdit{
+			{
+				Range: removedRange,
+			},
+		},
+	}
+}
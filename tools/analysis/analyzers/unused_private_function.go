@@ -0,0 +1,91 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const UnusedPrivateFunctionCategory = "unused-private-function"
+
+// UnusedPrivateFunctionAnalyzer reports private functions which are never referred to.
+//
+// A private function of a composite can only be referred to in the composite,
+// and a private global function can only be referred to in the program
+var UnusedPrivateFunctionAnalyzer = &analysis.Analyzer{
+	Description: "Detects unused private functions",
+	Requires: []*analysis.Analyzer{
+		analysis.InspectorAnalyzer,
+		IdentifiersAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		inspector := pass.ResultOf[analysis.InspectorAnalyzer].(*ast.Inspector)
+		identifiers := pass.ResultOf[IdentifiersAnalyzer].(Identifiers)
+
+		program := pass.Program
+
+		checkFunctions := func(functions []*ast.FunctionDeclaration, scope ast.Range) {
+			for _, function := range functions {
+				if function.Access != ast.AccessPrivate {
+					continue
+				}
+
+				if identifiers.CountIn(function.Identifier, scope) > 0 {
+					continue
+				}
+
+				pass.Report(
+					analysis.Diagnostic{
+						Location: program.Location,
+						Range:    ast.NewUnmeteredRangeFromPositioned(function.Identifier),
+						Category: UnusedPrivateFunctionCategory,
+						Message: fmt.Sprintf(
+							"unused private function `%s`",
+							function.Identifier.Identifier,
+						),
+					},
+				)
+			}
+		}
+
+		checkFunctions(
+			program.Program.FunctionDeclarations(),
+			ast.NewUnmeteredRangeFromPositioned(program.Program),
+		)
+
+		inspector.Preorder(
+			[]ast.Element{
+				(*ast.CompositeDeclaration)(nil),
+			},
+			func(element ast.Element) {
+				declaration := element.(*ast.CompositeDeclaration)
+
+				checkFunctions(
+					declaration.Members.Functions(),
+					declaration.Range,
+				)
+			},
+		)
+
+		return nil
+	},
+}
@@ -0,0 +1,60 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analyzers
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+const UnusedVariableCategory = "unused-variable"
+
+// UnusedVariableAnalyzer reports local variables which are declared, but never referred to
+var UnusedVariableAnalyzer = &analysis.Analyzer{
+	Description: "Detects unused local variables",
+	Requires: []*analysis.Analyzer{
+		LocalVariablesAnalyzer,
+	},
+	Run: func(pass *analysis.Pass) interface{} {
+		variables := pass.ResultOf[LocalVariablesAnalyzer].([]*LocalVariable)
+
+		for _, variable := range variables {
+			if variable.Declaration == nil || len(variable.References) > 0 {
+				continue
+			}
+
+			pass.Report(
+				analysis.Diagnostic{
+					Location: pass.Program.Location,
+					Range:    ast.NewUnmeteredRangeFromPositioned(variable.Identifier),
+					Category: UnusedVariableCategory,
+					Message: fmt.Sprintf(
+						"unused %s `%s`",
+						variable.DeclarationKind.Name(),
+						variable.Identifier.Identifier,
+					),
+				},
+			)
+		}
+
+		return nil
+	},
+}
@@ -21,12 +21,16 @@ package analysis
 import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 )
 
+type SuggestedFix = errors.SuggestedFix[ast.TextEdit]
+
 type Diagnostic struct {
 	ast.Range
 	Location         common.Location
 	Category         string // optional
 	Message          string
-	SecondaryMessage string // optional
+	SecondaryMessage string         // optional
+	SuggestedFixes   []SuggestedFix // optional
 }
@@ -0,0 +1,55 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// ApplySuggestedFixes applies the text edits of the given fixes to the given code,
+// in the given order.
+//
+// A fix is skipped if any of its edits overlaps with an edit of a previously applied fix,
+// so that fixes are applied completely or not at all.
+// The returned code has all applied fixes, which are also returned.
+func ApplySuggestedFixes(code []byte, fixes []SuggestedFix) ([]byte, []SuggestedFix, error) {
+	var edits []ast.TextEdit
+	var appliedFixes []SuggestedFix
+
+	for _, fix := range fixes {
+		candidateEdits := append(edits[:len(edits):len(edits)], fix.TextEdits...)
+
+		// Check the fix can be applied together with the previous fixes
+
+		_, err := ast.ApplyTextEdits(string(code), candidateEdits)
+		if err != nil {
+			continue
+		}
+
+		edits = candidateEdits
+		appliedFixes = append(appliedFixes, fix)
+	}
+
+	result, err := ast.ApplyTextEdits(string(code), edits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []byte(result), appliedFixes, nil
+}
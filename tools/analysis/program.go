@@ -31,6 +31,8 @@ type Program struct {
 	Code        []byte
 	Program     *ast.Program
 	Elaboration *sema.Elaboration
+	// PositionInfo is only available if NeedPositionInfo is requested
+	PositionInfo *sema.PositionInfo
 }
 
 // Run runs the given DAG of analyzers in parallel
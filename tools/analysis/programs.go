@@ -61,18 +61,23 @@ func (programs Programs) load(
 	}
 
 	var elaboration *sema.Elaboration
+	var positionInfo *sema.PositionInfo
 	if config.Mode&NeedTypes != 0 {
-		elaboration, err = programs.check(config, program, location)
+		var checker *sema.Checker
+		checker, err = programs.check(config, program, location)
 		if err != nil {
 			return wrapError(err)
 		}
+		elaboration = checker.Elaboration
+		positionInfo = checker.PositionInfo
 	}
 
 	programs[location] = &Program{
-		Location:    location,
-		Code:        code,
-		Program:     program,
-		Elaboration: elaboration,
+		Location:     location,
+		Code:         code,
+		Program:      program,
+		Elaboration:  elaboration,
+		PositionInfo: positionInfo,
 	}
 
 	return nil
@@ -83,7 +88,7 @@ func (programs Programs) check(
 	program *ast.Program,
 	location common.Location,
 ) (
-	*sema.Elaboration,
+	*sema.Checker,
 	error,
 ) {
 	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
@@ -138,5 +143,5 @@ func (programs Programs) check(
 		return nil, err
 	}
 
-	return checker.Elaboration, nil
+	return checker, nil
 }
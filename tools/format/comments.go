@@ -0,0 +1,882 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// The comments recorded by the parser are added to the document of the program.
+//
+// The AST has no comments, and it is not modified.
+// Instead, the document is flattened into a list of items, i.e. texts, line breaks,
+// and the starts and ends of groups and indentations, and the comments are inserted into this list.
+// The positions of the comments are found by matching the code with the texts of the document:
+// Apart from whitespace, they mostly consist of the same characters, e.g. only semicolons are removed.
+//
+// Comments which follow code on the same line (trailing comments) are kept after that code,
+// and comments which precede code on the same line (inline comments) are kept before it.
+// Comments on their own lines are kept on their own lines, before the following code,
+// or, at the end of a block or list, after the preceding code.
+// Comments in empty blocks and lists are kept inside of them (dangling comments).
+//
+// Line comments and comments on their own lines must be separated from code by line breaks,
+// so the groups which contain the line breaks are broken into multiple lines.
+// Blank lines before statements, declarations, and comments are preserved.
+
+type comment struct {
+	ast.Range
+	text  string
+	block bool
+}
+
+func newComment(c *ast.Comment) *comment {
+	return &comment{
+		Range: c.Range,
+		text:  strings.TrimRight(c.Text, " \t\r\n"),
+		block: c.IsBlock(),
+	}
+}
+
+func (c *comment) doc() prettier.Doc {
+	lines := strings.Split(c.text, "\n")
+
+	// Re-indent the lines of block comments relative to the start of the comment
+
+	doc := prettier.Concat{
+		prettier.Text(lines[0]),
+	}
+	for _, line := range lines[1:] {
+		doc = append(
+			doc,
+			prettier.HardLine{},
+			prettier.Text(trimIndentation(line, c.StartPos.Column)),
+		)
+	}
+
+	return doc
+}
+
+// trimIndentation removes at most the given number of leading spaces and tabs
+func trimIndentation(line string, maxLength int) string {
+	i := 0
+	for i < len(line) && i < maxLength && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return line[i:]
+}
+
+type itemKind uint8
+
+const (
+	itemText itemKind = iota
+	itemLine
+	itemSoftLine
+	itemHardLine
+	itemGroup
+	itemIndent
+	itemDedent
+	itemEnd
+	itemComment
+)
+
+// item is an element of a flattened document
+type item struct {
+	kind itemKind
+	// text is the text of a text item
+	text string
+	// comment is the comment of a comment item
+	comment *comment
+	// marked is true if the comment is represented by a marker
+	marked bool
+	// start and end are the offsets of the first and last character of the code
+	// which a text or comment item consists of, or -1 if it has no characters of the code
+	start int
+	end   int
+	// broken is true if the group is broken into multiple lines
+	broken bool
+	// blankLine is true if the line break is followed by an empty line
+	blankLine bool
+}
+
+func isLineBreak(kind itemKind) bool {
+	switch kind {
+	case itemLine, itemSoftLine, itemHardLine:
+		return true
+	}
+	return false
+}
+
+// textPosition is a position in the flattened document,
+// before the character at the offset of the text of the item, or before the item
+type textPosition struct {
+	item   int
+	offset int
+}
+
+func (p textPosition) less(other textPosition) bool {
+	if p.item != other.item {
+		return p.item < other.item
+	}
+	return p.offset < other.offset
+}
+
+type insertionKind uint8
+
+const (
+	insertionTrailing insertionKind = iota
+	insertionInline
+	insertionLeading
+	insertionFollowing
+	insertionDangling
+	insertionEnclosed
+)
+
+// insertion is a comment which is inserted into the flattened document
+type insertion struct {
+	position textPosition
+	kind     insertionKind
+	comment  *comment
+}
+
+type comments struct {
+	code  []byte
+	items []item
+	// depths are the numbers of groups and indentations which contain the items
+	depths []int
+	// groups are the innermost groups which contain the items, or -1
+	groups []int
+	// characters are the offsets of the characters of the code which are not whitespace or in comments
+	characters []int
+	// textCharacters are the positions of the characters of the texts which are not whitespace
+	textCharacters []textPosition
+	// matches are the indices of the text characters that the characters of the code are matched with, or -1
+	matches []int
+	// sources are the offsets of the characters of the code that the text characters are matched with, or -1
+	sources    []int
+	insertions []insertion
+	// useMarkers is true if trailing line comments are represented by markers
+	useMarkers bool
+	// marked are the texts of the comments represented by markers, by marker index
+	marked []string
+}
+
+// addComments returns the given document of the program of the given code,
+// with the given comments of the program
+func addComments(code []byte, programComments []*ast.Comment, doc prettier.Doc) (prettier.Doc, *comments) {
+	c := &comments{
+		code:       code,
+		useMarkers: bytes.IndexByte(code, commentMarkerDelimiter) < 0,
+	}
+
+	if len(programComments) == 0 {
+		return doc, c
+	}
+
+	c.flatten(doc)
+	c.nest()
+	c.match(programComments)
+
+	for _, programComment := range programComments {
+		c.add(newComment(programComment))
+	}
+
+	items := c.insertComments()
+	c.detectBlankLines(items)
+
+	return c.doc(items), c
+}
+
+func (c *comments) flatten(doc prettier.Doc) {
+	switch doc := doc.(type) {
+	case prettier.Text:
+		c.items = append(c.items, item{kind: itemText, text: string(doc)})
+
+	case prettier.Line:
+		c.items = append(c.items, item{kind: itemLine})
+
+	case prettier.SoftLine:
+		c.items = append(c.items, item{kind: itemSoftLine})
+
+	case prettier.HardLine:
+		c.items = append(c.items, item{kind: itemHardLine})
+
+	case prettier.Concat:
+		for _, child := range doc {
+			c.flatten(child)
+		}
+
+	case prettier.Group:
+		c.items = append(c.items, item{kind: itemGroup})
+		c.flatten(doc.Doc)
+		c.items = append(c.items, item{kind: itemEnd})
+
+	case prettier.Indent:
+		c.items = append(c.items, item{kind: itemIndent})
+		c.flatten(doc.Doc)
+		c.items = append(c.items, item{kind: itemEnd})
+
+	case prettier.Dedent:
+		c.items = append(c.items, item{kind: itemDedent})
+		c.flatten(doc.Doc)
+		c.items = append(c.items, item{kind: itemEnd})
+	}
+}
+
+// nest determines the depths and the groups of the items
+func (c *comments) nest() {
+	c.depths = make([]int, len(c.items))
+	c.groups = make([]int, len(c.items))
+
+	var starts []int
+
+	innermostGroup := func() int {
+		for i := len(starts) - 1; i >= 0; i-- {
+			if c.items[starts[i]].kind == itemGroup {
+				return starts[i]
+			}
+		}
+		return -1
+	}
+
+	for i, item := range c.items {
+		c.depths[i] = len(starts)
+		c.groups[i] = innermostGroup()
+
+		switch item.kind {
+		case itemGroup, itemIndent, itemDedent:
+			starts = append(starts, i)
+
+		case itemEnd:
+			starts = starts[:len(starts)-1]
+		}
+	}
+}
+
+func isSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r':
+		return true
+	}
+	return false
+}
+
+// match matches the characters of the code with the characters of the texts
+func (c *comments) match(programComments []*ast.Comment) {
+	nextComment := 0
+	for offset := 0; offset < len(c.code); offset++ {
+		if nextComment < len(programComments) &&
+			offset == programComments[nextComment].StartPos.Offset {
+
+			offset = programComments[nextComment].EndPos.Offset
+			nextComment++
+			continue
+		}
+
+		if isSpace(c.code[offset]) {
+			continue
+		}
+
+		c.characters = append(c.characters, offset)
+	}
+
+	for i, item := range c.items {
+		if item.kind != itemText {
+			continue
+		}
+
+		for offset := 0; offset < len(item.text); offset++ {
+			if isSpace(item.text[offset]) {
+				continue
+			}
+
+			c.textCharacters = append(
+				c.textCharacters,
+				textPosition{
+					item:   i,
+					offset: offset,
+				},
+			)
+		}
+	}
+
+	c.matches = matchSequences(
+		len(c.characters),
+		len(c.textCharacters),
+		func(i, j int) bool {
+			position := c.textCharacters[j]
+			return c.code[c.characters[i]] == c.items[position.item].text[position.offset]
+		},
+	)
+
+	c.sources = make([]int, len(c.textCharacters))
+	for i := range c.sources {
+		c.sources[i] = -1
+	}
+	for i, j := range c.matches {
+		if j >= 0 {
+			c.sources[j] = c.characters[i]
+		}
+	}
+}
+
+// matchBefore returns the last matched character of the code at or before the given index, or -1
+func (c *comments) matchBefore(index int) int {
+	for ; index >= 0; index-- {
+		if c.matches[index] >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// matchAfter returns the first matched character of the code at or after the given index, or -1
+func (c *comments) matchAfter(index int) int {
+	for ; index < len(c.characters); index++ {
+		if c.matches[index] >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// before returns the position before the text character matched with the given character of the code
+func (c *comments) before(character int) textPosition {
+	return c.textCharacters[c.matches[character]]
+}
+
+// after returns the position after the text character matched with the given character of the code
+func (c *comments) after(character int) textPosition {
+	position := c.textCharacters[c.matches[character]]
+	position.offset++
+	if position.offset == len(c.items[position.item].text) {
+		return textPosition{item: position.item + 1}
+	}
+	return position
+}
+
+func hasLineBreak(code []byte) bool {
+	return bytes.IndexByte(code, '\n') >= 0
+}
+
+// hasBlankLine returns true if the given code contains an empty line
+func hasBlankLine(code []byte) bool {
+	return bytes.Count(code, []byte{'\n'}) > 1
+}
+
+// isSeparator returns true if the given code only consists of whitespace and semicolons
+func isSeparator(code []byte) bool {
+	return len(bytes.Trim(code, " \t\r\n;")) == 0
+}
+
+func isOpeningDelimiter(b byte) bool {
+	switch b {
+	case '{', '(', '[':
+		return true
+	}
+	return false
+}
+
+func isClosingDelimiter(b byte) bool {
+	switch b {
+	case '}', ')', ']':
+		return true
+	}
+	return false
+}
+
+// add determines the position of the given comment in the flattened document
+func (c *comments) add(comment *comment) {
+
+	// The characters of the code before and after the comment
+
+	next := sort.SearchInts(c.characters, comment.StartPos.Offset)
+	previous := next - 1
+
+	previousMatch := c.matchBefore(previous)
+	nextMatch := c.matchAfter(next)
+
+	trailing := previous >= 0 &&
+		!hasLineBreak(c.code[c.characters[previous]+1:comment.StartPos.Offset])
+
+	// Block comments which are followed by code on the same line precede it,
+	// unless the code closes a block or list
+	inline := next < len(c.characters) &&
+		!isClosingDelimiter(c.code[c.characters[next]]) &&
+		!hasLineBreak(c.code[comment.EndPos.Offset+1:c.characters[next]]) &&
+		(comment.block || !trailing)
+
+	if previousMatch >= 0 && nextMatch >= 0 && c.isEmptyList(previousMatch, nextMatch) {
+		kind := insertionDangling
+		if comment.block && !hasLineBreak(c.code[c.characters[previousMatch]:c.characters[nextMatch]]) {
+			kind = insertionEnclosed
+		}
+		c.insert(c.after(previousMatch), kind, comment)
+		return
+	}
+
+	if inline && nextMatch >= 0 {
+		c.insert(c.before(nextMatch), insertionInline, comment)
+		return
+	}
+
+	if trailing && previousMatch >= 0 {
+		position := c.after(previousMatch)
+		if !comment.block {
+			position = c.lineEnd(position)
+			c.breakLineAt(position)
+		}
+		c.insert(position, insertionTrailing, comment)
+		return
+	}
+
+	if nextMatch >= 0 && !isClosingDelimiter(c.code[c.characters[nextMatch]]) {
+		position := c.lineStart(c.before(nextMatch))
+
+		// The comment must not be moved before the preceding code
+		if previousMatch < 0 || c.before(previousMatch).less(position) {
+			c.breakLineBefore(position)
+			c.insert(position, insertionLeading, comment)
+			return
+		}
+	}
+
+	if previousMatch >= 0 {
+		position := c.lineEnd(c.after(previousMatch))
+
+		// The comment belongs to the blocks and lists which start at the end of the line
+		for position.item < len(c.items) {
+			kind := c.items[position.item].kind
+			if kind != itemGroup && kind != itemIndent && kind != itemDedent {
+				break
+			}
+			position.item++
+		}
+
+		c.breakLineAt(position)
+		c.insert(position, insertionFollowing, comment)
+		return
+	}
+
+	c.insert(textPosition{}, insertionLeading, comment)
+}
+
+func (c *comments) insert(position textPosition, kind insertionKind, comment *comment) {
+	c.insertions = append(
+		c.insertions,
+		insertion{
+			position: position,
+			kind:     kind,
+			comment:  comment,
+		},
+	)
+}
+
+// isEmptyList returns true if the given characters of the code are the delimiters of an empty block or list
+func (c *comments) isEmptyList(previous, next int) bool {
+	if !isOpeningDelimiter(c.code[c.characters[previous]]) ||
+		!isClosingDelimiter(c.code[c.characters[next]]) ||
+		c.matches[next] != c.matches[previous]+1 {
+
+		return false
+	}
+
+	for i := c.before(previous).item + 1; i < c.before(next).item; i++ {
+		if isLineBreak(c.items[i].kind) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// previousLineBreak returns the index of the last line break before the item with the given index, or -1
+func (c *comments) previousLineBreak(index int) int {
+	for i := index - 1; i >= 0; i-- {
+		if isLineBreak(c.items[i].kind) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lineStart returns the position at the start of the line of the given position
+func (c *comments) lineStart(position textPosition) textPosition {
+	return textPosition{
+		item: c.previousLineBreak(position.item) + 1,
+	}
+}
+
+// lineEnd returns the position at the end of the line of the given position,
+// i.e. after the code before the next line break,
+// in the groups and indentations which contain the start of the line
+func (c *comments) lineEnd(position textPosition) textPosition {
+	depth := 0
+	if lineBreak := c.previousLineBreak(position.item); lineBreak >= 0 {
+		depth = c.depths[lineBreak]
+	}
+
+	if position.offset > 0 {
+		position = textPosition{item: position.item + 1}
+	}
+
+	for i := position.item; i < len(c.items) && !isLineBreak(c.items[i].kind); i++ {
+		item := c.items[i]
+		if item.kind == itemText && strings.TrimSpace(item.text) != "" {
+			position = textPosition{item: i + 1}
+		}
+	}
+
+	for position.item < len(c.items) &&
+		c.items[position.item].kind == itemEnd &&
+		c.depths[position.item] > depth {
+
+		position.item++
+	}
+
+	return position
+}
+
+// breakLineBefore ensures the line break before the given position is not replaced by a space
+func (c *comments) breakLineBefore(position textPosition) {
+	if position.item > 0 {
+		c.breakLine(position.item - 1)
+	}
+}
+
+// breakLineAt ensures the next line break at or after the given position is not replaced by a space
+func (c *comments) breakLineAt(position textPosition) {
+	for i := position.item; i < len(c.items); i++ {
+		if isLineBreak(c.items[i].kind) {
+			c.breakLine(i)
+			return
+		}
+	}
+}
+
+// breakLine breaks the group which contains the line break with the given index into multiple lines
+func (c *comments) breakLine(index int) {
+	switch c.items[index].kind {
+	case itemLine, itemSoftLine:
+		if group := c.groups[index]; group >= 0 {
+			c.items[group].broken = true
+		}
+	}
+}
+
+// insertComments returns the items of the flattened document with the comments
+func (c *comments) insertComments() []item {
+	sort.SliceStable(c.insertions, func(i, j int) bool {
+		return c.insertions[i].position.less(c.insertions[j].position)
+	})
+
+	result := make([]item, 0, len(c.items)+len(c.insertions)*2)
+
+	nextInsertion := 0
+	addInsertions := func(position textPosition) {
+		for nextInsertion < len(c.insertions) &&
+			c.insertions[nextInsertion].position == position {
+
+			insertion := c.insertions[nextInsertion]
+			nextInsertion++
+
+			commentItem := item{
+				kind:    itemComment,
+				comment: insertion.comment,
+				start:   insertion.comment.StartPos.Offset,
+				end:     insertion.comment.EndPos.Offset,
+			}
+
+			switch insertion.kind {
+			case insertionTrailing:
+				commentItem.marked = c.useMarkers && !insertion.comment.block
+				result = append(result, item{kind: itemText, text: " "}, commentItem)
+
+			case insertionInline:
+				result = append(result, commentItem, item{kind: itemText, text: " "})
+
+			case insertionLeading:
+				result = append(result, commentItem, item{kind: itemHardLine})
+
+			case insertionFollowing:
+				result = append(result, item{kind: itemHardLine}, commentItem)
+
+			case insertionEnclosed:
+				result = append(result, commentItem)
+
+			case insertionDangling:
+				result = append(result, item{kind: itemIndent}, item{kind: itemHardLine}, commentItem)
+
+				// Print all dangling comments of the block or list in one indentation
+				for nextInsertion < len(c.insertions) &&
+					c.insertions[nextInsertion].position == position &&
+					c.insertions[nextInsertion].kind == insertionDangling {
+
+					insertion = c.insertions[nextInsertion]
+					nextInsertion++
+
+					result = append(
+						result,
+						item{kind: itemHardLine},
+						item{
+							kind:    itemComment,
+							comment: insertion.comment,
+							start:   insertion.comment.StartPos.Offset,
+							end:     insertion.comment.EndPos.Offset,
+						},
+					)
+				}
+
+				result = append(result, item{kind: itemEnd}, item{kind: itemHardLine})
+			}
+		}
+	}
+
+	nextCharacter := 0
+
+	for i, current := range c.items {
+		if current.kind != itemText {
+			addInsertions(textPosition{item: i})
+			result = append(result, current)
+			continue
+		}
+
+		// Split the text at the positions of the insertions
+
+		start := 0
+		for {
+			end := len(current.text)
+			if nextInsertion < len(c.insertions) &&
+				c.insertions[nextInsertion].position.item == i {
+
+				end = c.insertions[nextInsertion].position.offset
+			}
+
+			if end > start || (end == len(current.text) && start == 0) {
+				text := item{
+					kind:  itemText,
+					text:  current.text[start:end],
+					start: -1,
+					end:   -1,
+				}
+
+				for ; nextCharacter < len(c.textCharacters); nextCharacter++ {
+					position := c.textCharacters[nextCharacter]
+					if position.item != i || position.offset >= end {
+						break
+					}
+
+					source := c.sources[nextCharacter]
+					if source < 0 {
+						continue
+					}
+					if text.start < 0 {
+						text.start = source
+					}
+					text.end = source
+				}
+
+				result = append(result, text)
+			}
+
+			if end == len(current.text) {
+				break
+			}
+
+			addInsertions(textPosition{item: i, offset: end})
+			start = end
+		}
+	}
+
+	addInsertions(textPosition{item: len(c.items)})
+
+	return result
+}
+
+// detectBlankLines determines the line breaks which are followed by an empty line,
+// i.e. single line breaks between statements, declarations, and comments
+// which were separated by an empty line in the code
+func (c *comments) detectBlankLines(items []item) {
+	previousEnd := -1
+	lineBreaks := 0
+	lineBreak := -1
+
+	for i, current := range items {
+		switch current.kind {
+		case itemHardLine:
+			lineBreaks++
+			lineBreak = i
+
+		case itemLine, itemSoftLine:
+			lineBreaks = -1
+
+		case itemText, itemComment:
+			if current.kind == itemText && strings.TrimSpace(current.text) == "" {
+				continue
+			}
+
+			if current.start >= 0 &&
+				lineBreaks == 1 &&
+				previousEnd >= 0 &&
+				previousEnd < current.start &&
+				!isOpeningDelimiter(c.code[previousEnd]) &&
+				!isClosingDelimiter(c.code[current.start]) &&
+				isSeparator(c.code[previousEnd+1:current.start]) &&
+				hasBlankLine(c.code[previousEnd+1:current.start]) {
+
+				items[lineBreak].blankLine = true
+			}
+
+			lineBreaks = 0
+			if current.end >= 0 {
+				previousEnd = current.end
+			}
+		}
+	}
+}
+
+// doc returns the document for the given flattened document
+func (c *comments) doc(items []item) prettier.Doc {
+
+	type frame struct {
+		item
+		doc prettier.Concat
+	}
+
+	stack := []*frame{{}}
+
+	for _, current := range items {
+		top := stack[len(stack)-1]
+
+		switch current.kind {
+		case itemText:
+			top.doc = append(top.doc, prettier.Text(current.text))
+
+		case itemLine:
+			if top.broken {
+				top.doc = append(top.doc, prettier.HardLine{})
+			} else {
+				top.doc = append(top.doc, prettier.Line{})
+			}
+
+		case itemSoftLine:
+			if top.broken {
+				top.doc = append(top.doc, prettier.HardLine{})
+			} else {
+				top.doc = append(top.doc, prettier.SoftLine{})
+			}
+
+		case itemHardLine:
+			top.doc = append(top.doc, prettier.HardLine{})
+			if current.blankLine {
+				top.doc = append(top.doc, prettier.HardLine{})
+			}
+
+		case itemComment:
+			top.doc = append(top.doc, c.commentDoc(current))
+
+		case itemGroup:
+			stack = append(stack, &frame{item: current})
+
+		case itemIndent, itemDedent:
+			// The lines of a broken group are broken, including the indented lines
+			nested := &frame{item: current}
+			nested.broken = top.broken
+			stack = append(stack, nested)
+
+		case itemEnd:
+			stack = stack[:len(stack)-1]
+			parent := stack[len(stack)-1]
+
+			var doc prettier.Doc
+			switch top.kind {
+			case itemGroup:
+				// A broken group is not flattened, its lines are already broken
+				if top.broken {
+					doc = top.doc
+				} else {
+					doc = prettier.Group{Doc: top.doc}
+				}
+
+			case itemIndent:
+				doc = prettier.Indent{Doc: top.doc}
+
+			case itemDedent:
+				doc = prettier.Dedent{Doc: top.doc}
+			}
+
+			parent.doc = append(parent.doc, doc)
+		}
+	}
+
+	return stack[0].doc
+}
+
+// Trailing line comments should not cause the code before them to be broken into multiple lines,
+// so they are represented by short markers in the document, which are replaced after the code was laid out
+
+const commentMarkerDelimiter = '\x00'
+
+func (c *comments) commentDoc(item item) prettier.Doc {
+	if !item.marked {
+		return item.comment.doc()
+	}
+
+	index := len(c.marked)
+	c.marked = append(c.marked, item.comment.text)
+
+	return prettier.Text(
+		fmt.Sprintf(
+			"%c%d%c",
+			commentMarkerDelimiter,
+			index,
+			commentMarkerDelimiter,
+		),
+	)
+}
+
+// insertMarkedComments replaces the comment markers in the given code with the comments
+func (c *comments) insertMarkedComments(code string) string {
+	if len(c.marked) == 0 {
+		return code
+	}
+
+	replacements := make([]string, 0, len(c.marked)*2)
+	for index, text := range c.marked {
+		replacements = append(
+			replacements,
+			fmt.Sprintf(
+				"%c%d%c",
+				commentMarkerDelimiter,
+				index,
+				commentMarkerDelimiter,
+			),
+			text,
+		)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(code)
+}
@@ -0,0 +1,86 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package format implements the standard formatting of Cadence source code.
+package format
+
+import (
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// Config controls the formatting of source code
+type Config struct {
+	// MaxLineWidth is the maximum width of lines, lines are broken where possible to not exceed it
+	MaxLineWidth int
+	// Indent is the string used to indent nested code
+	Indent string
+}
+
+var DefaultConfig = Config{
+	MaxLineWidth: 80,
+	Indent:       "    ",
+}
+
+// Source formats the given source code using the default configuration.
+//
+// Comments are preserved. Formatting is idempotent,
+// i.e. formatting already formatted code does not change it.
+// An error is returned if the code cannot be parsed.
+func Source(code []byte) ([]byte, error) {
+	return DefaultConfig.Source(code)
+}
+
+// Source formats the given source code using the configuration
+func (c Config) Source(code []byte) ([]byte, error) {
+	program, err := parser.ParseProgramWithConfig(
+		code,
+		nil,
+		parser.Config{Comments: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, comments := addComments(code, program.Comments(), programDoc(program))
+
+	var builder strings.Builder
+	prettier.Prettier(&builder, doc, c.MaxLineWidth, c.Indent)
+
+	formatted := comments.insertMarkedComments(builder.String())
+
+	return []byte(normalizeLines(formatted)), nil
+}
+
+// normalizeLines removes trailing whitespace from all lines,
+// and ensures the code ends with exactly one line break, unless it is empty
+func normalizeLines(code string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	result := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if result == "" {
+		return result
+	}
+	return result + "\n"
+}
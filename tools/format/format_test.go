@@ -0,0 +1,567 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/tools/format"
+)
+
+func testFormat(t *testing.T, config format.Config, code string, expected string) {
+	formatted, err := config.Source([]byte(code))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(formatted))
+
+	// Formatting is idempotent
+
+	reformatted, err := config.Source(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(reformatted))
+}
+
+func TestSource(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, code string, expected string) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testFormat(t, format.DefaultConfig, code, expected)
+		})
+	}
+
+	test(
+		"declarations",
+		`
+          import Foo from 0x1
+          pub contract C {
+              pub let x: Int
+              init() { self.x = 1 }
+              pub fun foo(a: Int): Int { return a }
+          }
+        `,
+		`import Foo from 0x1
+
+pub contract C {
+    pub let x: Int
+
+    init() {
+        self.x = 1
+    }
+
+    pub fun foo(a: Int): Int {
+        return a
+    }
+}
+`,
+	)
+
+	test(
+		"statements",
+		`
+          fun test(){
+            let x=1 ; var y : Int?=nil
+            if x==1{y=2}else{y=3}
+            while true { break }
+          }
+        `,
+		`fun test() {
+    let x = 1
+    var y: Int? = nil
+    if x == 1 {
+        y = 2
+    } else {
+        y = 3
+    }
+    while true {
+        break
+    }
+}
+`,
+	)
+
+	test(
+		"function without body",
+		`
+          pub resource interface R {
+              pub fun foo(): Int
+          }
+        `,
+		`pub resource interface R {
+    pub fun foo(): Int
+}
+`,
+	)
+
+	test(
+		"integer member",
+		`let x = (1).toString()`,
+		"let x = (1).toString()\n",
+	)
+
+	test(
+		"empty",
+		" \n\n ",
+		"",
+	)
+
+	test(
+		"only comments",
+		`
+          // first
+
+          /* second */
+        `,
+		`// first
+
+/* second */
+`,
+	)
+
+	test(
+		"leading and trailing comments",
+		`
+          // License
+
+          /// Docs
+          pub fun test(): Int { // after brace
+              // leading
+              let x = 1 // trailing
+              /* inline */ let y = 2 /* block */ // line
+              return x + y
+              // end of block
+          }
+          // end of file
+        `,
+		`// License
+
+/// Docs
+pub fun test(): Int { // after brace
+    // leading
+    let x = 1 // trailing
+    /* inline */ let y = 2 /* block */ // line
+    return x + y
+    // end of block
+}
+// end of file
+`,
+	)
+
+	test(
+		"comments in expressions",
+		`
+          fun test() {
+              foo(
+                  1, // one
+                  2
+              )
+          }
+        `,
+		`fun test() {
+    foo(
+        1, // one
+        2
+    )
+}
+`,
+	)
+
+	test(
+		"inline comments",
+		`
+          fun test(/* a */ a: Int, b: Int /* b */) {
+              let y = /* inline */ 2
+              foo(1, /* two */ 2)
+              bar(/* none */)
+          }
+        `,
+		`fun test(/* a */ a: Int, b: Int /* b */) {
+    let y = /* inline */ 2
+    foo(1, /* two */ 2)
+    bar(/* none */)
+}
+`,
+	)
+
+	test(
+		"trailing comments",
+		`
+          fun test() {
+              foo(1, 2) // call
+              let xs = [
+                  1, // one
+                  2  // two
+              ]
+          }
+        `,
+		`fun test() {
+    foo(1, 2) // call
+    let xs = [
+        1, // one
+        2 // two
+    ]
+}
+`,
+	)
+
+	test(
+		"comments in empty block",
+		`
+          pub fun main() {
+              // only comment
+          }
+        `,
+		`pub fun main() {
+    // only comment
+}
+`,
+	)
+
+	test(
+		"comments in empty members",
+		`
+          pub struct S {
+              // first
+
+              /* second */
+          }
+          pub resource interface R {
+              // only comment
+          }
+        `,
+		`pub struct S {
+    // first
+
+    /* second */
+}
+
+pub resource interface R {
+    // only comment
+}
+`,
+	)
+
+	test(
+		"comments in parameters",
+		`
+          fun foo(
+              // leading
+              a: Int, // first
+              /* inline */ b: Int // second
+              // after
+          ) {}
+        `,
+		`fun foo(
+    // leading
+    a: Int, // first
+    /* inline */ b: Int // second
+    // after
+) {}
+`,
+	)
+
+	test(
+		"comments in array literal",
+		`
+          fun test() {
+              let xs = [
+                  1, // one
+                  // two
+                  2
+              ]
+              xs = [3, 4, // four
+                  5]
+          }
+        `,
+		`fun test() {
+    let xs = [
+        1, // one
+        // two
+        2
+    ]
+    xs = [
+        3,
+        4, // four
+        5
+    ]
+}
+`,
+	)
+
+	test(
+		"members",
+		`
+          pub struct S {
+              // leading
+              pub let x: Int // trailing
+
+
+              /// Docs
+              pub let y: Int
+          }
+        `,
+		`pub struct S {
+    // leading
+    pub let x: Int // trailing
+
+    /// Docs
+    pub let y: Int
+}
+`,
+	)
+
+	test(
+		"blank lines",
+		`
+          fun test() {
+              let x = 1
+
+
+              let y = 2
+
+              // comment
+
+              let z = 3
+          }
+        `,
+		`fun test() {
+    let x = 1
+
+    let y = 2
+
+    // comment
+
+    let z = 3
+}
+`,
+	)
+
+	test(
+		"block comment",
+		`
+          fun test() {
+                  /*
+                   * multi-line
+                   *   indented
+                   */
+                  let x = 1
+          }
+        `,
+		`fun test() {
+    /*
+     * multi-line
+     *   indented
+     */
+    let x = 1
+}
+`,
+	)
+
+	test(
+		"switch",
+		`
+          fun test(x: Int) {
+              switch x {
+              case 1:
+                  // one
+                  return
+              default:
+                  return // default
+              }
+          }
+        `,
+		`fun test(x: Int) {
+    switch x {
+        case 1:
+            // one
+            return
+        default:
+            return // default
+    }
+}
+`,
+	)
+
+	test(
+		"else-if",
+		`
+          fun test(x: Int) {
+              if x == 1 {
+                  // one
+                  return
+              } else if x == 2 {
+                  return // two
+              }
+          }
+        `,
+		`fun test(x: Int) {
+    if x == 1 {
+        // one
+        return
+    } else if x == 2 {
+        return // two
+    }
+}
+`,
+	)
+
+	test(
+		"type aliases",
+		`
+          /// Counts
+          pub typealias Counts={String:[Int]}
+          contract C {
+              pub typealias R=Capability<&{FungibleToken.Receiver, FungibleToken.Balance}>
+          }
+        `,
+		`/// Counts
+pub typealias Counts = {String: [Int]}
+
+contract C {
+    pub typealias R =
+        Capability<&{FungibleToken.Receiver, FungibleToken.Balance}>
+}
+`,
+	)
+
+	test(
+		"generic declarations",
+		`
+          fun identity<T>(_ value:T):T { return value }
+          resource Collection< T : @AnyResource >{
+              var items: @[T]
+              init() { self.items <- [] }
+              destroy() { destroy self.items }
+          }
+          fun makeCollection<T:@AnyResource>():@Collection<@T> { return <-create Collection<@T>() }
+        `,
+		`fun identity<T>(_ value: T): T {
+    return value
+}
+
+resource Collection<T: @AnyResource> {
+    var items: @[T]
+
+    init() {
+        self.items <- []
+    }
+
+    destroy() {
+        destroy self.items
+    }
+}
+
+fun makeCollection<T: @AnyResource>(): @Collection<@T> {
+    return <-create Collection<@T>()
+}
+`,
+	)
+
+	test(
+		"attachments",
+		`
+          attachment A for R { fun foo(): Int { return base.bar } }
+          fun test(r: @R): @R {
+              let r2 <- attach A() to <-r
+              let a = r2[A]
+              remove A from r2
+              return <-r2
+          }
+        `,
+		`attachment A for R {
+    fun foo(): Int {
+        return base.bar
+    }
+}
+
+fun test(r: @R): @R {
+    let r2 <- attach A() to <-r
+    let a = r2[A]
+    remove A from r2
+    return <-r2
+}
+`,
+	)
+
+	test(
+		"string templates",
+		`
+          fun greet(name:String,count:Int):String {
+              return "Hello, \( name )! \(count+1)\t\("\(name)")"
+          }
+        `,
+		`fun greet(name: String, count: Int): String {
+    return "Hello, \(name)! \(count + 1)\t\("\(name)")"
+}
+`,
+	)
+}
+
+func TestSourceMaxLineWidth(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun test() {
+          let x = foo(first: 1, second: 2) // a trailing comment does not cause breaks
+          let y = [1, 2, 3]
+      }
+    `
+
+	testFormat(
+		t,
+		format.DefaultConfig,
+		code,
+		`fun test() {
+    let x = foo(first: 1, second: 2) // a trailing comment does not cause breaks
+    let y = [1, 2, 3]
+}
+`,
+	)
+
+	testFormat(
+		t,
+		format.Config{
+			MaxLineWidth: 20,
+			Indent:       "  ",
+		},
+		code,
+		`fun test() {
+  let x = foo(
+    first: 1,
+    second: 2
+  ) // a trailing comment does not cause breaks
+  let y = [1, 2, 3]
+}
+`,
+	)
+}
+
+func TestSourceInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := format.Source([]byte(`fun test( {`))
+	require.Error(t, err)
+}
@@ -0,0 +1,354 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"reflect"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// The layout of some statements and declarations differs from the documents of the AST:
+// Values are kept on the same line as assignments and variable declarations if they break inside their delimiters,
+// the messages of conditions are kept on the same line as their tests if they fit,
+// and function declarations without a block are printed without one.
+//
+// The AST is not modified. Instead, the documents of these elements are replaced in the document of the program.
+// The documents are found by comparing them with the documents of the elements,
+// which are in the same order in the document of the program as the elements are in the program.
+
+var functionEmptyBlockDoc prettier.Doc = prettier.Text(" {}")
+
+var typeSeparatorSpaceDoc prettier.Doc = prettier.Text(": ")
+
+// layoutElement is an element of the program whose layout may differ from its document
+type layoutElement struct {
+	// doc is the document of the element
+	doc prettier.Doc
+	// layout is the document with the layout of the formatter, or nil if it is the same
+	layout prettier.Doc
+	// replaced is true if the document of the element was found in the document of the program
+	replaced bool
+}
+
+type layouter struct {
+	// elements are the elements of the program, by the first text of their documents
+	elements map[prettier.Text][]*layoutElement
+}
+
+// programDoc returns the document for the given program, with the layout of the formatter
+func programDoc(program *ast.Program) prettier.Doc {
+	l := &layouter{
+		elements: map[prettier.Text][]*layoutElement{},
+	}
+
+	for _, declaration := range program.Declarations() {
+		l.visit(declaration)
+	}
+
+	return l.replace(program.Doc())
+}
+
+// visit adds the elements with a layout in the given element.
+//
+// All elements of a kind are added, even if their layout does not differ from their document,
+// so that elements with equal documents are found in the right order
+func (l *layouter) visit(element ast.Element) {
+	if element == nil {
+		return
+	}
+
+	switch element := element.(type) {
+	case *ast.AssignmentStatement:
+		l.add(element.Doc(), assignmentStatementDoc(element))
+
+	case *ast.VariableDeclaration:
+		l.add(element.Doc(), variableDeclarationDoc(element))
+
+	case *ast.FunctionDeclaration:
+		doc := element.Doc()
+		l.add(doc, functionDeclarationDoc(doc, element.FunctionBlock))
+
+	case *ast.SpecialFunctionDeclaration:
+		doc := element.Doc()
+		l.add(doc, functionDeclarationDoc(doc, element.FunctionDeclaration.FunctionBlock))
+
+	case *ast.FunctionBlock:
+		l.visitConditions(element.PreConditions)
+		l.visitConditions(element.PostConditions)
+
+	case *ast.TransactionDeclaration:
+		l.visitConditions(element.PreConditions)
+		l.visitConditions(element.PostConditions)
+
+	case *ast.CompositeDeclaration:
+		// The members of events are not printed, only the parameters of their initializer
+		if element.CompositeKind == common.CompositeKindEvent {
+			return
+		}
+	}
+
+	element.Walk(l.visit)
+}
+
+func (l *layouter) visitConditions(conditions *ast.Conditions) {
+	if conditions == nil {
+		return
+	}
+
+	for _, condition := range *conditions {
+		l.add(condition.Doc(), conditionDoc(condition))
+	}
+}
+
+func (l *layouter) add(doc prettier.Doc, layout prettier.Doc) {
+	text, ok := firstText(doc)
+	if !ok {
+		return
+	}
+
+	l.elements[text] = append(
+		l.elements[text],
+		&layoutElement{
+			doc:    doc,
+			layout: layout,
+		},
+	)
+}
+
+// replace returns the given document,
+// in which the documents of the elements are replaced with their layout
+func (l *layouter) replace(doc prettier.Doc) prettier.Doc {
+	if element := l.find(doc); element != nil && element.layout != nil {
+		doc = element.layout
+	}
+
+	switch doc := doc.(type) {
+	case prettier.Concat:
+		result := make(prettier.Concat, len(doc))
+		for i, child := range doc {
+			result[i] = l.replace(child)
+		}
+		return result
+
+	case prettier.Group:
+		return prettier.Group{
+			Doc: l.replace(doc.Doc),
+		}
+
+	case prettier.Indent:
+		return prettier.Indent{
+			Doc: l.replace(doc.Doc),
+		}
+
+	case prettier.Dedent:
+		return prettier.Dedent{
+			Doc: l.replace(doc.Doc),
+		}
+	}
+
+	return doc
+}
+
+// find returns the next element which has the given document, if any
+func (l *layouter) find(doc prettier.Doc) *layoutElement {
+	switch doc.(type) {
+	case prettier.Concat, prettier.Group:
+		break
+	default:
+		// The documents of the elements are always concatenations or groups
+		return nil
+	}
+
+	text, ok := firstText(doc)
+	if !ok {
+		return nil
+	}
+
+	for _, element := range l.elements[text] {
+		if element.replaced || !reflect.DeepEqual(element.doc, doc) {
+			continue
+		}
+
+		element.replaced = true
+		return element
+	}
+
+	return nil
+}
+
+// firstText returns the first non-empty text of the given document
+func firstText(doc prettier.Doc) (prettier.Text, bool) {
+	switch doc := doc.(type) {
+	case prettier.Text:
+		return doc, doc != ""
+
+	case prettier.Concat:
+		for _, child := range doc {
+			if text, ok := firstText(child); ok {
+				return text, true
+			}
+		}
+
+	case prettier.Group:
+		return firstText(doc.Doc)
+
+	case prettier.Indent:
+		return firstText(doc.Doc)
+
+	case prettier.Dedent:
+		return firstText(doc.Doc)
+	}
+
+	return "", false
+}
+
+// functionDeclarationDoc returns the given document of a function declaration without the empty block,
+// if the function declaration has no block, e.g. a function requirement in an interface,
+// as it is different from a function declaration with an empty block
+func functionDeclarationDoc(doc prettier.Doc, functionBlock *ast.FunctionBlock) prettier.Doc {
+	if functionBlock != nil {
+		return nil
+	}
+
+	concat, ok := doc.(prettier.Concat)
+	if !ok || len(concat) == 0 || concat[len(concat)-1] != functionEmptyBlockDoc {
+		return nil
+	}
+
+	return concat[:len(concat)-1]
+}
+
+func assignmentStatementDoc(statement *ast.AssignmentStatement) prettier.Doc {
+	return prettier.Group{
+		Doc: prettier.Concat{
+			statement.Target.Doc(),
+			prettier.Space,
+			statement.Transfer.Doc(),
+			prettier.Space,
+			prettier.Group{
+				Doc: statement.Value.Doc(),
+			},
+		},
+	}
+}
+
+func variableDeclarationDoc(declaration *ast.VariableDeclaration) prettier.Doc {
+	if declaration.SecondValue != nil || !isDelimitedExpression(declaration.Value) {
+		return nil
+	}
+
+	keywordDoc := prettier.Text("var")
+	if declaration.IsConstant {
+		keywordDoc = prettier.Text("let")
+	}
+
+	identifierTypeDoc := prettier.Concat{
+		prettier.Text(declaration.Identifier.Identifier),
+	}
+
+	if declaration.TypeAnnotation != nil {
+		identifierTypeDoc = append(
+			identifierTypeDoc,
+			typeSeparatorSpaceDoc,
+			declaration.TypeAnnotation.Doc(),
+		)
+	}
+
+	var doc prettier.Concat
+
+	if declaration.Access != ast.AccessNotSpecified {
+		doc = append(
+			doc,
+			prettier.Text(declaration.Access.Keyword()),
+			prettier.Space,
+		)
+	}
+
+	// The value breaks inside its delimiters,
+	// so keep it on the same line as the transfer
+
+	doc = append(
+		doc,
+		keywordDoc,
+		prettier.Space,
+		prettier.Group{
+			Doc: prettier.Concat{
+				prettier.Group{
+					Doc: identifierTypeDoc,
+				},
+				prettier.Space,
+				declaration.Transfer.Doc(),
+				prettier.Space,
+				prettier.Group{
+					Doc: declaration.Value.Doc(),
+				},
+			},
+		},
+	)
+
+	return prettier.Group{
+		Doc: doc,
+	}
+}
+
+// isDelimitedExpression returns true if the given expression is printed
+// with delimiters it can be broken within, e.g. the brackets of an array
+func isDelimitedExpression(expression ast.Expression) bool {
+	switch expression := expression.(type) {
+	case *ast.ArrayExpression,
+		*ast.DictionaryExpression,
+		*ast.FunctionExpression:
+
+		return true
+
+	case *ast.InvocationExpression:
+		_, ok := expression.InvokedExpression.(*ast.IdentifierExpression)
+		return ok
+
+	case *ast.CreateExpression:
+		return isDelimitedExpression(expression.InvocationExpression)
+	}
+
+	return false
+}
+
+// conditionDoc returns the document for the given condition,
+// in which the message is only put on a separate line if the condition does not fit on one line
+func conditionDoc(condition *ast.Condition) prettier.Doc {
+	if condition.Message == nil {
+		return nil
+	}
+
+	return prettier.Group{
+		Doc: prettier.Concat{
+			condition.Test.Doc(),
+			prettier.Text(":"),
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					condition.Message.Doc(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// matchSequences determines the longest common subsequence of two sequences,
+// with the given lengths, whose elements are compared using the given function.
+//
+// The result maps the indices of the first sequence to the indices of the matching elements
+// in the second sequence, or to -1 if an element has no match.
+//
+// The sequences are matched using the linear space variant of the algorithm described in
+// "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers,
+// which is fast if the sequences only differ in few places
+func matchSequences(length1, length2 int, equal func(index1, index2 int) bool) []int {
+	matches := make([]int, length1)
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	size := length1 + length2 + 4
+
+	m := &matcher{
+		equal:    equal,
+		matches:  matches,
+		forward:  make([]int, size),
+		backward: make([]int, size),
+	}
+	m.match(0, length1, 0, length2)
+
+	return matches
+}
+
+type matcher struct {
+	equal   func(index1, index2 int) bool
+	matches []int
+	// forward and backward are the furthest reaching paths, by diagonal
+	forward  []int
+	backward []int
+}
+
+// match matches the elements of the given ranges of the two sequences
+func (m *matcher) match(start1, end1, start2, end2 int) {
+
+	// Match the common prefix and suffix
+
+	for start1 < end1 && start2 < end2 && m.equal(start1, start2) {
+		m.matches[start1] = start2
+		start1++
+		start2++
+	}
+
+	for start1 < end1 && start2 < end2 && m.equal(end1-1, end2-1) {
+		end1--
+		end2--
+		m.matches[end1] = end2
+	}
+
+	if start1 == end1 || start2 == end2 {
+		return
+	}
+
+	// Split the ranges at the middle snake of a shortest edit script,
+	// and match the parts before and after it
+
+	snakeStart1, snakeStart2, snakeEnd1, snakeEnd2 := m.middleSnake(start1, end1, start2, end2)
+
+	m.match(start1, snakeStart1, start2, snakeStart2)
+
+	for i, j := snakeStart1, snakeStart2; i < snakeEnd1; i, j = i+1, j+1 {
+		m.matches[i] = j
+	}
+
+	m.match(snakeEnd1, end1, snakeEnd2, end2)
+}
+
+// middleSnake returns the start and end of the middle snake of a shortest edit script
+// for the given ranges of the two sequences, which must differ
+func (m *matcher) middleSnake(start1, end1, start2, end2 int) (int, int, int, int) {
+	length1 := end1 - start1
+	length2 := end2 - start2
+
+	delta := length1 - length2
+	odd := delta%2 != 0
+
+	maxEdits := (length1 + length2 + 1) / 2
+	offset := maxEdits + 1
+
+	forward := m.forward
+	backward := m.backward
+
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+
+	for edits := 0; edits <= maxEdits; edits++ {
+
+		// Extend the furthest reaching paths from the start
+
+		for diagonal := -edits; diagonal <= edits; diagonal += 2 {
+			var x int
+			if diagonal == -edits ||
+				(diagonal != edits && forward[offset+diagonal-1] < forward[offset+diagonal+1]) {
+
+				x = forward[offset+diagonal+1]
+			} else {
+				x = forward[offset+diagonal-1] + 1
+			}
+			y := x - diagonal
+
+			snakeX, snakeY := x, y
+			for x < length1 && y < length2 && m.equal(start1+x, start2+y) {
+				x++
+				y++
+			}
+			forward[offset+diagonal] = x
+
+			reverseDiagonal := delta - diagonal
+			if odd &&
+				reverseDiagonal >= -(edits-1) &&
+				reverseDiagonal <= edits-1 &&
+				x+backward[offset+reverseDiagonal] >= length1 {
+
+				return start1 + snakeX, start2 + snakeY, start1 + x, start2 + y
+			}
+		}
+
+		// Extend the furthest reaching paths from the end
+
+		for diagonal := -edits; diagonal <= edits; diagonal += 2 {
+			var x int
+			if diagonal == -edits ||
+				(diagonal != edits && backward[offset+diagonal-1] < backward[offset+diagonal+1]) {
+
+				x = backward[offset+diagonal+1]
+			} else {
+				x = backward[offset+diagonal-1] + 1
+			}
+			y := x - diagonal
+
+			snakeX, snakeY := x, y
+			for x < length1 && y < length2 && m.equal(end1-1-x, end2-1-y) {
+				x++
+				y++
+			}
+			backward[offset+diagonal] = x
+
+			forwardDiagonal := delta - diagonal
+			if !odd &&
+				forwardDiagonal >= -edits &&
+				forwardDiagonal <= edits &&
+				x+forward[offset+forwardDiagonal] >= length1 {
+
+				return end1 - x, end2 - y, end1 - snakeX, end2 - snakeY
+			}
+		}
+	}
+
+	panic(errors.NewUnreachableError())
+}
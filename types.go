@@ -1052,6 +1052,76 @@ func (t *ResourceType) CompositeInitializers() [][]Parameter {
 	return t.Initializers
 }
 
+// AttachmentType
+
+type AttachmentType struct {
+	Location            common.Location
+	QualifiedIdentifier string
+	BaseType            Type
+	Fields              []Field
+	Initializers        [][]Parameter
+}
+
+func NewAttachmentType(
+	location common.Location,
+	qualifiedIdentifier string,
+	baseType Type,
+	fields []Field,
+	initializers [][]Parameter,
+) *AttachmentType {
+	return &AttachmentType{
+		Location:            location,
+		QualifiedIdentifier: qualifiedIdentifier,
+		BaseType:            baseType,
+		Fields:              fields,
+		Initializers:        initializers,
+	}
+}
+
+func NewMeteredAttachmentType(
+	gauge common.MemoryGauge,
+	location common.Location,
+	qualifiedIdentifier string,
+	baseType Type,
+	fields []Field,
+	initializers [][]Parameter,
+) *AttachmentType {
+	common.UseMemory(gauge, common.CadenceAttachmentTypeMemoryUsage)
+	return NewAttachmentType(location, qualifiedIdentifier, baseType, fields, initializers)
+}
+
+func (*AttachmentType) isType() {}
+
+func (t *AttachmentType) ID() string {
+	if t.Location == nil {
+		return t.QualifiedIdentifier
+	}
+
+	return string(t.Location.TypeID(nil, t.QualifiedIdentifier))
+}
+
+func (*AttachmentType) isCompositeType() {}
+
+func (t *AttachmentType) CompositeTypeLocation() common.Location {
+	return t.Location
+}
+
+func (t *AttachmentType) CompositeTypeQualifiedIdentifier() string {
+	return t.QualifiedIdentifier
+}
+
+func (t *AttachmentType) CompositeFields() []Field {
+	return t.Fields
+}
+
+func (t *AttachmentType) SetCompositeFields(fields []Field) {
+	t.Fields = fields
+}
+
+func (t *AttachmentType) CompositeInitializers() [][]Parameter {
+	return t.Initializers
+}
+
 // EventType
 
 type EventType struct {
@@ -1396,9 +1466,18 @@ func (t *ContractInterfaceType) InterfaceInitializers() [][]Parameter {
 
 // Function
 
+// FunctionPurity indicates whether a function is known to not have side-effects
+type FunctionPurity int
+
+const (
+	FunctionPurityImpure FunctionPurity = iota
+	FunctionPurityView
+)
+
 // TODO: type parameters
 type FunctionType struct {
 	typeID     string
+	Purity     FunctionPurity
 	Parameters []Parameter
 	ReturnType Type
 }
@@ -1436,6 +1515,11 @@ func (t *FunctionType) WithID(id string) *FunctionType {
 	return t
 }
 
+func (t *FunctionType) WithPurity(purity FunctionPurity) *FunctionType {
+	t.Purity = purity
+	return t
+}
+
 // ReferenceType
 
 type ReferenceType struct {